@@ -23,9 +23,9 @@ func main() {
 	flag.Parse()
 
 	opts := &plugin.ServeOpts{
-		ProviderFunc: stripe.Provider,
-		Debug:        debugMode,
-		ProviderAddr: providerAddress,
+		GRPCProviderFunc: stripe.ProviderServer,
+		Debug:            debugMode,
+		ProviderAddr:     providerAddress,
 	}
 
 	plugin.Serve(opts)
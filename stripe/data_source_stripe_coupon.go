@@ -0,0 +1,35 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStripeCoupon() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceStripeCoupon())
+	s["coupon_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The ID of the coupon to look up.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStripeCouponRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	couponID := ExtractString(d, "coupon_id")
+
+	d.SetId(couponID)
+	if dg := resourceStripeCouponRead(ctx, d, m); dg.HasError() {
+		return dg
+	}
+	if d.Id() == "" {
+		return diag.Errorf("coupon %s not found", couponID)
+	}
+	return nil
+}
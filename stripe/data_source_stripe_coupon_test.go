@@ -0,0 +1,44 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeCoupon(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("SPRING", map[string]interface{}{
+		"object":      "coupon",
+		"name":        "Spring sale",
+		"percent_off": 25,
+		"duration":    "once",
+		"valid":       true,
+	})
+
+	lookup := func(couponID string) string {
+		return testAccConfig(t, standIn, nil, testAccDataSource(t, "stripe_coupon", "test", map[string]interface{}{
+			"coupon_id": couponID,
+		}))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: lookup("SPRING"),
+				Check: testAccCheckAttributes("data.stripe_coupon.test", map[string]string{
+					"id":          "SPRING",
+					"name":        "Spring sale",
+					"percent_off": "25",
+					"duration":    "once",
+				}),
+			},
+			{
+				Config:      lookup("WINTER"),
+				ExpectError: regexp.MustCompile(`coupon WINTER not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripeCustomers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeCustomersRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The Stripe Search query, e.g. email:'jenny@example.com' OR metadata['plan']:'partner'. " +
+					"See https://stripe.com/docs/search#search-query-language.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the customers matching the query.",
+			},
+			"customers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Customers matching the query.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The customer’s full name or business name.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The customer’s email address.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An arbitrary string attached to the customer.",
+						},
+						"phone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The customer’s phone number.",
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs attached to the object.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeCustomersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var ids []string
+	var customers []map[string]interface{}
+	var err error
+
+	query := ExtractString(d, "query")
+
+	params := &stripe.CustomerSearchParams{
+		SearchParams: stripe.SearchParams{Query: query},
+	}
+	err = c.retryWithBackOff(ctx, params, func() error {
+		ids, customers = nil, nil
+		i := c.Customers.Search(params)
+		for i.Next() {
+			customer := i.Customer()
+			ids = append(ids, customer.ID)
+			customers = append(customers, map[string]interface{}{
+				"id":          customer.ID,
+				"name":        customer.Name,
+				"email":       customer.Email,
+				"description": customer.Description,
+				"phone":       customer.Phone,
+				"metadata":    customer.Metadata,
+			})
+		}
+		return i.Err()
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(query)
+	return CallSet(
+		d.Set("ids", ids),
+		d.Set("customers", customers),
+	)
+}
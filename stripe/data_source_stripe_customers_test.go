@@ -0,0 +1,37 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeCustomers(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	for id, customer := range map[string]map[string]interface{}{
+		"cus_jane": {"name": "Jane Doe", "email": "jane@example.com", "metadata": map[string]interface{}{"team": "eu"}},
+		"cus_john": {"name": "John Doe", "email": "john@example.com", "metadata": map[string]interface{}{"team": "us"}},
+	} {
+		customer["object"] = "customer"
+		standIn.load(id, customer)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(t, standIn, nil,
+					testAccDataSource(t, "stripe_customers", "test", map[string]interface{}{
+						"query": "metadata['team']:'eu'",
+					})),
+				Check: testAccCheckAttributes("data.stripe_customers.test", map[string]string{
+					"ids.#":                     "1",
+					"ids.0":                     "cus_jane",
+					"customers.0.name":          "Jane Doe",
+					"customers.0.email":         "jane@example.com",
+					"customers.0.metadata.team": "eu",
+				}),
+			},
+		},
+	})
+}
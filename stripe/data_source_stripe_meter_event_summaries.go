@@ -0,0 +1,134 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripeMeterEventSummaries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeMeterEventSummariesRead,
+		Schema: map[string]*schema.Schema{
+			"meter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the meter the usage is aggregated by.",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The customer for which to fetch event summaries.",
+			},
+			"start_time": {
+				Type:     schema.TypeInt,
+				Required: true,
+				Description: "The timestamp from when to start aggregating meter events (inclusive). " +
+					"Must be aligned with minute boundaries.",
+			},
+			"end_time": {
+				Type:     schema.TypeInt,
+				Required: true,
+				Description: "The timestamp from when to stop aggregating meter events (exclusive). " +
+					"Must be aligned with minute boundaries.",
+			},
+			"value_grouping_window": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"day",
+					"hour",
+				}, false),
+				Description: "Specifies what granularity to use when generating event summaries, either day or hour. " +
+					"A single summary of the whole window is returned when not set.",
+			},
+			"aggregated_value": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The usage of the customer over the whole window, the sum of the summaries.",
+			},
+			"summaries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The aggregated usage of the customer per grouping window, in chronological order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"aggregated_value": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Aggregated value of all the events within start_time (inclusive) and end_time (exclusive).",
+						},
+						"start_time": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Start timestamp for this event summary (inclusive).",
+						},
+						"end_time": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "End timestamp for this event summary (exclusive).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeMeterEventSummariesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var summaries []map[string]interface{}
+	var aggregatedValue float64
+	var err error
+
+	meter := ExtractString(d, "meter")
+	customer := ExtractString(d, "customer")
+	startTime, endTime := ExtractInt64(d, "start_time"), ExtractInt64(d, "end_time")
+	if endTime <= startTime {
+		return diag.Errorf("end_time %d must be after start_time %d", endTime, startTime)
+	}
+
+	params := &stripe.BillingMeterEventSummaryListParams{
+		ID:        stripe.String(meter),
+		Customer:  stripe.String(customer),
+		StartTime: stripe.Int64(startTime),
+		EndTime:   stripe.Int64(endTime),
+	}
+	if window, set := d.GetOk("value_grouping_window"); set {
+		params.ValueGroupingWindow = stripe.String(ToString(window))
+	}
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		summaries, aggregatedValue = nil, 0
+		i := c.BillingMeterEventSummaries.List(params)
+		for i.Next() {
+			summary := i.BillingMeterEventSummary()
+			summaries = append(summaries, map[string]interface{}{
+				"id":               summary.ID,
+				"aggregated_value": summary.AggregatedValue,
+				"start_time":       summary.StartTime,
+				"end_time":         summary.EndTime,
+			})
+			aggregatedValue += summary.AggregatedValue
+		}
+		return i.Err()
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%d-%d", meter, customer, startTime, endTime))
+	return CallSet(
+		d.Set("aggregated_value", aggregatedValue),
+		d.Set("summaries", summaries),
+	)
+}
@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeMeterEventSummaries(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("mtr_standin", testAccMeterFixture)
+
+	const day = 24 * 60 * 60
+	start := 1767225600
+	var events []string
+	for i, event := range []struct {
+		customer  string
+		value     int
+		timestamp int
+	}{
+		{"cus_standin", 25, start},
+		{"cus_standin", 10, start + 60},
+		{"cus_standin", 5, start + day},
+		{"cus_other", 100, start},
+		{"cus_standin", 40, start + day + 60},
+	} {
+		events = append(events, testAccResource(t, "stripe_meter_event", fmt.Sprintf("seed_%d", i+1), map[string]interface{}{
+			"event_name": "api_requests",
+			"meter":      "mtr_standin",
+			"customer":   event.customer,
+			"value":      event.value,
+			"timestamp":  event.timestamp,
+			"identifier": fmt.Sprintf("seed-%d", i+1),
+		}))
+	}
+	events = append(events, testAccResource(t, "stripe_meter_event_adjustment", "test", map[string]interface{}{
+		"event_name": "api_requests",
+		"cancel": []interface{}{
+			map[string]interface{}{"identifier": testAccRef("stripe_meter_event.seed_5.identifier")},
+		},
+	}))
+
+	// the summaries are read once the events are recorded
+	summaries := append(events,
+		testAccDataSource(t, "stripe_meter_event_summaries", "daily", map[string]interface{}{
+			"meter":                 "mtr_standin",
+			"customer":              "cus_standin",
+			"start_time":            start,
+			"end_time":              start + 2*day,
+			"value_grouping_window": "day",
+		}),
+		testAccDataSource(t, "stripe_meter_event_summaries", "total", map[string]interface{}{
+			"meter":      "mtr_standin",
+			"customer":   "cus_other",
+			"start_time": start,
+			"end_time":   start + 2*day,
+		}),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(t, standIn, nil, events...),
+			},
+			{
+				Config: testAccConfig(t, standIn, nil, summaries...),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("data.stripe_meter_event_summaries.daily", map[string]string{
+						"aggregated_value":             "40",
+						"summaries.#":                  "2",
+						"summaries.0.aggregated_value": "35",
+						"summaries.0.end_time":         "1767312000",
+						"summaries.1.aggregated_value": "5",
+					}),
+					testAccCheckAttributes("data.stripe_meter_event_summaries.total", map[string]string{
+						"aggregated_value":             "100",
+						"summaries.#":                  "1",
+						"summaries.0.aggregated_value": "100",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripePrice() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceStripePrice(), "transfer_lookup_key", "on_destroy")
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "lookup_key"},
+		Description:  "The ID of the price to look up.",
+	}
+	s["lookup_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The lookup key of the active price to look up.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStripePriceRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	priceID := ExtractString(d, "id")
+	if priceID == "" {
+		lookupKey := ExtractString(d, "lookup_key")
+
+		params := &stripe.PriceListParams{
+			LookupKeys: stripe.StringSlice([]string{lookupKey}),
+		}
+		err = c.retryWithBackOff(ctx, params, func() error {
+			priceID = ""
+			i := c.Prices.List(params)
+			for i.Next() {
+				priceID = i.Price().ID
+			}
+			return i.Err()
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if priceID == "" {
+			return diag.Errorf("no active price with lookup key %q", lookupKey)
+		}
+	}
+
+	d.SetId(priceID)
+	if dg := resourceStripePriceRead(ctx, d, m); dg.HasError() {
+		return dg
+	}
+	if d.Id() == "" {
+		return diag.Errorf("price %s not found", priceID)
+	}
+	return nil
+}
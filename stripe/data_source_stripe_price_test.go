@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripePrice(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	for id, lookupKey := range map[string]string{"price_monthly": "gold_monthly", "price_yearly": "gold_yearly"} {
+		standIn.load(id, map[string]interface{}{
+			"object":              "price",
+			"product":             "prod_gold",
+			"active":              true,
+			"currency":            "usd",
+			"unit_amount":         1000,
+			"unit_amount_decimal": "1000",
+			"billing_scheme":      "per_unit",
+			"type":                "one_time",
+			"lookup_key":          lookupKey,
+		})
+	}
+
+	lookup := func(values map[string]interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccDataSource(t, "stripe_price", "test", values))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: lookup(map[string]interface{}{"lookup_key": "gold_yearly"}),
+				Check: testAccCheckAttributes("data.stripe_price.test", map[string]string{
+					"id":          "price_yearly",
+					"lookup_key":  "gold_yearly",
+					"product":     "prod_gold",
+					"unit_amount": "1000",
+				}),
+			},
+			{
+				Config: lookup(map[string]interface{}{"id": "price_monthly"}),
+				Check: testAccCheckAttributes("data.stripe_price.test", map[string]string{
+					"id":         "price_monthly",
+					"lookup_key": "gold_monthly",
+				}),
+			},
+			{
+				Config:      lookup(map[string]interface{}{"lookup_key": "gold_weekly"}),
+				ExpectError: regexp.MustCompile(`no active price with lookup key "gold_weekly"`),
+			},
+			{
+				Config:      lookup(map[string]interface{}{"id": "price_missing"}),
+				ExpectError: regexp.MustCompile(`price price_missing not found`),
+			},
+		},
+	})
+}
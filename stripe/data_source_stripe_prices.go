@@ -0,0 +1,143 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripePrices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripePricesRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The Stripe Search query, e.g. product:'prod_123' AND active:'true'. " +
+					"See https://stripe.com/docs/search#search-query-language.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the prices matching the query.",
+			},
+			"prices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Prices matching the query.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"product": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the product this price is associated with.",
+						},
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the price can be used for new purchases.",
+						},
+						"currency": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Three-letter ISO currency code, in lowercase.",
+						},
+						"unit_amount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The unit amount in cents to be charged.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of one_time or recurring.",
+						},
+						"interval": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The billing frequency of a recurring price. Either day, week, month or year.",
+						},
+						"interval_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of intervals between billings of a recurring price.",
+						},
+						"lookup_key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A lookup key used to retrieve prices dynamically from a static string.",
+						},
+						"nickname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A brief description of the price, hidden from customers.",
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs attached to the object.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripePricesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var ids []string
+	var prices []map[string]interface{}
+	var err error
+
+	query := ExtractString(d, "query")
+
+	params := &stripe.PriceSearchParams{
+		SearchParams: stripe.SearchParams{Query: query},
+	}
+	err = c.retryWithBackOff(ctx, params, func() error {
+		ids, prices = nil, nil
+		i := c.Prices.Search(params)
+		for i.Next() {
+			price := i.Price()
+			p := map[string]interface{}{
+				"id":          price.ID,
+				"active":      price.Active,
+				"currency":    price.Currency,
+				"unit_amount": price.UnitAmount,
+				"type":        price.Type,
+				"lookup_key":  price.LookupKey,
+				"nickname":    price.Nickname,
+				"metadata":    price.Metadata,
+			}
+			if price.Product != nil {
+				p["product"] = price.Product.ID
+			}
+			if price.Recurring != nil {
+				p["interval"] = price.Recurring.Interval
+				p["interval_count"] = price.Recurring.IntervalCount
+			}
+			ids = append(ids, price.ID)
+			prices = append(prices, p)
+		}
+		return i.Err()
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(query)
+	return CallSet(
+		d.Set("ids", ids),
+		d.Set("prices", prices),
+	)
+}
@@ -0,0 +1,52 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripePrices(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	for id, price := range map[string]map[string]interface{}{
+		"price_monthly": {"product": "prod_gold", "unit_amount": 1000, "recurring": map[string]interface{}{
+			"interval": "month", "interval_count": 1,
+		}},
+		"price_once":   {"product": "prod_gold", "unit_amount": 5000},
+		"price_silver": {"product": "prod_silver", "unit_amount": 500},
+	} {
+		price["object"] = "price"
+		price["active"] = true
+		price["currency"] = "usd"
+		price["type"] = "one_time"
+		if price["recurring"] != nil {
+			price["type"] = "recurring"
+		}
+		standIn.load(id, price)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(t, standIn, nil,
+					testAccDataSource(t, "stripe_prices", "test", map[string]interface{}{
+						"query": "product:'prod_gold' AND active:'true'",
+					})),
+				Check: testAccCheckAttributes("data.stripe_prices.test", map[string]string{
+					"ids.#":                   "2",
+					"ids.0":                   "price_monthly",
+					"ids.1":                   "price_once",
+					"prices.0.product":        "prod_gold",
+					"prices.0.type":           "recurring",
+					"prices.0.interval":       "month",
+					"prices.0.interval_count": "1",
+					"prices.0.unit_amount":    "1000",
+					"prices.1.type":           "one_time",
+					"prices.1.interval":       "",
+					"prices.1.unit_amount":    "5000",
+				}),
+			},
+		},
+	})
+}
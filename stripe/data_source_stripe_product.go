@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripeProduct() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceStripeProduct())
+	s["product_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"product_id", "metadata_filter"},
+		Description:  "The ID of the product to look up.",
+	}
+	s["metadata_filter"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "Key-value pairs the product metadata has to contain. " +
+			"Exactly one product has to match the filter.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStripeProductRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	productID := ExtractString(d, "product_id")
+	if productID == "" {
+		filter := ExtractMap(d, "metadata_filter")
+		var matches []string
+
+		params := &stripe.ProductListParams{}
+		err = c.retryWithBackOff(ctx, params, func() error {
+			matches = nil
+			i := c.Products.List(params)
+			for i.Next() {
+				if matchesMetadata(i.Product().Metadata, filter) {
+					matches = append(matches, i.Product().ID)
+				}
+			}
+			return i.Err()
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no product matches the metadata filter")
+		case 1:
+			productID = matches[0]
+		default:
+			return diag.Errorf("metadata filter matches %d products (%s), exactly one is expected",
+				len(matches), strings.Join(matches, ", "))
+		}
+	}
+
+	d.SetId(productID)
+	if dg := resourceStripeProductRead(ctx, d, m); dg.HasError() {
+		return dg
+	}
+	if d.Id() == "" {
+		return diag.Errorf("product %s not found", productID)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeProduct(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("prod_gold", map[string]interface{}{
+		"object":   "product",
+		"name":     "Gold",
+		"active":   true,
+		"metadata": map[string]interface{}{"tier": "gold", "plan": "team"},
+	})
+	standIn.load("prod_silver", map[string]interface{}{
+		"object":   "product",
+		"name":     "Silver",
+		"active":   true,
+		"metadata": map[string]interface{}{"tier": "silver", "plan": "team"},
+	})
+
+	lookup := func(values map[string]interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccDataSource(t, "stripe_product", "test", values))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: lookup(map[string]interface{}{"product_id": "prod_silver"}),
+				Check: testAccCheckAttributes("data.stripe_product.test", map[string]string{
+					"id":            "prod_silver",
+					"name":          "Silver",
+					"metadata.tier": "silver",
+				}),
+			},
+			{
+				Config: lookup(map[string]interface{}{
+					"metadata_filter": map[string]interface{}{"tier": "gold", "plan": "team"},
+				}),
+				Check: testAccCheckAttributes("data.stripe_product.test", map[string]string{
+					"id":         "prod_gold",
+					"product_id": "prod_gold",
+					"name":       "Gold",
+				}),
+			},
+			{
+				Config:      lookup(map[string]interface{}{"metadata_filter": map[string]interface{}{"tier": "bronze"}}),
+				ExpectError: regexp.MustCompile(`no product matches the metadata filter`),
+			},
+			{
+				Config: lookup(map[string]interface{}{"metadata_filter": map[string]interface{}{"plan": "team"}}),
+				ExpectError: regexp.MustCompile(
+					`metadata filter matches 2 products \(prod_gold, prod_silver\), exactly one is expected`),
+			},
+			{
+				Config:      lookup(map[string]interface{}{"product_id": "prod_missing"}),
+				ExpectError: regexp.MustCompile(`product prod_missing not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripeProducts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeProductsRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The Stripe Search query, e.g. metadata['tier']:'gold' AND active:'true'. " +
+					"See https://stripe.com/docs/search#search-query-language.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the products matching the query.",
+			},
+			"products": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Products matching the query.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the object.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The product’s name, meant to be displayable to the customer.",
+						},
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the product is currently available for purchase.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The product’s description, meant to be displayable to the customer.",
+						},
+						"default_price": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the default price this product is associated with.",
+						},
+						"tax_code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A tax code ID.",
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs attached to the object.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStripeProductsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var ids []string
+	var products []map[string]interface{}
+	var err error
+
+	query := ExtractString(d, "query")
+
+	params := &stripe.ProductSearchParams{
+		SearchParams: stripe.SearchParams{Query: query},
+	}
+	err = c.retryWithBackOff(ctx, params, func() error {
+		ids, products = nil, nil
+		i := c.Products.Search(params)
+		for i.Next() {
+			product := i.Product()
+			ids = append(ids, product.ID)
+			products = append(products, map[string]interface{}{
+				"id":          product.ID,
+				"name":        product.Name,
+				"active":      product.Active,
+				"description": product.Description,
+				"default_price": func() string {
+					if product.DefaultPrice != nil {
+						return product.DefaultPrice.ID
+					}
+					return ""
+				}(),
+				"tax_code": func() string {
+					if product.TaxCode != nil {
+						return product.TaxCode.ID
+					}
+					return ""
+				}(),
+				"metadata": product.Metadata,
+			})
+		}
+		return i.Err()
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(query)
+	return CallSet(
+		d.Set("ids", ids),
+		d.Set("products", products),
+	)
+}
@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeProducts(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	for id, product := range map[string]map[string]interface{}{
+		"prod_gold":     {"name": "Gold", "active": true, "metadata": map[string]interface{}{"tier": "gold"}},
+		"prod_gold_eu":  {"name": "Gold EU", "active": true, "metadata": map[string]interface{}{"tier": "gold"}},
+		"prod_gold_old": {"name": "Gold 2023", "active": false, "metadata": map[string]interface{}{"tier": "gold"}},
+		"prod_silver":   {"name": "Silver", "active": true, "metadata": map[string]interface{}{"tier": "silver"}},
+	} {
+		product["object"] = "product"
+		standIn.load(id, product)
+	}
+
+	search := func(query string) string {
+		return testAccConfig(t, standIn, nil, testAccDataSource(t, "stripe_products", "test", map[string]interface{}{
+			"query": query,
+		}))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: search("metadata['tier']:'gold' AND active:'true'"),
+				Check: testAccCheckAttributes("data.stripe_products.test", map[string]string{
+					"ids.#":                    "2",
+					"ids.0":                    "prod_gold",
+					"ids.1":                    "prod_gold_eu",
+					"products.#":               "2",
+					"products.1.name":          "Gold EU",
+					"products.1.active":        "true",
+					"products.1.metadata.tier": "gold",
+				}),
+			},
+			{
+				Config: search("metadata['tier']:'bronze'"),
+				Check: testAccCheckAttributes("data.stripe_products.test", map[string]string{
+					"ids.#":      "0",
+					"products.#": "0",
+				}),
+			},
+			{
+				Config:      search("name~'Gold'"),
+				ExpectError: regexp.MustCompile(`doesn't support the search clause`),
+			},
+		},
+	})
+}
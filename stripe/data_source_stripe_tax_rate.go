@@ -0,0 +1,110 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func dataSourceStripeTaxRate() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourceStripeTaxRate())
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		AtLeastOneOf: []string{"id", "jurisdiction", "percentage"},
+		Description:  "The ID of the tax rate to look up.",
+	}
+	s["jurisdiction"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The jurisdiction of the active tax rate to look up.",
+	}
+	s["percentage"] = &schema.Schema{
+		Type:        schema.TypeFloat,
+		Optional:    true,
+		Computed:    true,
+		Description: "The percentage of the active tax rate to look up.",
+	}
+	s["country"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Two-letter country code (ISO 3166-1 alpha-2) of the active tax rate to look up.",
+	}
+	s["state"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "ISO 3166-2 subdivision code, without country prefix, of the active tax rate to look up.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceStripeTaxRateRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	taxRateID := ExtractString(d, "id")
+	if taxRateID == "" {
+		var matches []string
+
+		params := &stripe.TaxRateListParams{Active: stripe.Bool(true)}
+		err = c.retryWithBackOff(ctx, params, func() error {
+			matches = nil
+			i := c.TaxRates.List(params)
+			for i.Next() {
+				if taxRateMatches(d, i.TaxRate()) {
+					matches = append(matches, i.TaxRate().ID)
+				}
+			}
+			return i.Err()
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no active tax rate matches the given arguments")
+		case 1:
+			taxRateID = matches[0]
+		default:
+			return diag.Errorf("%d active tax rates (%s) match the given arguments, exactly one is expected",
+				len(matches), strings.Join(matches, ", "))
+		}
+	}
+
+	d.SetId(taxRateID)
+	if dg := resourceStripeTaxRateRead(ctx, d, m); dg.HasError() {
+		return dg
+	}
+	if d.Id() == "" {
+		return diag.Errorf("tax rate %s not found", taxRateID)
+	}
+	return nil
+}
+
+func taxRateMatches(d *schema.ResourceData, taxRate *stripe.TaxRate) bool {
+	if jurisdiction, set := d.GetOk("jurisdiction"); set && ToString(jurisdiction) != taxRate.Jurisdiction {
+		return false
+	}
+	if percentage, set := d.GetOk("percentage"); set && ToFloat64(percentage) != taxRate.Percentage {
+		return false
+	}
+	if country, set := d.GetOk("country"); set && ToString(country) != taxRate.Country {
+		return false
+	}
+	if state, set := d.GetOk("state"); set && ToString(state) != taxRate.State {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStripeTaxRate(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	for id, taxRate := range map[string]map[string]interface{}{
+		"txr_de":      {"jurisdiction": "DE", "country": "DE", "percentage": 19, "active": true},
+		"txr_fr":      {"jurisdiction": "FR", "country": "FR", "percentage": 20, "active": true},
+		"txr_fr_old":  {"jurisdiction": "FR", "country": "FR", "percentage": 19.6, "active": false},
+		"txr_at":      {"jurisdiction": "AT", "country": "AT", "percentage": 20, "active": true},
+		"txr_de_food": {"jurisdiction": "DE", "country": "DE", "percentage": 7, "active": true},
+	} {
+		taxRate["object"] = "tax_rate"
+		taxRate["display_name"] = "VAT"
+		taxRate["inclusive"] = false
+		standIn.load(id, taxRate)
+	}
+
+	lookup := func(values map[string]interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccDataSource(t, "stripe_tax_rate", "test", values))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the inactive rate of the jurisdiction isn't a match
+				Config: lookup(map[string]interface{}{"jurisdiction": "FR"}),
+				Check: testAccCheckAttributes("data.stripe_tax_rate.test", map[string]string{
+					"id":           "txr_fr",
+					"percentage":   "20",
+					"display_name": "VAT",
+				}),
+			},
+			{
+				Config: lookup(map[string]interface{}{"jurisdiction": "DE", "percentage": 7}),
+				Check: testAccCheckAttributes("data.stripe_tax_rate.test", map[string]string{
+					"id": "txr_de_food",
+				}),
+			},
+			{
+				Config:      lookup(map[string]interface{}{"jurisdiction": "IT"}),
+				ExpectError: regexp.MustCompile(`no active tax rate matches the given arguments`),
+			},
+			{
+				Config: lookup(map[string]interface{}{"percentage": 20}),
+				ExpectError: regexp.MustCompile(
+					`2 active tax rates \(txr_at, txr_fr\) match the given arguments, exactly one is expected`),
+			},
+		},
+	})
+}
@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78/webhook"
+)
+
+func dataSourceStripeWebhookSignature() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStripeWebhookSignatureRead,
+		Schema: map[string]*schema.Schema{
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The endpoint’s secret (whsec_...), e.g. the secret of a stripe_webhook_endpoint.",
+			},
+			"payload": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The raw body of the webhook request, usually a JSON encoded event.",
+			},
+			"timestamp": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Time of the signature, measured in seconds since the Unix epoch. " +
+					"Defaults to the current time.",
+			},
+			"verify_header": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "A Stripe-Signature header to verify against the payload and the secret, " +
+					"the result is exported as valid.",
+			},
+			"tolerance": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(webhook.DefaultTolerance / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum age of the verified header's timestamp in seconds, " +
+					"0 accepts headers of any age. Defaults to 300.",
+			},
+			"signature": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hex encoded HMAC-SHA256 signature (v1) of the payload at timestamp.",
+			},
+			"header": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Stripe-Signature header of the payload at timestamp, e.g. t=1700000000,v1=5257a869....",
+			},
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether verify_header is a valid signature of the payload. " +
+					"Always true without verify_header.",
+			},
+			"validation_error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason verify_header isn't valid, empty when it's valid.",
+			},
+		},
+	}
+}
+
+// dataSourceStripeWebhookSignatureRead signs and verifies webhook payloads locally,
+// it doesn't make any request to Stripe.
+func dataSourceStripeWebhookSignatureRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	payload := []byte(ExtractString(d, "payload"))
+	secret := ExtractString(d, "secret")
+
+	timestamp := time.Now()
+	if t, set := d.GetOk("timestamp"); set {
+		timestamp = time.Unix(ToInt64(t), 0)
+	}
+	signature := hex.EncodeToString(webhook.ComputeSignature(timestamp, payload, secret))
+	header := fmt.Sprintf("t=%d,v1=%s", timestamp.Unix(), signature)
+
+	var validationErr error
+	if verifyHeader, set := d.GetOk("verify_header"); set {
+		tolerance := time.Duration(ExtractInt(d, "tolerance")) * time.Second
+		if tolerance == 0 {
+			validationErr = webhook.ValidatePayloadIgnoringTolerance(payload, ToString(verifyHeader), secret)
+		} else {
+			validationErr = webhook.ValidatePayloadWithTolerance(payload, ToString(verifyHeader), secret, tolerance)
+		}
+	}
+
+	d.SetId(signature)
+	return CallSet(
+		d.Set("timestamp", timestamp.Unix()),
+		d.Set("signature", signature),
+		d.Set("header", header),
+		d.Set("valid", validationErr == nil),
+		func() error {
+			if validationErr != nil {
+				return d.Set("validation_error", validationErr.Error())
+			}
+			return d.Set("validation_error", "")
+		}(),
+	)
+}
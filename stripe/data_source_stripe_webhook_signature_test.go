@@ -0,0 +1,84 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDataSourceStripeWebhookSignature(t *testing.T) {
+	const (
+		secret  = "whsec_standin"
+		payload = `{"id":"evt_standin","object":"event","type":"invoice.paid"}`
+	)
+
+	signed := testAccReadDataSource(t, "stripe_webhook_signature", map[string]interface{}{
+		"secret":    secret,
+		"payload":   payload,
+		"timestamp": 1767225600,
+	})
+	header := signed.Attributes["header"]
+	if !strings.HasPrefix(header, "t=1767225600,v1=") || signed.Attributes["valid"] != "true" {
+		t.Fatalf("unexpected signature: %v", signed.Attributes)
+	}
+
+	testCases := map[string]struct {
+		config map[string]interface{}
+		valid  string
+	}{
+		"signed header": {
+			config: map[string]interface{}{"verify_header": header, "tolerance": 0},
+			valid:  "true",
+		},
+		"expired header": {
+			config: map[string]interface{}{"verify_header": header},
+			valid:  "false",
+		},
+		"other payload": {
+			config: map[string]interface{}{"verify_header": header, "tolerance": 0, "payload": "{}"},
+			valid:  "false",
+		},
+		"other secret": {
+			config: map[string]interface{}{"verify_header": header, "tolerance": 0, "secret": "whsec_other"},
+			valid:  "false",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{"secret": secret, "payload": payload}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			state := testAccReadDataSource(t, "stripe_webhook_signature", config)
+			if state.Attributes["valid"] != tc.valid {
+				t.Fatalf("expected valid %s, got %v", tc.valid, state.Attributes)
+			}
+			if (tc.valid == "true") != (state.Attributes["validation_error"] == "") {
+				t.Fatalf("unexpected validation error %q", state.Attributes["validation_error"])
+			}
+		})
+	}
+}
+
+// testAccReadDataSource reads the data source with the given configuration, without any Stripe API access.
+func testAccReadDataSource(t *testing.T, name string, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	r := Provider().DataSourcesMap[name]
+	c := terraform.NewResourceConfigRaw(config)
+	if diags := r.Validate(c); diags.HasError() {
+		t.Fatalf("validate: %v", diags)
+	}
+	diff, err := r.Diff(context.Background(), nil, c, nil)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	state, diags := r.ReadDataApply(context.Background(), diff, nil)
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	return state
+}
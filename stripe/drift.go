@@ -0,0 +1,178 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// driftPolicyStrict reads back every attribute, changes made by Stripe show as differences in the plan.
+	driftPolicyStrict = "strict"
+	// driftPolicyIgnoreStripeManaged keeps the Stripe-mutated attributes as known to Terraform
+	// and reports changes of managed attributes made outside of Terraform as warnings.
+	driftPolicyIgnoreStripeManaged = "ignore_stripe_managed"
+)
+
+var driftPolicies = []string{driftPolicyStrict, driftPolicyIgnoreStripeManaged}
+
+// stripeMutatedAttributes lists the arguments Stripe changes in the normal course of business,
+// they aren't drift, e.g. the invoice sequence of a customer grows with every invoice.
+// Attributes which are computed only are never drift, any other argument is managed by Terraform.
+var stripeMutatedAttributes = map[string][]string{
+	// the balance follows the invoices, the sequence grows with them
+	"stripe_customer": {"balance", "invoice_prefix", "next_invoice_sequence"},
+	// expired or fully redeemed promotion codes are deactivated
+	"stripe_promotion_code": {"active"},
+	// endpoints failing to receive events for days are disabled
+	"stripe_webhook_endpoint": {"disabled"},
+}
+
+// withDriftPolicy applies the drift policy of the provider to the resource.
+// Stripe-mutated attributes keep the value known to Terraform across refreshes and applies,
+// managed attributes changed outside of Terraform are listed in a warning on refresh.
+func withDriftPolicy(name string, r *schema.Resource) {
+	mutated := stripeMutatedAttributes[name]
+	var managed []string
+	for k, s := range r.Schema {
+		if (s.Optional || s.Required) && !containsString(mutated, k) {
+			managed = append(managed, k)
+		}
+	}
+	sort.Strings(managed)
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if m.(*stripeClient).driftPolicy != driftPolicyIgnoreStripeManaged {
+			return read(ctx, d, m)
+		}
+
+		prior := d.State().Attributes
+		known := map[string]interface{}{}
+		for _, k := range mutated {
+			if hasDriftAttribute(prior, k) {
+				known[k] = d.Get(k)
+			}
+		}
+
+		dg := read(ctx, d, m)
+		if dg.HasError() || d.Id() == "" {
+			return dg
+		}
+
+		var changedMutated []string
+		for _, k := range mutated {
+			if v, ok := known[k]; ok && driftAttributeChanged(prior, d.State().Attributes, k) {
+				changedMutated = append(changedMutated, k)
+				if err := d.Set(k, v); err != nil {
+					return append(dg, diag.FromErr(err)...)
+				}
+			}
+		}
+
+		var changed []string
+		for _, k := range managed {
+			if hasDriftAttribute(prior, k) && driftAttributeChanged(prior, d.State().Attributes, k) {
+				changed = append(changed, k)
+			}
+		}
+		if len(changed) > 0 {
+			detail := fmt.Sprintf("Changed attributes: %s.", strings.Join(changed, ", "))
+			if len(changedMutated) > 0 {
+				detail += fmt.Sprintf(" Attributes managed by Stripe are kept as known to Terraform: %s.",
+					strings.Join(changedMutated, ", "))
+			}
+			dg = append(dg, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s %s has been changed outside of Terraform", name, d.Id()),
+				Detail: detail + " The next apply reverts the changes unless the configuration is updated " +
+					"to match them.",
+			})
+		}
+		return dg
+	}
+
+	// the values planned for Stripe-mutated attributes are kept, Stripe may have moved on already
+	keepPlanned := func(apply schema.CreateContextFunc) schema.CreateContextFunc {
+		if apply == nil || len(mutated) == 0 {
+			return apply
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if m.(*stripeClient).driftPolicy != driftPolicyIgnoreStripeManaged {
+				return apply(ctx, d, m)
+			}
+
+			planned := map[string]interface{}{}
+			for _, k := range mutated {
+				planned[k] = d.Get(k)
+			}
+			dg := apply(ctx, d, m)
+			if dg.HasError() || d.Id() == "" {
+				return dg
+			}
+			for k, v := range planned {
+				if err := d.Set(k, v); err != nil {
+					return append(dg, diag.FromErr(err)...)
+				}
+			}
+			return dg
+		}
+	}
+	r.CreateContext = keepPlanned(r.CreateContext)
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(keepPlanned(schema.CreateContextFunc(r.UpdateContext)))
+	}
+}
+
+// hasDriftAttribute reports whether the attribute is part of the state, imported objects don't have
+// any state to compare with.
+func hasDriftAttribute(state map[string]string, key string) bool {
+	for k := range state {
+		if k == key || strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// driftAttributeChanged compares the flattened values of the attribute in both states.
+func driftAttributeChanged(before, after map[string]string, key string) bool {
+	values := func(state map[string]string) map[string]string {
+		v := map[string]string{}
+		for k, value := range state {
+			if k != key && !strings.HasPrefix(k, key+".") {
+				continue
+			}
+			// an empty collection and a missing one are the same to Terraform
+			if (strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%")) && value == "0" {
+				continue
+			}
+			v[k] = value
+		}
+		return v
+	}
+
+	b, a := values(before), values(after)
+	if len(b) != len(a) {
+		return true
+	}
+	for k, v := range b {
+		if a[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDriftPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policy string
+		// sequence is the refreshed next_invoice_sequence.
+		sequence string
+		// stripeSequence is the sequence kept by Stripe once the configuration is applied again.
+		stripeSequence int64
+	}{
+		"strict": {
+			policy:         driftPolicyStrict,
+			sequence:       "1003",
+			stripeSequence: 1001,
+		},
+		"ignore Stripe managed": {
+			policy:         driftPolicyIgnoreStripeManaged,
+			sequence:       "1001",
+			stripeSequence: 1003,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			config := testAccConfig(t, standIn, map[string]interface{}{"drift_policy": tc.policy},
+				testAccResource(t, "stripe_customer", "test", map[string]interface{}{
+					"name":                  "Jane Doe",
+					"email":                 "jane@example.com",
+					"next_invoice_sequence": 1001,
+				}))
+
+			state := &terraform.InstanceState{}
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  testAccKeepState("stripe_customer.test", state),
+					},
+					{
+						// two invoices and a dashboard edit later
+						PreConfig: func() {
+							customer, _ := standIn.object(state.ID)
+							customer["next_invoice_sequence"] = 1003
+							customer["balance"] = 500
+							customer["email"] = "jane.doe@example.com"
+							standIn.load(state.ID, customer)
+						},
+						RefreshState:       true,
+						ExpectNonEmptyPlan: true,
+						Check: testAccCheckAttributes("stripe_customer.test", map[string]string{
+							"next_invoice_sequence": tc.sequence,
+							"email":                 "jane.doe@example.com",
+						}),
+					},
+					{
+						// only the dashboard edit is reverted, a Stripe managed sequence isn't reset
+						Config: config,
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckAttributes("stripe_customer.test", map[string]string{
+								"email":                 "jane@example.com",
+								"next_invoice_sequence": "1001",
+							}),
+							func(*terraform.State) error {
+								customer, _ := standIn.object(state.ID)
+								if sequence := standInInt64(customer["next_invoice_sequence"]); sequence != tc.stripeSequence {
+									return fmt.Errorf("expected Stripe to keep the sequence %d, got %d",
+										tc.stripeSequence, sequence)
+								}
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDriftPolicyWarning(t *testing.T) {
+	testCases := map[string]struct {
+		policy  string
+		warning string
+	}{
+		"strict": {
+			policy: driftPolicyStrict,
+		},
+		"ignore Stripe managed": {
+			policy: driftPolicyIgnoreStripeManaged,
+			warning: "Changed attributes: email. Attributes managed by Stripe are kept as known to Terraform: " +
+				"balance, next_invoice_sequence.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			// two invoices and a dashboard edit later
+			standIn.load("cus_standin", map[string]interface{}{
+				"object":                "customer",
+				"name":                  "Jane Doe",
+				"email":                 "jane.doe@example.com",
+				"next_invoice_sequence": 1003,
+				"balance":               500,
+			})
+			meta := testAccConfigure(t, map[string]interface{}{
+				"api_base_url": standIn.URL,
+				"drift_policy": tc.policy,
+			})
+			state := &terraform.InstanceState{ID: "cus_standin", Attributes: map[string]string{
+				"name":                  "Jane Doe",
+				"email":                 "jane@example.com",
+				"next_invoice_sequence": "1001",
+				"balance":               "0",
+			}}
+
+			_, diags := Provider().ResourcesMap["stripe_customer"].RefreshWithoutUpgrade(context.Background(), state, meta)
+			if diags.HasError() {
+				t.Fatalf("refresh: %v", diags)
+			}
+			switch {
+			case tc.warning == "" && testAccHasWarning(diags):
+				t.Fatalf("expected no warning, got %v", diags)
+			case tc.warning != "" && (len(diags) != 1 || diags[0].Severity != diag.Warning ||
+				!strings.HasPrefix(diags[0].Detail, tc.warning)):
+				t.Fatalf("expected a warning %q, got %v", tc.warning, diags)
+			}
+		})
+	}
+}
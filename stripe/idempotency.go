@@ -0,0 +1,433 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	// pendingCreatePrefix starts the identifier of the state kept for a create which failed before Stripe
+	// returned the object.
+	pendingCreatePrefix = "pending-create:"
+	// idempotencyKeyTTL is how long Stripe replays the response of an idempotency key.
+	idempotencyKeyTTL = 24 * time.Hour
+)
+
+// idempotencyKey is the key of a create call, the call is its position among the create calls of the apply.
+type idempotencyKey struct {
+	key       string
+	call      int
+	createdAt int64
+}
+
+// idempotencyKeys hands out the keys of the create calls made by the apply of a new resource instance.
+// The unsettled keys of a failed apply are reused by the next one, so a create Stripe accepted without the
+// provider receiving the response is replayed instead of duplicated.
+type idempotencyKeys struct {
+	mu      sync.Mutex
+	reused  map[int]idempotencyKey
+	sent    []idempotencyKey
+	settled map[string]bool
+}
+
+type idempotencyKeysContextKey struct{}
+
+// newIdempotencyKeys returns the keys of an apply, the previous keys younger than Stripe's expiry are reused.
+func newIdempotencyKeys(previous []idempotencyKey, now time.Time) *idempotencyKeys {
+	keys := &idempotencyKeys{reused: map[int]idempotencyKey{}, settled: map[string]bool{}}
+	for _, key := range previous {
+		if now.Sub(time.Unix(key.createdAt, 0)) < idempotencyKeyTTL {
+			keys.reused[key.call] = key
+		}
+	}
+	return keys
+}
+
+func idempotencyKeysFrom(ctx context.Context) *idempotencyKeys {
+	keys, _ := ctx.Value(idempotencyKeysContextKey{}).(*idempotencyKeys)
+	return keys
+}
+
+// newIdempotencyKey returns the idempotency key of a create call.
+// The key is set on the params before retryWithBackOff runs, so retries of a request Stripe may already have
+// accepted replay the original response instead of creating a duplicate. Applying a new resource instance again
+// after a failure reuses the key of the same call, any other create, e.g. of a replaced resource or of another
+// count instance with the same arguments, gets a key of its own.
+func newIdempotencyKey(ctx context.Context) *string {
+	keys := idempotencyKeysFrom(ctx)
+	if keys == nil {
+		return randomIdempotencyKey()
+	}
+
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+	call := len(keys.sent)
+	key, ok := keys.reused[call]
+	if !ok {
+		random := randomIdempotencyKey()
+		if random == nil {
+			return nil
+		}
+		key = idempotencyKey{key: *random, call: call, createdAt: time.Now().Unix()}
+	}
+	keys.sent = append(keys.sent, key)
+	return stripe.String(key.key)
+}
+
+func randomIdempotencyKey() *string {
+	key, err := uuid.GenerateUUID()
+	if err != nil {
+		// stripe-go generates a key of its own for retried requests
+		return nil
+	}
+	return stripe.String("terraform-" + key)
+}
+
+// settle records the outcome of the call made with the params. The key of a call answered by Stripe, successfully
+// or not, is never sent again by another apply: only a request whose response never arrived is worth replaying.
+func (k *idempotencyKeys) settle(params stripeAccountSetter, err error) {
+	key := idempotencyKeyOf(params)
+	if k == nil || key == "" {
+		return
+	}
+	if stripeErr := toStripeError(err); err != nil &&
+		(stripeErr == nil || stripeErr.HTTPStatusCode == 0 || stripeErr.Code == stripe.ErrorCodeIdempotencyKeyInUse) {
+		return
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.settled[key] = true
+}
+
+// rotate replaces the key reused from a failed apply when Stripe refuses it for arguments changed since,
+// the call is then made with a new key.
+func (k *idempotencyKeys) rotate(ctx context.Context, params stripeAccountSetter, err error) bool {
+	stripeErr := toStripeError(err)
+	p, ok := params.(stripe.ParamsContainer)
+	if k == nil || !ok || stripeErr == nil || stripeErr.Type != stripe.ErrorTypeIdempotency {
+		return false
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i, key := range k.sent {
+		previous, reused := k.reused[key.call]
+		if !reused || previous.key != idempotencyKeyOf(params) {
+			continue
+		}
+		random := randomIdempotencyKey()
+		if random == nil {
+			return false
+		}
+		tflog.Warn(ctx, "Idempotency key of a failed apply refused, the create is made with a new key", map[string]interface{}{
+			"error": err.Error(),
+		})
+		delete(k.reused, key.call)
+		k.sent[i] = idempotencyKey{key: *random, call: key.call, createdAt: time.Now().Unix()}
+		p.GetParams().IdempotencyKey = random
+		return true
+	}
+	return false
+}
+
+// pending returns the keys sent without a response from Stripe.
+func (k *idempotencyKeys) pending() []idempotencyKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var pending []idempotencyKey
+	for _, key := range k.sent {
+		if !k.settled[key.key] {
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
+
+func idempotencyKeyOf(params stripeAccountSetter) string {
+	if p, ok := params.(stripe.ParamsContainer); ok && p.GetParams().IdempotencyKey != nil {
+		return *p.GetParams().IdempotencyKey
+	}
+	return ""
+}
+
+// pendingCreateID returns the identifier of a pending create, it carries the keys of the unanswered calls as
+// <call>:<created_at>:<key> separated by commas.
+func pendingCreateID(keys []idempotencyKey) string {
+	encoded := make([]string, len(keys))
+	for i, key := range keys {
+		encoded[i] = fmt.Sprintf("%d:%d:%s", key.call, key.createdAt, key.key)
+	}
+	return pendingCreatePrefix + strings.Join(encoded, ",")
+}
+
+// parsePendingCreateID returns the keys of a pending create, ok is false for the identifier of a Stripe object.
+func parsePendingCreateID(id string) (keys []idempotencyKey, ok bool) {
+	encoded, ok := strings.CutPrefix(id, pendingCreatePrefix)
+	if !ok {
+		return nil, false
+	}
+	for _, part := range strings.Split(encoded, ",") {
+		fields := strings.SplitN(part, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		call, callErr := strconv.Atoi(fields[0])
+		createdAt, createdErr := strconv.ParseInt(fields[1], 10, 64)
+		if callErr != nil || createdErr != nil {
+			continue
+		}
+		keys = append(keys, idempotencyKey{key: fields[2], call: call, createdAt: createdAt})
+	}
+	return keys, true
+}
+
+// ProviderServer serves the provider. On top of the server of the plugin SDK it keeps the idempotency keys of a
+// create which failed before Stripe returned the object in the state, so applying it again replays the object
+// Stripe may have created.
+func ProviderServer() tfprotov5.ProviderServer {
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(Provider()),
+		pending:        map[string][]pendingCreate{},
+	}
+}
+
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	typesOnce sync.Once
+	types     map[string]tftypes.Type
+	typesErr  error
+
+	mu sync.Mutex
+	// pending holds the pending creates destroyed by the running apply by resource type, Terraform replaces them
+	// by destroying them before the create of the new object.
+	pending map[string][]pendingCreate
+}
+
+// pendingCreate is the state of a create which failed before Stripe returned the object.
+type pendingCreate struct {
+	state tftypes.Value
+	keys  []idempotencyKey
+}
+
+// ReadResource keeps the state of a pending create as it is, there's no object to read yet.
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	pending, err := s.pendingCreate(ctx, req.TypeName, req.CurrentState)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return &tfprotov5.ReadResourceResponse{NewState: req.CurrentState, Private: req.Private}, nil
+	}
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+// ApplyResourceChange creates new resource instances with the idempotency keys of their failed apply, if any.
+// Terraform replaces the tainted state of a pending create, its destroy hands the keys over to the create.
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	destroy, err := req.PlannedState.IsNull()
+	if err != nil {
+		return nil, err
+	}
+	create, err := req.PriorState.IsNull()
+	if err != nil {
+		return nil, err
+	}
+	if destroy {
+		pending, err := s.pendingCreate(ctx, req.TypeName, req.PriorState)
+		if err != nil || pending == nil {
+			if err != nil {
+				return nil, err
+			}
+			return s.ProviderServer.ApplyResourceChange(ctx, req)
+		}
+		// Stripe returned no object to delete
+		s.mu.Lock()
+		s.pending[req.TypeName] = append(s.pending[req.TypeName], *pending)
+		s.mu.Unlock()
+		return &tfprotov5.ApplyResourceChangeResponse{NewState: req.PlannedState}, nil
+	}
+	if !create {
+		return s.ProviderServer.ApplyResourceChange(ctx, req)
+	}
+
+	previous, err := s.takePendingKeys(ctx, req.TypeName, req.Config)
+	if err != nil {
+		return nil, err
+	}
+	keys := newIdempotencyKeys(previous, time.Now())
+	resp, err := s.ProviderServer.ApplyResourceChange(context.WithValue(ctx, idempotencyKeysContextKey{}, keys), req)
+	if err != nil || !diagnosticsHaveError(resp.Diagnostics) {
+		return resp, err
+	}
+
+	unsettled := keys.pending()
+	noObject, err := resp.NewState.IsNull()
+	if err != nil {
+		return nil, err
+	}
+	if len(unsettled) == 0 || !noObject {
+		return resp, nil
+	}
+	newState, err := s.pendingCreateState(ctx, req.TypeName, req.PlannedState, unsettled)
+	if err != nil {
+		return nil, err
+	}
+	resp.NewState = newState
+	resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  "Create may have been accepted by Stripe",
+		Detail: "Stripe didn't answer every create call of the resource. The next apply makes them again with " +
+			"the same idempotency keys, a create Stripe already accepted returns the original object.",
+	})
+	return resp, nil
+}
+
+func diagnosticsHaveError(diags []*tfprotov5.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// takePendingKeys returns the keys of the pending create destroyed for the configuration, the arguments set by the
+// configuration must be the ones of the failed apply.
+func (s *providerServer) takePendingKeys(ctx context.Context, typeName string, config *tfprotov5.DynamicValue) ([]idempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending[typeName]) == 0 {
+		return nil, nil
+	}
+
+	t, err := s.resourceType(ctx, typeName)
+	if err != nil {
+		return nil, err
+	}
+	value, err := config.Unmarshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var arguments map[string]tftypes.Value
+	if err := value.As(&arguments); err != nil {
+		return nil, err
+	}
+
+	for i, pending := range s.pending[typeName] {
+		var state map[string]tftypes.Value
+		if err := pending.state.As(&state); err != nil {
+			return nil, err
+		}
+		matches := true
+		for name, argument := range arguments {
+			if !argument.IsNull() && !argument.Equal(state[name]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			s.pending[typeName] = append(s.pending[typeName][:i], s.pending[typeName][i+1:]...)
+			return pending.keys, nil
+		}
+	}
+	return nil, nil
+}
+
+// resourceType returns the type of the state of the resource.
+func (s *providerServer) resourceType(ctx context.Context, typeName string) (tftypes.Type, error) {
+	s.typesOnce.Do(func() {
+		resp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			s.typesErr = err
+			return
+		}
+		s.types = map[string]tftypes.Type{}
+		for name, resourceSchema := range resp.ResourceSchemas {
+			s.types[name] = resourceSchema.ValueType()
+		}
+	})
+	if s.typesErr != nil {
+		return nil, s.typesErr
+	}
+	t, ok := s.types[typeName]
+	if !ok {
+		return nil, errors.New("unknown resource type " + typeName)
+	}
+	return t, nil
+}
+
+// pendingCreate returns the pending create of the state, nil for the state of a Stripe object.
+func (s *providerServer) pendingCreate(ctx context.Context, typeName string, state *tfprotov5.DynamicValue) (*pendingCreate, error) {
+	if state == nil {
+		return nil, nil
+	}
+	if null, err := state.IsNull(); err != nil || null {
+		return nil, err
+	}
+	t, err := s.resourceType(ctx, typeName)
+	if err != nil {
+		return nil, err
+	}
+	value, err := state.Unmarshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		return nil, err
+	}
+	var id string
+	if attributes["id"].IsKnown() && !attributes["id"].IsNull() {
+		if err := attributes["id"].As(&id); err != nil {
+			return nil, err
+		}
+	}
+	keys, ok := parsePendingCreateID(id)
+	if !ok {
+		return nil, nil
+	}
+	return &pendingCreate{state: value, keys: keys}, nil
+}
+
+// pendingCreateState returns the state of a pending create, the planned state with the values Stripe didn't
+// return left null.
+func (s *providerServer) pendingCreateState(ctx context.Context, typeName string, planned *tfprotov5.DynamicValue,
+	keys []idempotencyKey) (*tfprotov5.DynamicValue, error) {
+	t, err := s.resourceType(ctx, typeName)
+	if err != nil {
+		return nil, err
+	}
+	value, err := planned.Unmarshal(t)
+	if err != nil {
+		return nil, err
+	}
+	id := tftypes.NewAttributePath().WithAttributeName("id")
+	value, err = tftypes.Transform(value, func(path *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		switch {
+		case path.Equal(id):
+			return tftypes.NewValue(tftypes.String, pendingCreateID(keys)), nil
+		case !v.IsKnown():
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	state, err := tfprotov5.NewDynamicValue(t, value)
+	return &state, err
+}
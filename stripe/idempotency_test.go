@@ -0,0 +1,172 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func TestAccIdempotencyKey(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	product := map[string]interface{}{"name": "Gold"}
+	config := testAccConfig(t, standIn, map[string]interface{}{
+		"max_retries": 2,
+		"max_backoff": "1s",
+		"retry_on":    []interface{}{"5xx"},
+	},
+		testAccResource(t, "stripe_product", "first", product),
+		testAccResource(t, "stripe_product", "second", product))
+
+	first := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the retries of the lost responses replay the products created by the first attempts
+				PreConfig: func() { standIn.loseResponses(2) },
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccKeepState("stripe_product.first", first),
+					testAccCheckProducts(standIn, 2),
+				),
+			},
+			{
+				// identical arguments of a tainted resource create a new product instead of replaying the old one
+				Config: config,
+				Taint:  []string{"stripe_product.first"},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSameObject("stripe_product.first", first, false),
+					testAccCheckProducts(standIn, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdempotencyKeyFailedApply(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	gold := testAccConfig(t, standIn, nil,
+		testAccResource(t, "stripe_product", "test", map[string]interface{}{"name": "Gold"}))
+	silver := testAccConfig(t, standIn, nil,
+		testAccResource(t, "stripe_product", "test", map[string]interface{}{"name": "Silver"}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Stripe creates the product but the response never arrives
+				PreConfig:   func() { standIn.dropConnections(1) },
+				Config:      gold,
+				ExpectError: regexp.MustCompile(`(?i)eof|connection`),
+			},
+			{
+				// the re-applied create is sent with the same key and returns the product of the failed apply
+				Config: gold,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProducts(standIn, 1),
+					testAccCheckProductID("stripe_product.test", standIn),
+				),
+			},
+			{
+				// the key is given up once the product is in the state, a replacement is a new product
+				PreConfig:   func() { standIn.dropConnections(1) },
+				Config:      gold,
+				Taint:       []string{"stripe_product.test"},
+				ExpectError: regexp.MustCompile(`(?i)eof|connection`),
+			},
+			{
+				// the product accepted by the failed apply is left behind when the arguments changed since
+				Config: silver,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProducts(standIn, 2),
+					resource.TestCheckResourceAttr("stripe_product.test", "name", "Silver"),
+				),
+			},
+		},
+	})
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	now := time.Now()
+	keys := newIdempotencyKeys([]idempotencyKey{
+		{key: "terraform-expired", call: 0, createdAt: now.Add(-idempotencyKeyTTL).Unix()},
+		{key: "terraform-pending", call: 1, createdAt: now.Add(-time.Hour).Unix()},
+	}, now)
+	ctx := context.WithValue(context.Background(), idempotencyKeysContextKey{}, keys)
+
+	first := &stripe.ProductParams{}
+	first.IdempotencyKey = newIdempotencyKey(ctx)
+	second := &stripe.ProductParams{}
+	second.IdempotencyKey = newIdempotencyKey(ctx)
+	third := &stripe.ProductParams{}
+	third.IdempotencyKey = newIdempotencyKey(ctx)
+
+	// the expired key is replaced, the key of the second call is reused
+	if *first.IdempotencyKey == "terraform-expired" || *second.IdempotencyKey != "terraform-pending" {
+		t.Fatalf("expected a new first key and the pending second key, got %s and %s",
+			*first.IdempotencyKey, *second.IdempotencyKey)
+	}
+
+	// answered calls are settled, a request without a response isn't
+	keys.settle(first, nil)
+	keys.settle(third, testRetryError(http.StatusBadRequest, stripe.ErrorCodeParameterMissing, nil))
+	keys.settle(second, errors.New("connection reset by peer"))
+	if pending := keys.pending(); len(pending) != 1 || pending[0].key != "terraform-pending" || pending[0].call != 1 {
+		t.Fatalf("expected the second key to be pending, got %v", pending)
+	}
+
+	// a reused key refused for changed arguments is replaced
+	refused := testRetryError(http.StatusBadRequest, "", nil)
+	refused.Type = stripe.ErrorTypeIdempotency
+	if keys.rotate(ctx, first, refused) {
+		t.Fatal("expected a new key not to be rotated")
+	}
+	if !keys.rotate(ctx, second, refused) || *second.IdempotencyKey == "terraform-pending" {
+		t.Fatalf("expected the reused key to be rotated, got %s", *second.IdempotencyKey)
+	}
+	if keys.rotate(ctx, second, refused) {
+		t.Fatal("expected the rotated key not to be rotated again")
+	}
+
+	// the keys are kept in the identifier of the pending create
+	pending := keys.pending()
+	if parsed, ok := parsePendingCreateID(pendingCreateID(pending)); !ok || !reflect.DeepEqual(parsed, pending) {
+		t.Fatalf("expected the keys %v to be kept, got %v", pending, parsed)
+	}
+	if _, ok := parsePendingCreateID("prod_standin1"); ok {
+		t.Fatal("expected the identifier of a product not to be a pending create")
+	}
+}
+
+// testAccCheckProductID checks the resource holds the single product stored by the stand-in.
+func testAccCheckProductID(name string, standIn *stripeStandIn) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+		if products := standIn.objectsOf("product"); len(products) != 1 || products[0] != rs.ID {
+			return fmt.Errorf("expected %s to be the single product, got %v", rs.ID, products)
+		}
+		return nil
+	}
+}
+
+// testAccCheckProducts checks the number of products stored by the stand-in.
+func testAccCheckProducts(standIn *stripeStandIn, expected int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if products := standIn.objectsOf("product"); len(products) != expected {
+			return fmt.Errorf("expected %d products, got %v", expected, products)
+		}
+		return nil
+	}
+}
@@ -2,14 +2,23 @@ package stripe
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
 	"github.com/stripe/stripe-go/v78/client"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
@@ -18,31 +27,351 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("STRIPE_API_KEY", nil),
 			},
+			"max_retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultMaxRetries,
+				Description: "Maximum number of times a failed Stripe API call is retried before the error is returned. " +
+					"Set to 0 to disable retries. Defaults to 8.",
+			},
+			"max_backoff": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultMaxBackOff.String(),
+				Description: "Upper bound of the wait time between two retries, expressed as a duration " +
+					"like 30s or 2m. A longer Retry-After returned by Stripe is honoured. Defaults to 30s.",
+			},
+			"api_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "Base URL of the Stripe API, e.g. a local stripe-mock or a proxy. " +
+					"Defaults to https://api.stripe.com.",
+			},
+			"uploads_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "Base URL of the Stripe file uploads API. Defaults to api_base_url when it's set, " +
+					"https://files.stripe.com otherwise.",
+			},
+			"stripe_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Stripe API version the configuration is written for. Every request is pinned " +
+					"to the version the provider is built against, the provider fails when either that version " +
+					"or the default API version of the account differs.",
+			},
+			"stripe_account": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_ACCOUNT", nil),
+				ValidateFunc: validateStripeAccount,
+				Description: "Connected account the requests are made on behalf of, sent as the Stripe-Account header. " +
+					"Defaults to the STRIPE_ACCOUNT environmental variable.",
+			},
+			"max_network_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(stripe.DefaultMaxNetworkRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of times the Stripe client retries a request failed on a network error " +
+					"or a conflict. Every call retried by max_retries is retried by the client as well, the attempts " +
+					"multiply. Set to 0 to leave the retries to max_retries. Defaults to 2.",
+			},
+			"request_timeout": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultRequestTimeout.String(),
+				Description: "Timeout of a single HTTP request to the Stripe API, expressed as a duration " +
+					"like 30s or 2m. Defaults to 80s.",
+			},
+			"ca_bundle": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path to a PEM encoded bundle of CA certificates trusted in addition to the system ones, " +
+					"e.g. of a TLS intercepting proxy.",
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description: "URL of the proxy the requests to Stripe go through. " +
+					"Defaults to the HTTPS_PROXY and NO_PROXY environmental variables.",
+			},
+			"retry_on": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(retryCategories, false),
+				},
+				Description: "Error categories that trigger a retry. " +
+					"Allowed values are 429 (rate limiting), 5xx (server errors), lock_timeout " +
+					"and idempotency_conflict. Defaults to 429 and lock_timeout.",
+			},
+			"drift_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      driftPolicyStrict,
+				ValidateFunc: validation.StringInSlice(driftPolicies, false),
+				Description: "How changes made outside of Terraform are handled. strict reads back every attribute, " +
+					"ignore_stripe_managed keeps the attributes Stripe changes itself (e.g. the customer's " +
+					"next_invoice_sequence) as known to Terraform and warns about other changes. Defaults to strict.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"stripe_card":                 resourceStripeCard(),
-			"stripe_coupon":               resourceStripeCoupon(),
-			"stripe_customer":             resourceStripeCustomer(),
-			"stripe_entitlements_feature": resourceStripeEntitlementsFeature(),
-			"stripe_file":                 resourceStripeFile(),
-			"stripe_meter":                resourceStripeMeter(),
-			"stripe_price":                resourceStripePrice(),
-			"stripe_portal_configuration": resourceStripePortalConfiguration(),
-			"stripe_product":              resourceStripeProduct(),
-			"stripe_product_feature":      resourceStripeProductFeature(),
-			"stripe_promotion_code":       resourceStripePromotionCode(),
-			"stripe_shipping_rate":        resourceStripeShippingRate(),
-			"stripe_tax_rate":             resourceStripeTaxRate(),
-			"stripe_webhook_endpoint":     resourceStripeWebhookEndpoint(),
+			"stripe_account":                   resourceStripeAccount(),
+			"stripe_account_link":              resourceStripeAccountLink(),
+			"stripe_card":                      resourceStripeCard(),
+			"stripe_checkout_session":          resourceStripeCheckoutSession(),
+			"stripe_coupon":                    resourceStripeCoupon(),
+			"stripe_customer":                  resourceStripeCustomer(),
+			"stripe_customer_tax_id":           resourceStripeCustomerTaxID(),
+			"stripe_entitlements_feature":      resourceStripeEntitlementsFeature(),
+			"stripe_file":                      resourceStripeFile(),
+			"stripe_invoice":                   resourceStripeInvoice(),
+			"stripe_invoice_item":              resourceStripeInvoiceItem(),
+			"stripe_login_link":                resourceStripeLoginLink(),
+			"stripe_meter":                     resourceStripeMeter(),
+			"stripe_meter_event":               resourceStripeMeterEvent(),
+			"stripe_meter_event_adjustment":    resourceStripeMeterEventAdjustment(),
+			"stripe_payment_link":              resourceStripePaymentLink(),
+			"stripe_payment_method_attachment": resourceStripePaymentMethodAttachment(),
+			"stripe_price":                     resourceStripePrice(),
+			"stripe_pricing_matrix":            resourceStripePricingMatrix(),
+			"stripe_portal_configuration":      resourceStripePortalConfiguration(),
+			"stripe_product":                   resourceStripeProduct(),
+			"stripe_product_feature":           resourceStripeProductFeature(),
+			"stripe_promotion_code":            resourceStripePromotionCode(),
+			"stripe_shipping_rate":             resourceStripeShippingRate(),
+			"stripe_subscription":              resourceStripeSubscription(),
+			"stripe_subscription_schedule":     resourceStripeSubscriptionSchedule(),
+			"stripe_tax_rate":                  resourceStripeTaxRate(),
+			"stripe_tax_registration":          resourceStripeTaxRegistration(),
+			"stripe_tax_settings":              resourceStripeTaxSettings(),
+			"stripe_test_clock":                resourceStripeTestClock(),
+			"stripe_webhook_endpoint":          resourceStripeWebhookEndpoint(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"stripe_coupon":                dataSourceStripeCoupon(),
+			"stripe_customers":             dataSourceStripeCustomers(),
+			"stripe_meter_event_summaries": dataSourceStripeMeterEventSummaries(),
+			"stripe_price":                 dataSourceStripePrice(),
+			"stripe_prices":                dataSourceStripePrices(),
+			"stripe_product":               dataSourceStripeProduct(),
+			"stripe_products":              dataSourceStripeProducts(),
+			"stripe_tax_rate":              dataSourceStripeTaxRate(),
+			"stripe_webhook_signature":     dataSourceStripeWebhookSignature(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		withDriftPolicy(name, r)
+		withStripeAccount(r)
+	}
+	return p
+}
+
+// defaultRequestTimeout matches the timeout of the HTTP client used by stripe-go.
+const defaultRequestTimeout = 80 * time.Second
+
+// stripeClient is the provider meta shared by all resources.
+// It embeds the Stripe API client and carries the provider level settings.
+type stripeClient struct {
+	*client.API
+	retry       retryPolicy
+	driftPolicy string
+	// account is the connected account of the provider, empty for the platform.
+	account string
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	key := ExtractString(d, "api_key")
 	if key == "" {
 		return nil, diag.Errorf("api_key is required")
 	}
-	return client.New(key, nil), nil
+
+	// requests always carry the API version of the stripe-go release the provider is built with,
+	// the models don't match responses of any other version
+	version := ExtractString(d, "stripe_version")
+	if version != "" && version != stripe.APIVersion {
+		return nil, diag.Errorf("stripe_version %q differs from the Stripe API version %q the provider is built for",
+			version, stripe.APIVersion)
+	}
+
+	retry, err := retryPolicyFromConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	httpClient, err := httpClientFromConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// webhooks and objects created outside of Terraform follow the default API version of the account
+	if version != "" {
+		accountVersion, err := accountAPIVersion(ctx, httpClient, ExtractString(d, "api_base_url"), key)
+		if err != nil {
+			return nil, diag.Errorf("stripe_version: %v", err)
+		}
+		if accountVersion != version {
+			return nil, diag.Errorf("stripe_version %q differs from the default API version %q of the account",
+				version, accountVersion)
+		}
+	}
+
+	backends := backendsFromConfig(d, httpClient)
+
+	return &stripeClient{
+		API:         client.New(key, backends),
+		retry:       retry,
+		driftPolicy: ExtractString(d, "drift_policy"),
+		account:     ExtractString(d, "stripe_account"),
+	}, nil
+}
+
+// accountAPIVersion reads the default API version of the account,
+// Stripe answers a request without the Stripe-Version header in that version and names it in the response.
+func accountAPIVersion(ctx context.Context, httpClient *http.Client, apiURL, key string) (string, error) {
+	if apiURL == "" {
+		apiURL = stripe.APIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/v1/account", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reading the default API version of the account: %w", err)
+	}
+	defer resp.Body.Close()
+
+	version := resp.Header.Get("Stripe-Version")
+	if version == "" {
+		return "", fmt.Errorf("reading the default API version of the account: %s responded %s without a version",
+			req.URL, resp.Status)
+	}
+	return version, nil
+}
+
+// backendsFromConfig builds the Stripe backends sharing the configured HTTP client.
+func backendsFromConfig(d *schema.ResourceData, httpClient *http.Client) *stripe.Backends {
+	apiURL := NonZeroString(ExtractString(d, "api_base_url"))
+	uploadsURL := NonZeroString(ExtractString(d, "uploads_base_url"))
+	if uploadsURL == nil {
+		// stand-ins like stripe-mock serve the uploads on the same address
+		uploadsURL = apiURL
+	}
+	maxNetworkRetries := stripe.Int64(ExtractInt64(d, "max_network_retries"))
+
+	return &stripe.Backends{
+		API: stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+			URL:               apiURL,
+			HTTPClient:        httpClient,
+			MaxNetworkRetries: maxNetworkRetries,
+		}),
+		Connect: stripe.GetBackendWithConfig(stripe.ConnectBackend, &stripe.BackendConfig{
+			URL:               apiURL,
+			HTTPClient:        httpClient,
+			MaxNetworkRetries: maxNetworkRetries,
+		}),
+		Uploads: stripe.GetBackendWithConfig(stripe.UploadsBackend, &stripe.BackendConfig{
+			URL:               uploadsURL,
+			HTTPClient:        httpClient,
+			MaxNetworkRetries: maxNetworkRetries,
+		}),
+	}
+}
+
+func httpClientFromConfig(d *schema.ResourceData) (*http.Client, error) {
+	timeout, err := time.ParseDuration(ExtractString(d, "request_timeout"))
+	if err != nil {
+		return nil, fmt.Errorf("request_timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("request_timeout must be positive, got %s", timeout)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy := ExtractString(d, "http_proxy"); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("http_proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if caBundle := ExtractString(d, "ca_bundle"); caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("ca_bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_bundle: no PEM encoded certificate found in %s", caBundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if account := ExtractString(d, "stripe_account"); account != "" {
+		roundTripper = &stripeAccountTransport{account: account, next: transport}
+	}
+
+	return &http.Client{Timeout: timeout, Transport: roundTripper}, nil
+}
+
+// stripeAccountTransport sends the requests on behalf of the connected account, unless the request already
+// names the account itself or is made for an object the state records on another account, e.g. the platform.
+type stripeAccountTransport struct {
+	account string
+	next    http.RoundTripper
+}
+
+func (t *stripeAccountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Stripe-Account") != "" {
+		return t.next.RoundTrip(req)
+	}
+	if account, recorded := req.Context().Value(stripeAccountContextKey{}).(string); recorded && account != t.account {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Stripe-Account", t.account)
+	return t.next.RoundTrip(req)
+}
+
+func retryPolicyFromConfig(d *schema.ResourceData) (retryPolicy, error) {
+	policy := retryPolicy{
+		maxRetries: ExtractInt(d, "max_retries"),
+		retryOn:    map[string]bool{},
+	}
+	if policy.maxRetries < 0 {
+		return policy, fmt.Errorf("max_retries must not be negative, got %d", policy.maxRetries)
+	}
+
+	maxBackOff, err := time.ParseDuration(ExtractString(d, "max_backoff"))
+	if err != nil {
+		return policy, fmt.Errorf("max_backoff: %w", err)
+	}
+	if maxBackOff <= 0 {
+		return policy, fmt.Errorf("max_backoff must be positive, got %s", maxBackOff)
+	}
+	policy.maxBackOff = maxBackOff
+
+	retryOn := ToStringSlice(d.Get("retry_on").(*schema.Set).List())
+	if len(retryOn) == 0 {
+		retryOn = defaultRetryOn
+	}
+	for _, category := range retryOn {
+		policy.retryOn[category] = true
+	}
+
+	return policy, nil
 }
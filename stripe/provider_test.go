@@ -0,0 +1,607 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func TestMain(m *testing.M) {
+	// not found errors are part of the tested flows, keep the output readable
+	stripe.DefaultLeveledLogger = &stripe.LeveledLogger{Level: stripe.LevelNull}
+	os.Exit(m.Run())
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderRetryOn(t *testing.T) {
+	for retryOn, valid := range map[string]bool{"5xx": true, "idempotency_conflict": true, "5XX": false, "500": false} {
+		diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"retry_on": []interface{}{retryOn},
+		}))
+		if diags.HasError() == valid {
+			t.Fatalf("retry_on %q: expected valid %t, got %v", retryOn, valid, diags)
+		}
+	}
+}
+
+func TestProviderConfigure(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	upgraded := newStripeStandIn(t)
+	upgraded.upgradeAccount("2024-06-20")
+
+	testCases := map[string]struct {
+		config map[string]interface{}
+		err    string
+	}{
+		"pinned version": {
+			config: map[string]interface{}{"stripe_version": stripe.APIVersion, "api_base_url": standIn.URL},
+		},
+		"different version": {
+			config: map[string]interface{}{"stripe_version": "2020-08-27", "api_base_url": standIn.URL},
+			err:    "differs from the Stripe API version",
+		},
+		"upgraded account": {
+			config: map[string]interface{}{"stripe_version": stripe.APIVersion, "api_base_url": upgraded.URL},
+			err:    `differs from the default API version "2024-06-20" of the account`,
+		},
+		"unreachable account": {
+			config: map[string]interface{}{"stripe_version": stripe.APIVersion, "api_base_url": "http://127.0.0.1:1"},
+			err:    "stripe_version: reading the default API version of the account",
+		},
+		"invalid timeout": {
+			config: map[string]interface{}{"request_timeout": "soon"},
+			err:    "request_timeout",
+		},
+		"missing CA bundle": {
+			config: map[string]interface{}{"ca_bundle": filepath.Join(t.TempDir(), "missing.pem")},
+			err:    "ca_bundle",
+		},
+		"CA bundle without certificates": {
+			config: map[string]interface{}{"ca_bundle": testWriteFile(t, "empty.pem", "not a certificate")},
+			err:    "no PEM encoded certificate",
+		},
+		"proxy": {
+			config: map[string]interface{}{"http_proxy": "http://proxy.example.com:3128"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{"api_key": "sk_test_standin"}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+			switch {
+			case tc.err == "" && diags.HasError():
+				t.Fatalf("expected no error, got %v", diags)
+			case tc.err != "" && !diags.HasError():
+				t.Fatalf("expected an error containing %q", tc.err)
+			case tc.err != "" && !strings.Contains(diags[0].Summary, tc.err):
+				t.Fatalf("expected an error containing %q, got %q", tc.err, diags[0].Summary)
+			}
+		})
+	}
+}
+
+func TestProviderStripeAccount(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	c := testAccConfigure(t, map[string]interface{}{
+		"api_base_url":   standIn.URL,
+		"stripe_account": "acct_standin",
+	}).(*stripeClient)
+
+	if _, err := c.Customers.New(&stripe.CustomerParams{}); err != nil {
+		t.Fatal(err)
+	}
+	if account := standIn.lastHeader("Stripe-Account"); account != "acct_standin" {
+		t.Fatalf("expected the request on behalf of acct_standin, got %q", account)
+	}
+
+	// an account named by the request takes precedence
+	params := &stripe.CustomerParams{}
+	params.SetStripeAccount("acct_other")
+	if _, err := c.Customers.New(params); err != nil {
+		t.Fatal(err)
+	}
+	if account := standIn.lastHeader("Stripe-Account"); account != "acct_other" {
+		t.Fatalf("expected the request on behalf of acct_other, got %q", account)
+	}
+}
+
+func TestAccProviderStripeAccountChange(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	product := testAccResource(t, "stripe_product", "test", map[string]interface{}{"name": "Gold plan"})
+	config := func(account string) string {
+		provider := map[string]interface{}{}
+		if account != "" {
+			provider["stripe_account"] = account
+		}
+		return testAccConfig(t, standIn, provider, product)
+	}
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the account of the provider is recorded in the state
+				Config: config("acct_connected1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_product.test", "stripe_account", "acct_connected1"),
+					testAccCheckAccount(standIn, "stripe_product.test"),
+					testAccKeepState("stripe_product.test", state),
+				),
+			},
+			{
+				// the product is replaced in the new account, the old one is deleted from its own account
+				Config: config("acct_connected2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_product.test", "stripe_account", "acct_connected2"),
+					testAccCheckAccount(standIn, "stripe_product.test"),
+					testAccCheckSameObject("stripe_product.test", state, false),
+					testAccCheckProducts(standIn, 1),
+					testAccKeepState("stripe_product.test", state),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccount(standIn, "stripe_product.test"),
+					testAccCheckSameObject("stripe_product.test", state, false),
+					testAccCheckProducts(standIn, 1),
+					testAccKeepState("stripe_product.test", state),
+				),
+			},
+			{
+				// the product of the platform is read and deleted on the platform, not on the provider's account
+				Config: config("acct_connected1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_product.test", "stripe_account", "acct_connected1"),
+					testAccCheckAccount(standIn, "stripe_product.test"),
+					testAccCheckSameObject("stripe_product.test", state, false),
+					testAccCheckProducts(standIn, 1),
+				),
+			},
+			{
+				// an account set on the resource takes precedence over the provider one
+				Config: testAccConfig(t, standIn, map[string]interface{}{"stripe_account": "acct_connected2"},
+					testAccResource(t, "stripe_product", "test", map[string]interface{}{
+						"name":           "Gold plan",
+						"stripe_account": "acct_connected1",
+					})),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testWriteFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// testAccProviderFactories serve the provider in-process to the Terraform CLI run by resource.Test.
+var testAccProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"stripe": func() (tfprotov5.ProviderServer, error) {
+		return ProviderServer(), nil
+	},
+}
+
+// testAccCase describes the lifecycle of a single resource exercised against the Stripe stand-in.
+type testAccCase struct {
+	resource string
+	// create is the initial configuration.
+	create testAccStep
+	// update changes arguments which are updated in-place, the identifier must be kept.
+	update *testAccStep
+	// replace changes a ForceNew argument, the object must be recreated.
+	replace *testAccStep
+	// importIgnore lists the attributes which can't be read back on import, e.g. write only arguments.
+	importIgnore []string
+	// skipImport explains why the resource can't be imported by its identifier only.
+	skipImport string
+	// fixtures are the objects referenced by the configuration, they're loaded to the stand-in by their identifier.
+	fixtures map[string]map[string]interface{}
+	// remove deletes the objects of the resource out-of-band, it defaults to the object of the identifier.
+	remove func(standIn *stripeStandIn, state *terraform.InstanceState)
+}
+
+type testAccStep struct {
+	// config holds the arguments of the resource, nested blocks are lists of maps.
+	config map[string]interface{}
+	// checks are compared with the flattened state, e.g. "metadata.key" or "tiers.0.up_to".
+	checks map[string]string
+}
+
+// testAccRun drives the resource through create, in-place update, replacement, import,
+// out-of-band deletion and destroy with resource.Test. Every apply is followed by a plan which must be empty,
+// a coupon consuming the identifier of the resource checks its references follow the replacements.
+func testAccRun(t *testing.T, tc testAccCase) {
+	t.Helper()
+
+	standIn := newStripeStandIn(t)
+	for id, obj := range tc.fixtures {
+		standIn.load(id, obj)
+	}
+	address := tc.resource + ".test"
+	config := func(step testAccStep) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, tc.resource, "test", step.config),
+			testAccDependent(t, address))
+	}
+
+	state := &terraform.InstanceState{}
+	steps := []resource.TestStep{{
+		Config: config(tc.create),
+		Check: resource.ComposeTestCheckFunc(
+			testAccCheckAttributes(address, tc.create.checks),
+			testAccCheckAccount(standIn, address),
+			testAccCheckDependent(address),
+			testAccKeepState(address, state),
+		),
+	}}
+
+	if tc.update != nil {
+		steps = append(steps, resource.TestStep{
+			Config: config(*tc.update),
+			Check: resource.ComposeTestCheckFunc(
+				testAccCheckAttributes(address, tc.update.checks),
+				testAccCheckSameObject(address, state, true),
+				testAccCheckDependent(address),
+				testAccKeepState(address, state),
+			),
+		})
+	}
+
+	if tc.replace != nil {
+		steps = append(steps, resource.TestStep{
+			Config: config(*tc.replace),
+			Check: resource.ComposeTestCheckFunc(
+				testAccCheckAttributes(address, tc.replace.checks),
+				testAccCheckSameObject(address, state, false),
+				testAccCheckAccount(standIn, address),
+				testAccCheckDependent(address),
+				testAccKeepState(address, state),
+			),
+		})
+	}
+
+	if tc.skipImport == "" {
+		steps = append(steps, resource.TestStep{
+			ResourceName:            address,
+			ImportState:             true,
+			ImportStateIdFunc:       testAccImportStateID(address),
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: tc.importIgnore,
+		})
+	} else {
+		t.Logf("import skipped: %s", tc.skipImport)
+	}
+
+	// out-of-band deletion removes the resource from the state on refresh, the next apply creates it again
+	steps = append(steps, resource.TestStep{
+		PreConfig: func() {
+			if tc.remove != nil {
+				tc.remove(standIn, state)
+			} else {
+				standIn.remove(state.ID)
+			}
+		},
+		RefreshState:       true,
+		ExpectNonEmptyPlan: true,
+		Check:              testAccCheckRemoved(address),
+	}, resource.TestStep{
+		Config: config(tc.create),
+		Check: resource.ComposeTestCheckFunc(
+			testAccCheckAccount(standIn, address),
+			testAccCheckDependent(address),
+		),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+// testAccPlanError is a configuration of the resource rejected at plan time.
+type testAccPlanError struct {
+	config map[string]interface{}
+	// err matches the plan error, e.g. "currency: required when amount_off is set".
+	err string
+}
+
+// testAccRunPlanErrors plans the configurations one after another, every plan must fail before reaching Stripe.
+func testAccRunPlanErrors(t *testing.T, resourceType string, planErrors []testAccPlanError) {
+	t.Helper()
+
+	standIn := newStripeStandIn(t)
+	steps := make([]resource.TestStep, 0, len(planErrors))
+	for _, planError := range planErrors {
+		steps = append(steps, resource.TestStep{
+			Config:      testAccConfig(t, standIn, nil, testAccResource(t, resourceType, "test", planError.config)),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(regexp.QuoteMeta(planError.err)),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+// testAccMeta configures the provider against the stand-in.
+func testAccMeta(t *testing.T, standIn *stripeStandIn) interface{} {
+	t.Helper()
+	return testAccConfigure(t, map[string]interface{}{"api_base_url": standIn.URL})
+}
+
+// testAccConfigure configures the provider, the stand-in's API key and no retries are used unless overridden.
+func testAccConfigure(t *testing.T, config map[string]interface{}) interface{} {
+	t.Helper()
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(testAccProviderArguments(config)))
+	if diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+	return p.Meta()
+}
+
+func testAccProviderArguments(config map[string]interface{}) map[string]interface{} {
+	arguments := map[string]interface{}{
+		"api_key":             "sk_test_standin",
+		"max_retries":         0,
+		"max_network_retries": 0,
+	}
+	for k, v := range config {
+		arguments[k] = v
+	}
+	return arguments
+}
+
+// testAccConfig renders the provider block pointed at the stand-in, followed by the given blocks.
+// The provider arguments override the defaults of testAccConfigure.
+func testAccConfig(t *testing.T, standIn *stripeStandIn, provider map[string]interface{}, blocks ...string) string {
+	t.Helper()
+
+	arguments := testAccProviderArguments(map[string]interface{}{"api_base_url": standIn.URL})
+	for k, v := range provider {
+		arguments[k] = v
+	}
+	return testAccBlock(t, `provider "stripe"`, Provider().Schema, arguments, "") + strings.Join(blocks, "")
+}
+
+// testAccResource renders the block of a resource, the values are its arguments.
+func testAccResource(t *testing.T, resourceType, name string, values map[string]interface{}) string {
+	t.Helper()
+
+	r := Provider().ResourcesMap[resourceType]
+	if r == nil {
+		t.Fatalf("resource %s is not registered", resourceType)
+	}
+	return testAccBlock(t, fmt.Sprintf("resource %q %q", resourceType, name), r.Schema, values, "")
+}
+
+// testAccDataSource renders the block of a data source, the values are its arguments.
+func testAccDataSource(t *testing.T, dataSourceType, name string, values map[string]interface{}) string {
+	t.Helper()
+
+	r := Provider().DataSourcesMap[dataSourceType]
+	if r == nil {
+		t.Fatalf("data source %s is not registered", dataSourceType)
+	}
+	return testAccBlock(t, fmt.Sprintf("data %q %q", dataSourceType, name), r.Schema, values, "")
+}
+
+// testAccDependent renders a coupon consuming the identifier of the resource at the address in its metadata.
+func testAccDependent(t *testing.T, address string) string {
+	t.Helper()
+
+	return testAccResource(t, "stripe_coupon", "dependent", map[string]interface{}{
+		"percent_off": 10,
+		"duration":    "once",
+		"metadata":    map[string]interface{}{"id": testAccRef(address + ".id")},
+	})
+}
+
+// testAccCheckDependent checks the coupon of testAccDependent follows the identifier of the resource,
+// a replacement must update it within the same apply.
+func testAccCheckDependent(address string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrPair("stripe_coupon.dependent", "metadata.id", address, "id")
+}
+
+// testAccRef is an expression rendered as is, e.g. a reference to the attribute of another resource.
+type testAccRef string
+
+// testAccBlock renders a block, lists of nested resources are rendered as repeated nested blocks
+// and everything else as arguments.
+func testAccBlock(t *testing.T, header string, s map[string]*schema.Schema, values map[string]interface{},
+	indent string) string {
+	t.Helper()
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(indent + header + " {\n")
+	for _, k := range keys {
+		argument, ok := s[k]
+		switch {
+		case !ok:
+			t.Fatalf("%s: unsupported argument %s", header, k)
+		case values[k] == nil:
+			continue
+		}
+		if elem, ok := argument.Elem.(*schema.Resource); ok {
+			if _, ref := values[k].(testAccRef); !ref {
+				for _, item := range values[k].([]interface{}) {
+					b.WriteString(testAccBlock(t, k, elem.Schema, ToMap(item), indent+"  "))
+				}
+				continue
+			}
+		}
+		fmt.Fprintf(&b, "%s  %s = %s\n", indent, k, testAccValue(values[k]))
+	}
+	b.WriteString(indent + "}\n")
+	return b.String()
+}
+
+func testAccValue(value interface{}) string {
+	switch v := value.(type) {
+	case testAccRef:
+		return string(v)
+	case string:
+		// template sequences are taken literally, e.g. {CHECKOUT_SESSION_ID} placeholders
+		quoted := strconv.Quote(v)
+		return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(quoted)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = testAccValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = strconv.Quote(k) + " = " + testAccValue(v[k])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// testAccPrimary returns the state of the resource or data source at the address.
+func testAccPrimary(s *terraform.State, address string) (*terraform.InstanceState, error) {
+	rs, ok := s.RootModule().Resources[address]
+	if !ok || rs.Primary == nil {
+		return nil, fmt.Errorf("%s is missing from the state", address)
+	}
+	return rs.Primary, nil
+}
+
+// testAccCheckAttributes compares the attributes with the flattened state, missing attributes are empty.
+func testAccCheckAttributes(address string, checks map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		var diffs []string
+		for key, expected := range checks {
+			actual := primary.Attributes[key]
+			if actual != expected && !(testAccEmptyCollection(key, actual) && testAccEmptyCollection(key, expected)) {
+				diffs = append(diffs, fmt.Sprintf("expected %s to be %q, got %q", key, expected, actual))
+			}
+		}
+		if len(diffs) > 0 {
+			sort.Strings(diffs)
+			return fmt.Errorf("%s: %s", address, strings.Join(diffs, ", "))
+		}
+		return nil
+	}
+}
+
+// testAccCheckAccount checks the object was created for the connected account of the state.
+func testAccCheckAccount(standIn *stripeStandIn, address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		if expected, actual := primary.Attributes["stripe_account"], standIn.account(primary.ID); actual != expected {
+			return fmt.Errorf("expected %s to belong to %q, got %q", primary.ID, expected, actual)
+		}
+		return nil
+	}
+}
+
+// testAccKeepState copies the state of the resource for later steps.
+func testAccKeepState(address string, state *terraform.InstanceState) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		*state = *primary.DeepCopy()
+		return nil
+	}
+}
+
+// testAccCheckSameObject checks whether the resource is still the object of the kept state.
+func testAccCheckSameObject(address string, state *terraform.InstanceState, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		switch {
+		case same && primary.ID != state.ID:
+			return fmt.Errorf("expected in-place update of %s, got new object %s", state.ID, primary.ID)
+		case !same && primary.ID == state.ID:
+			return fmt.Errorf("expected a new object, got %s again", state.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckRemoved checks the resource is missing from the state.
+func testAccCheckRemoved(address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := testAccPrimary(s, address); err == nil {
+			return fmt.Errorf("expected %s to be removed from the state", address)
+		}
+		return nil
+	}
+}
+
+// testAccImportStateID is the identifier of the resource, prefixed by the connected account if any.
+func testAccImportStateID(address string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return "", err
+		}
+		if account := primary.Attributes["stripe_account"]; account != "" {
+			return account + "/" + primary.ID, nil
+		}
+		return primary.ID, nil
+	}
+}
+
+// testAccEmptyCollection reports whether the attribute is the size of an empty or missing list or map,
+// Terraform doesn't tell these apart.
+func testAccEmptyCollection(key, value string) bool {
+	return (strings.HasSuffix(key, ".#") || strings.HasSuffix(key, ".%")) && (value == "" || value == "0")
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeShippingRate() *schema.Resource {
@@ -18,7 +18,7 @@ func resourceStripeShippingRate() *schema.Resource {
 		UpdateContext: resourceStripeShippingRateUpdate,
 		DeleteContext: resourceStripeShippingRateDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -26,11 +26,15 @@ func resourceStripeShippingRate() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"type": {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
 				Default:  "fixed_amount",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.ShippingRateTypeFixedAmount),
+				}, false),
 				Description: "The type of calculation to use on the shipping rate. " +
 					"Can only be fixed_amount for now",
 			},
@@ -56,14 +60,16 @@ func resourceStripeShippingRate() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"amount": {
-							Type:        schema.TypeInt,
-							Required:    true,
-							Description: "A non-negative integer in cents representing how much to charge.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "A non-negative integer in cents representing how much to charge.",
 						},
 						"currency": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Three-letter ISO currency code, in lowercase. Must be a supported currency.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO currency code, in lowercase. Must be a supported currency.",
 						},
 						"currency_option": {
 							Type:     schema.TypeList,
@@ -78,22 +84,29 @@ func resourceStripeShippingRate() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"currency": {
-										Type:        schema.TypeString,
-										Required:    true,
-										ForceNew:    true,
-										Description: "Three-letter ISO currency code, in lowercase. Must be a supported currency.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validateCurrency,
+										Description:  "Three-letter ISO currency code, in lowercase. Must be a supported currency.",
 									},
 									"amount": {
-										Type:        schema.TypeInt,
-										Required:    true,
-										ForceNew:    true,
-										Description: "A non-negative integer in cents representing how much to charge.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "A non-negative integer in cents representing how much to charge.",
 									},
 									"tax_behavior": {
 										Type:     schema.TypeString,
 										Optional: true,
 										ForceNew: true,
 										Default:  stripe.PriceTaxBehaviorUnspecified,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.ShippingRateFixedAmountCurrencyOptionsTaxBehaviorExclusive),
+											string(stripe.ShippingRateFixedAmountCurrencyOptionsTaxBehaviorInclusive),
+											string(stripe.ShippingRateFixedAmountCurrencyOptionsTaxBehaviorUnspecified),
+										}, false),
 										Description: "Specifies whether the rate is considered inclusive of taxes or " +
 											"exclusive of taxes. One of inclusive, exclusive, or unspecified. ",
 									},
@@ -119,14 +132,22 @@ func resourceStripeShippingRate() *schema.Resource {
 										Type:     schema.TypeString,
 										Required: true,
 										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.ShippingRateDeliveryEstimateMinimumUnitHour),
+											string(stripe.ShippingRateDeliveryEstimateMinimumUnitDay),
+											string(stripe.ShippingRateDeliveryEstimateMinimumUnitBusinessDay),
+											string(stripe.ShippingRateDeliveryEstimateMinimumUnitWeek),
+											string(stripe.ShippingRateDeliveryEstimateMinimumUnitMonth),
+										}, false),
 										Description: "The lower bound of the estimated range. " +
 											"If empty, represents no lower bound.",
 									},
 									"value": {
-										Type:        schema.TypeInt,
-										Required:    true,
-										ForceNew:    true,
-										Description: "Must be greater than 0.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "Must be greater than 0.",
 									},
 								},
 							},
@@ -141,14 +162,22 @@ func resourceStripeShippingRate() *schema.Resource {
 										Type:     schema.TypeString,
 										Required: true,
 										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.ShippingRateDeliveryEstimateMaximumUnitHour),
+											string(stripe.ShippingRateDeliveryEstimateMaximumUnitDay),
+											string(stripe.ShippingRateDeliveryEstimateMaximumUnitBusinessDay),
+											string(stripe.ShippingRateDeliveryEstimateMaximumUnitWeek),
+											string(stripe.ShippingRateDeliveryEstimateMaximumUnitMonth),
+										}, false),
 										Description: "The upper bound of the estimated range. " +
 											"If empty, represents no lower bound.",
 									},
 									"value": {
-										Type:        schema.TypeInt,
-										ForceNew:    true,
-										Required:    true,
-										Description: "Must be greater than 0.",
+										Type:         schema.TypeInt,
+										ForceNew:     true,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "Must be greater than 0.",
 									},
 								},
 							},
@@ -160,6 +189,11 @@ func resourceStripeShippingRate() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  stripe.PriceTaxBehaviorUnspecified,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.ShippingRateTaxBehaviorExclusive),
+					string(stripe.ShippingRateTaxBehaviorInclusive),
+					string(stripe.ShippingRateTaxBehaviorUnspecified),
+				}, false),
 				Description: "Specifies whether the rate is considered inclusive of taxes or " +
 					"exclusive of taxes. One of inclusive, exclusive, or unspecified. ",
 			},
@@ -175,6 +209,15 @@ func resourceStripeShippingRate() *schema.Resource {
 				ForceNew:    true,
 				Description: "A tax code ID. The Shipping tax code is txcd_92010001.",
 			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the shipping rate when the resource is destroyed, " +
+					"Stripe doesn't support its deletion. Either archive (the shipping rate is set inactive) " +
+					"or abandon (the shipping rate is only removed from the Terraform state). Defaults to archive.",
+			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -186,14 +229,15 @@ func resourceStripeShippingRate() *schema.Resource {
 	}
 }
 
-func resourceStripeShippingRateRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeShippingRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var shippingRate *stripe.ShippingRate
 	var err error
 
 	params := &stripe.ShippingRateParams{}
 	params.AddExpand("fixed_amount.currency_options")
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		shippingRate, err = c.ShippingRates.Get(d.Id(), params)
 		return err
 	})
@@ -270,7 +314,7 @@ func resourceStripeShippingRateRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeShippingRateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var shippingRate *stripe.ShippingRate
 	var err error
 
@@ -332,7 +376,10 @@ func resourceStripeShippingRateCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		shippingRate, err = c.ShippingRates.New(params)
 		return err
 	})
@@ -344,7 +391,7 @@ func resourceStripeShippingRateCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceStripeShippingRateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.ShippingRateParams{}
@@ -359,7 +406,8 @@ func resourceStripeShippingRateUpdate(ctx context.Context, d *schema.ResourceDat
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.ShippingRates.Update(d.Id(), params)
 		return err
 	})
@@ -370,8 +418,29 @@ func resourceStripeShippingRateUpdate(ctx context.Context, d *schema.ResourceDat
 	return resourceStripeShippingRateRead(ctx, d, m)
 }
 
-func resourceStripeShippingRateDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
-	tflog.Warn(ctx, "[WARN] Stripe API doesn't support deletion of shipping rate")
+func resourceStripeShippingRateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Shipping rate is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.ShippingRateParams{
+		Active: stripe.Bool(false),
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.ShippingRates.Update(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
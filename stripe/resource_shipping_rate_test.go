@@ -0,0 +1,67 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeShippingRate(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_shipping_rate",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"display_name": "Express",
+				"fixed_amount": []interface{}{
+					map[string]interface{}{"amount": 1500, "currency": "usd"},
+				},
+				"delivery_estimate": []interface{}{
+					map[string]interface{}{
+						"minimum": []interface{}{map[string]interface{}{"unit": "business_day", "value": 1}},
+						"maximum": []interface{}{map[string]interface{}{"unit": "business_day", "value": 3}},
+					},
+				},
+				"metadata": map[string]interface{}{"carrier": "dhl"},
+			},
+			checks: map[string]string{
+				"display_name":                        "Express",
+				"fixed_amount.0.amount":               "1500",
+				"delivery_estimate.0.maximum.0.value": "3",
+				"active":                              "true",
+				"tax_behavior":                        "unspecified",
+				"metadata.carrier":                    "dhl",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"display_name": "Express",
+				"fixed_amount": []interface{}{
+					map[string]interface{}{"amount": 1500, "currency": "usd"},
+				},
+				"delivery_estimate": []interface{}{
+					map[string]interface{}{
+						"minimum": []interface{}{map[string]interface{}{"unit": "business_day", "value": 1}},
+						"maximum": []interface{}{map[string]interface{}{"unit": "business_day", "value": 3}},
+					},
+				},
+				"active":       false,
+				"tax_behavior": "exclusive",
+				"metadata":     map[string]interface{}{"carrier": "fedex"},
+			},
+			checks: map[string]string{
+				"active":           "false",
+				"tax_behavior":     "exclusive",
+				"metadata.carrier": "fedex",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"display_name": "Standard",
+				"fixed_amount": []interface{}{
+					map[string]interface{}{"amount": 500, "currency": "usd"},
+				},
+			},
+			checks: map[string]string{
+				"display_name":          "Standard",
+				"fixed_amount.0.amount": "500",
+			},
+		},
+		importIgnore: []string{"on_destroy"},
+	})
+}
@@ -0,0 +1,661 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	onDestroyDelete = "delete"
+	onDestroyReject = "reject"
+)
+
+func resourceStripeAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeAccountRead,
+		CreateContext: resourceStripeAccountCreate,
+		UpdateContext: resourceStripeAccountUpdate,
+		DeleteContext: resourceStripeAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountTypeCustom),
+					string(stripe.AccountTypeExpress),
+					string(stripe.AccountTypeStandard),
+				}, false),
+				Description: "The type of Stripe account to create. One of custom, express or standard.",
+			},
+			"country": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCountry,
+				Description: "The country in which the account holder resides, or in which the business is legally " +
+					"established. Defaults to the country of the platform.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The email address of the account holder.",
+			},
+			"business_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountBusinessTypeCompany),
+					string(stripe.AccountBusinessTypeGovernmentEntity),
+					string(stripe.AccountBusinessTypeIndividual),
+					string(stripe.AccountBusinessTypeNonProfit),
+				}, false),
+				Description: "The business type. One of company, government_entity, individual or non_profit.",
+			},
+			"default_currency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code representing the default currency for the account.",
+			},
+			"capabilities": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Capabilities requested for the account, e.g. card_payments or transfers. " +
+					"A requested capability may not immediately become active.",
+			},
+			"capability_statuses": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Status of every requested capability, one of active, inactive or pending.",
+			},
+			"business_profile": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Business information about the account.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The customer-facing business name.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The business’s publicly available website.",
+						},
+						"mcc": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							Description: "The merchant category code for the account. " +
+								"MCCs are used to classify businesses based on the goods or services they provide.",
+						},
+						"product_description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Internal-only description of the product sold or service provided by the business.",
+						},
+						"support_email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A publicly available email address for sending support issues to.",
+						},
+						"support_phone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A publicly available phone number to call with support issues.",
+						},
+						"support_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A publicly available website for handling support issues.",
+						},
+					},
+				},
+			},
+			"settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Options for customizing how the account functions within Stripe.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branding": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "Settings used to apply the account’s branding to email receipts, invoices, Checkout, and other products.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"icon": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "An icon for the account, the identifier of a file with the business_icon purpose.",
+									},
+									"logo": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "A logo for the account, the identifier of a file with the business_logo purpose.",
+									},
+									"primary_color": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+										Description: "A CSS hex color value representing the primary branding color for this account.",
+									},
+									"secondary_color": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+										Description: "A CSS hex color value representing the secondary branding color for this account.",
+									},
+								},
+							},
+						},
+						"payments": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "Settings that apply across payment methods for charging on the account.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"statement_descriptor": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+										Description: "The default text that appears on credit card statements when a charge " +
+											"is made. This field prefixes any dynamic statement_descriptor specified on the charge.",
+									},
+								},
+							},
+						},
+						"payouts": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "Settings specific to the account’s payouts.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"debit_negative_balances": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Computed: true,
+										Description: "A Boolean indicating whether Stripe should try to reclaim negative " +
+											"balances from an attached bank account.",
+									},
+									"statement_descriptor": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+										Description: "The text that appears on the bank account statement for payouts. " +
+											"If not set, this defaults to the platform’s bank descriptor.",
+									},
+									"schedule": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Computed:    true,
+										MaxItems:    1,
+										Description: "Details on when funds from charges are available, and when they are paid out to an external account.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"interval": {
+													Type:     schema.TypeString,
+													Optional: true,
+													Computed: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														string(stripe.AccountSettingsPayoutsScheduleIntervalDaily),
+														string(stripe.AccountSettingsPayoutsScheduleIntervalManual),
+														string(stripe.AccountSettingsPayoutsScheduleIntervalMonthly),
+														string(stripe.AccountSettingsPayoutsScheduleIntervalWeekly),
+													}, false),
+													Description: "How frequently funds will be paid out. " +
+														"One of manual (payouts only created via API call), daily, weekly, or monthly.",
+												},
+												"delay_days": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+													Description:  "The number of days charges for the account will be held before being paid out.",
+												},
+												"weekly_anchor": {
+													Type:     schema.TypeString,
+													Optional: true,
+													Computed: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+													}, false),
+													Description: "The day of the week funds will be paid out, required when interval is weekly.",
+												},
+												"monthly_anchor": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: validation.IntBetween(1, 31),
+													Description:  "The day of the month funds will be paid out, required when interval is monthly.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyReject,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyReject, onDestroyDelete}, false),
+				Description: "What happens with the account when the resource is destroyed, including its replacement. " +
+					"Either reject (the account is flagged as rejected with reject_reason) or delete (only possible " +
+					"for test mode accounts and custom or express accounts with zero balance). Defaults to reject.",
+			},
+			"reject_reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "other",
+				ValidateFunc: validation.StringInSlice([]string{
+					"fraud", "terms_of_service", "other",
+				}, false),
+				Description: "The reason for rejecting the account when on_destroy is reject. " +
+					"One of fraud, terms_of_service or other. Defaults to other.",
+			},
+			"charges_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account can create live charges.",
+			},
+			"payouts_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether Stripe can send payouts to this account.",
+			},
+			"details_submitted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether account details have been submitted.",
+			},
+			"requirements_currently_due": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Fields that need to be collected to keep the account enabled. " +
+					"If not collected by the current deadline, these fields appear in past_due as well.",
+			},
+			"requirements_disabled_reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "If the account is disabled, this string describes why.",
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the account was connected. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+func resourceStripeAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var account *stripe.Account
+	var err error
+
+	params := &stripe.AccountParams{}
+	err = c.retryWithBackOff(ctx, params, func() error {
+		account, err = c.Accounts.GetByID(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	capabilities, err := flattenAccountCapabilities(account)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("type", account.Type),
+		d.Set("country", account.Country),
+		d.Set("email", account.Email),
+		d.Set("business_type", account.BusinessType),
+		d.Set("default_currency", account.DefaultCurrency),
+		func() error {
+			var requested []string
+			for capability := range capabilities {
+				requested = append(requested, capability)
+			}
+			sort.Strings(requested)
+			return d.Set("capabilities", requested)
+		}(),
+		d.Set("capability_statuses", capabilities),
+		func() error {
+			if account.BusinessProfile != nil {
+				return d.Set("business_profile", []map[string]interface{}{
+					{
+						"name":                account.BusinessProfile.Name,
+						"url":                 account.BusinessProfile.URL,
+						"mcc":                 account.BusinessProfile.MCC,
+						"product_description": account.BusinessProfile.ProductDescription,
+						"support_email":       account.BusinessProfile.SupportEmail,
+						"support_phone":       account.BusinessProfile.SupportPhone,
+						"support_url":         account.BusinessProfile.SupportURL,
+					},
+				})
+			}
+			return d.Set("business_profile", nil)
+		}(),
+		d.Set("settings", flattenAccountSettings(account.Settings)),
+		d.Set("metadata", account.Metadata),
+		d.Set("charges_enabled", account.ChargesEnabled),
+		d.Set("payouts_enabled", account.PayoutsEnabled),
+		d.Set("details_submitted", account.DetailsSubmitted),
+		func() error {
+			if account.Requirements != nil {
+				return d.Set("requirements_currently_due", account.Requirements.CurrentlyDue)
+			}
+			return d.Set("requirements_currently_due", nil)
+		}(),
+		func() error {
+			if account.Requirements != nil {
+				return d.Set("requirements_disabled_reason", account.Requirements.DisabledReason)
+			}
+			return d.Set("requirements_disabled_reason", "")
+		}(),
+		d.Set("created", account.Created),
+	)
+}
+
+// flattenAccountCapabilities reads the capabilities from the raw response,
+// the Stripe SDK models every capability as a separate field.
+func flattenAccountCapabilities(account *stripe.Account) (map[string]string, error) {
+	if account.LastResponse == nil {
+		return nil, nil
+	}
+
+	var raw struct {
+		Capabilities map[string]string `json:"capabilities"`
+	}
+	if err := json.Unmarshal(account.LastResponse.RawJSON, &raw); err != nil {
+		return nil, err
+	}
+	return raw.Capabilities, nil
+}
+
+func flattenAccountSettings(settings *stripe.AccountSettings) []map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+	if settings.Branding != nil {
+		branding := map[string]interface{}{
+			"primary_color":   settings.Branding.PrimaryColor,
+			"secondary_color": settings.Branding.SecondaryColor,
+		}
+		if settings.Branding.Icon != nil {
+			branding["icon"] = settings.Branding.Icon.ID
+		}
+		if settings.Branding.Logo != nil {
+			branding["logo"] = settings.Branding.Logo.ID
+		}
+		result["branding"] = []map[string]interface{}{branding}
+	}
+	if settings.Payments != nil {
+		result["payments"] = []map[string]interface{}{
+			{"statement_descriptor": settings.Payments.StatementDescriptor},
+		}
+	}
+	if settings.Payouts != nil {
+		payouts := map[string]interface{}{
+			"debit_negative_balances": settings.Payouts.DebitNegativeBalances,
+			"statement_descriptor":    settings.Payouts.StatementDescriptor,
+		}
+		if settings.Payouts.Schedule != nil {
+			payouts["schedule"] = []map[string]interface{}{
+				{
+					"interval":       settings.Payouts.Schedule.Interval,
+					"delay_days":     settings.Payouts.Schedule.DelayDays,
+					"weekly_anchor":  settings.Payouts.Schedule.WeeklyAnchor,
+					"monthly_anchor": settings.Payouts.Schedule.MonthlyAnchor,
+				},
+			}
+		}
+		result["payouts"] = []map[string]interface{}{payouts}
+	}
+	return []map[string]interface{}{result}
+}
+
+func resourceStripeAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var account *stripe.Account
+	var err error
+
+	params := &stripe.AccountParams{
+		Type: stripe.String(ExtractString(d, "type")),
+	}
+	if country, set := d.GetOk("country"); set {
+		params.Country = stripe.String(ToString(country))
+	}
+	if email, set := d.GetOk("email"); set {
+		params.Email = stripe.String(ToString(email))
+	}
+	if businessType, set := d.GetOk("business_type"); set {
+		params.BusinessType = stripe.String(ToString(businessType))
+	}
+	if defaultCurrency, set := d.GetOk("default_currency"); set {
+		params.DefaultCurrency = stripe.String(ToString(defaultCurrency))
+	}
+	if capabilities, set := d.GetOk("capabilities"); set {
+		for _, capability := range ToStringSlice(capabilities.(*schema.Set).List()) {
+			params.AddExtra("capabilities["+capability+"][requested]", "true")
+		}
+	}
+	if _, set := d.GetOk("business_profile"); set {
+		params.BusinessProfile = expandAccountBusinessProfile(ExtractMap(d, "business_profile"))
+	}
+	if _, set := d.GetOk("settings"); set {
+		params.Settings = expandAccountSettings(ExtractMap(d, "settings"))
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		account, err = c.Accounts.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(account.ID)
+	return resourceStripeAccountRead(ctx, d, m)
+}
+
+func expandAccountBusinessProfile(businessProfile map[string]interface{}) *stripe.AccountBusinessProfileParams {
+	params := &stripe.AccountBusinessProfileParams{}
+	for k, v := range businessProfile {
+		value := NonZeroString(v)
+		switch k {
+		case "name":
+			params.Name = value
+		case "url":
+			params.URL = value
+		case "mcc":
+			params.MCC = value
+		case "product_description":
+			params.ProductDescription = value
+		case "support_email":
+			params.SupportEmail = value
+		case "support_phone":
+			params.SupportPhone = value
+		case "support_url":
+			params.SupportURL = value
+		}
+	}
+	return params
+}
+
+func expandAccountSettings(settings map[string]interface{}) *stripe.AccountSettingsParams {
+	params := &stripe.AccountSettingsParams{}
+	for k, v := range settings {
+		items := ToSlice(v)
+		if len(items) == 0 || items[0] == nil {
+			continue
+		}
+		values := ToMap(items[0])
+		switch k {
+		case "branding":
+			params.Branding = &stripe.AccountSettingsBrandingParams{
+				Icon:           NonZeroString(values["icon"]),
+				Logo:           NonZeroString(values["logo"]),
+				PrimaryColor:   NonZeroString(values["primary_color"]),
+				SecondaryColor: NonZeroString(values["secondary_color"]),
+			}
+		case "payments":
+			params.Payments = &stripe.AccountSettingsPaymentsParams{
+				StatementDescriptor: NonZeroString(values["statement_descriptor"]),
+			}
+		case "payouts":
+			params.Payouts = &stripe.AccountSettingsPayoutsParams{
+				DebitNegativeBalances: stripe.Bool(ToBool(values["debit_negative_balances"])),
+				StatementDescriptor:   NonZeroString(values["statement_descriptor"]),
+			}
+			if schedules := ToSlice(values["schedule"]); len(schedules) > 0 && schedules[0] != nil {
+				schedule := ToMap(schedules)
+				params.Payouts.Schedule = &stripe.AccountSettingsPayoutsScheduleParams{
+					Interval:      NonZeroString(schedule["interval"]),
+					MonthlyAnchor: NonZeroInt64(schedule["monthly_anchor"]),
+					WeeklyAnchor:  NonZeroString(schedule["weekly_anchor"]),
+				}
+				if delayDays := ToInt64(schedule["delay_days"]); delayDays > 0 {
+					params.Payouts.Schedule.DelayDays = stripe.Int64(delayDays)
+				}
+			}
+		}
+	}
+	return params
+}
+
+func resourceStripeAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.AccountParams{}
+
+	if d.HasChange("email") {
+		params.Email = stripe.String(ExtractString(d, "email"))
+	}
+	if d.HasChange("business_type") {
+		params.BusinessType = stripe.String(ExtractString(d, "business_type"))
+	}
+	if d.HasChange("default_currency") {
+		params.DefaultCurrency = stripe.String(ExtractString(d, "default_currency"))
+	}
+	if d.HasChange("capabilities") {
+		oldCapabilities, newCapabilities := d.GetChange("capabilities")
+		for _, capability := range ToStringSlice(oldCapabilities.(*schema.Set).Difference(newCapabilities.(*schema.Set)).List()) {
+			params.AddExtra("capabilities["+capability+"][requested]", "false")
+		}
+		for _, capability := range ToStringSlice(newCapabilities.(*schema.Set).List()) {
+			params.AddExtra("capabilities["+capability+"][requested]", "true")
+		}
+	}
+	if d.HasChange("business_profile") {
+		params.BusinessProfile = expandAccountBusinessProfile(ExtractMap(d, "business_profile"))
+	}
+	if d.HasChange("settings") {
+		params.Settings = expandAccountSettings(ExtractMap(d, "settings"))
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Accounts.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeAccountRead(ctx, d, m)
+}
+
+func resourceStripeAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	// deleting a connected account can't be undone, it's only done when the configuration asks for it
+	if ExtractString(d, "on_destroy") == onDestroyDelete {
+		params := &stripe.AccountParams{}
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Accounts.Del(d.Id(), params)
+			return err
+		})
+	} else {
+		params := &stripe.AccountRejectParams{
+			Reason: stripe.String(ExtractString(d, "reject_reason")),
+		}
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Accounts.Reject(d.Id(), params)
+			return err
+		})
+	}
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
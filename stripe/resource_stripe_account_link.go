@@ -0,0 +1,162 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeAccountLink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeAccountLinkRead,
+		CreateContext: resourceStripeAccountLinkCreate,
+		DeleteContext: resourceStripeAccountLinkDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the link in the Terraform state, the account followed by the creation time.",
+			},
+			"account": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+				Description:  "The identifier of the account to create an account link for.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.AccountLinkTypeAccountOnboarding),
+					string(stripe.AccountLinkTypeAccountUpdate),
+				}, false),
+				Description: "The type of account link the user is requesting. " +
+					"Either account_onboarding or account_update.",
+			},
+			"refresh_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "The URL the user will be redirected to if the account link is expired, " +
+					"has been previously-visited, or is otherwise invalid.",
+			},
+			"return_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL that the user will be redirected to upon leaving or completing the linked flow.",
+			},
+			"collection_options": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Specifies the requirements that Stripe collects from connected accounts in the Connect Onboarding flow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fields": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"currently_due", "eventually_due",
+							}, false),
+							Description: "Specifies whether the platform collects only currently_due requirements " +
+								"or both currently_due and eventually_due requirements.",
+						},
+						"future_requirements": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"include", "omit",
+							}, false),
+							Description: "Specifies whether the platform collects future_requirements in addition " +
+								"to requirements in Connect Onboarding. Either include or omit.",
+						},
+					},
+				},
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL for the account link.",
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the object was created. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+			"expires_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "The timestamp at which this account link will expire. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+// resourceStripeAccountLinkRead keeps the link until it expires, account links can't be retrieved from the API.
+func resourceStripeAccountLinkRead(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if expiresAt := ExtractInt64(d, "expires_at"); expiresAt != 0 && time.Now().Unix() >= expiresAt {
+		tflog.Info(ctx, "Account link has expired, it's removed from the Terraform state to be created again")
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceStripeAccountLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var link *stripe.AccountLink
+	var err error
+
+	account := ExtractString(d, "account")
+	params := &stripe.AccountLinkParams{
+		Account:    stripe.String(account),
+		Type:       stripe.String(ExtractString(d, "type")),
+		RefreshURL: stripe.String(ExtractString(d, "refresh_url")),
+		ReturnURL:  stripe.String(ExtractString(d, "return_url")),
+	}
+	if _, set := d.GetOk("collection_options"); set {
+		collectionOptions := ExtractMap(d, "collection_options")
+		params.CollectionOptions = &stripe.AccountLinkCollectionOptionsParams{
+			Fields:             NonZeroString(collectionOptions["fields"]),
+			FutureRequirements: NonZeroString(collectionOptions["future_requirements"]),
+		}
+	}
+
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		link, err = c.AccountLinks.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", account, link.Created))
+	return CallSet(
+		d.Set("url", link.URL),
+		d.Set("created", link.Created),
+		d.Set("expires_at", link.ExpiresAt),
+	)
+}
+
+func resourceStripeAccountLinkDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "[WARN] Account link can't be deleted, it's only removed from the Terraform state")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,48 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeAccountLink(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_account_link", "test", map[string]interface{}{
+		"account":     "acct_connected1",
+		"type":        "account_onboarding",
+		"refresh_url": "https://example.com/reauth",
+		"return_url":  "https://example.com/return",
+		"collection_options": []interface{}{
+			map[string]interface{}{"fields": "eventually_due"},
+		},
+	}))
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("stripe_account_link.test", "id", regexp.MustCompile(`^acct_connected1/`)),
+					resource.TestCheckResourceAttrSet("stripe_account_link.test", "url"),
+					testAccKeepState("stripe_account_link.test", state),
+				),
+			},
+			{
+				// an expired link is removed from the state on refresh to be created again
+				PreConfig: func() {
+					standIn.backdate(time.Hour)
+				},
+				Config:             config,
+				Taint:              []string{"stripe_account_link.test"},
+				ExpectNonEmptyPlan: true,
+				Check:              testAccCheckSameObject("stripe_account_link.test", state, false),
+			},
+		},
+	})
+}
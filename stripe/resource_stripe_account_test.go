@@ -0,0 +1,144 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeAccount(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_account",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"type":          "custom",
+				"country":       "US",
+				"email":         "jenny.rosen@example.com",
+				"business_type": "company",
+				"capabilities":  []interface{}{"card_payments", "transfers"},
+				"business_profile": []interface{}{
+					map[string]interface{}{
+						"name": "Rocket Rides",
+						"url":  "https://rocketrides.example.com",
+						"mcc":  "4121",
+					},
+				},
+				"settings": []interface{}{
+					map[string]interface{}{
+						"payments": []interface{}{
+							map[string]interface{}{"statement_descriptor": "ROCKET RIDES"},
+						},
+						"payouts": []interface{}{
+							map[string]interface{}{
+								"schedule": []interface{}{
+									map[string]interface{}{"interval": "weekly", "weekly_anchor": "friday"},
+								},
+							},
+						},
+					},
+				},
+				"metadata": map[string]interface{}{"tier": "gold"},
+			},
+			checks: map[string]string{
+				"country":                                       "US",
+				"capabilities.#":                                "2",
+				"capability_statuses.card_payments":             "active",
+				"business_profile.0.name":                       "Rocket Rides",
+				"settings.0.payments.0.statement_descriptor":    "ROCKET RIDES",
+				"settings.0.payouts.0.schedule.0.interval":      "weekly",
+				"settings.0.payouts.0.schedule.0.weekly_anchor": "friday",
+				"metadata.tier":                                 "gold",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"type":          "custom",
+				"country":       "US",
+				"email":         "rocket.rides@example.com",
+				"business_type": "company",
+				"capabilities":  []interface{}{"transfers"},
+				"business_profile": []interface{}{
+					map[string]interface{}{
+						"name": "Rocket Rides",
+						"url":  "https://rocketrides.example.com",
+						"mcc":  "4121",
+					},
+				},
+				"metadata": map[string]interface{}{"tier": "platinum"},
+			},
+			checks: map[string]string{
+				"email":                         "rocket.rides@example.com",
+				"capabilities.#":                "1",
+				"capability_statuses.%":         "1",
+				"capability_statuses.transfers": "active",
+				"metadata.tier":                 "platinum",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"type":       "express",
+				"country":    "US",
+				"on_destroy": "reject",
+			},
+			checks: map[string]string{
+				"type": "express",
+			},
+		},
+		importIgnore: []string{"on_destroy", "reject_reason"},
+	})
+}
+
+func TestAccStripeAccountOnDestroy(t *testing.T) {
+	testCases := map[string]struct {
+		onDestroy string
+		// deleted and rejected describe the account once it's destroyed.
+		deleted  bool
+		rejected bool
+	}{
+		// accounts are rejected unless their deletion is asked for
+		"default": {
+			rejected: true,
+		},
+		"reject": {
+			onDestroy: onDestroyReject,
+			rejected:  true,
+		},
+		"delete": {
+			onDestroy: onDestroyDelete,
+			deleted:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			values := map[string]interface{}{"type": "express", "country": "US"}
+			if tc.onDestroy != "" {
+				values["on_destroy"] = tc.onDestroy
+			}
+
+			state := &terraform.InstanceState{}
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{{
+					Config: testAccConfig(t, standIn, nil, testAccResource(t, "stripe_account", "test", values)),
+					Check:  testAccKeepState("stripe_account.test", state),
+				}},
+				CheckDestroy: func(*terraform.State) error {
+					account, ok := standIn.object(state.ID)
+					if ok == tc.deleted {
+						return fmt.Errorf("expected account %s to be deleted %t", state.ID, tc.deleted)
+					}
+					reason := ToString(ToMap(account["requirements"])["disabled_reason"])
+					if rejected := reason == "rejected.other"; ok && rejected != tc.rejected {
+						return fmt.Errorf("expected account %s to be rejected %t, disabled reason %q",
+							state.ID, tc.rejected, reason)
+					}
+					return nil
+				},
+			})
+		})
+	}
+}
@@ -3,11 +3,12 @@ package stripe
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeCard() *schema.Resource {
@@ -17,134 +18,231 @@ func resourceStripeCard() *schema.Resource {
 		UpdateContext: resourceStripeCardUpdate,
 		DeleteContext: resourceStripeCardDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Unique identifier for the object.",
-			},
-			"name": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Cardholder name.",
-			},
-			"customer": {
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Required:    true,
-				Description: "The customer that this card belongs to. ",
-			},
-			"number": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Sensitive:   true,
-				Description: "The card number, as a string without any separators.",
-			},
-			"exp_month": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Two-digit number representing the card's expiration month.",
-			},
-			"exp_year": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Four-digit number representing the card's expiration year.",
-			},
-			"cvc": {
-				Type:      schema.TypeInt,
-				Optional:  true,
-				ForceNew:  true,
-				Sensitive: true,
-				Description: "Card security code. Highly recommended to always include this value, " +
-					"but it's required only for accounts based in European countries.",
-			},
-			"address": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Description: "Address map with fields related to the address: line1, line2, city, state, " +
-					"zip and country",
-			},
-			"address_line1_check": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "If address_line1 was provided, results of the check: pass, fail, " +
-					"unavailable, or unchecked.",
-			},
-			"address_zip_check": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "If address_zip was provided, results of the check: pass, fail, unavailable, " +
-					"or unchecked.",
-			},
-			"brand": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "Card brand. Can be American Express, Diners Club, Discover, JCB, MasterCard, UnionPay, " +
-					"Visa, or Unknown.",
-			},
-			"country": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "Two-letter ISO code representing the country of the card. " +
-					"You could use this attribute to get a sense of the international " +
-					"breakdown of cards you’ve collected.",
-			},
-			"cvc_check": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "If a CVC was provided, results of the check: pass, fail, unavailable, or unchecked. " +
-					"A result of unchecked indicates that CVC was provided but hasn’t been checked yet.",
-			},
-			"fingerprint": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "Uniquely identifies this particular card number. " +
-					"You can use this attribute to check whether two customers who’ve signed up with you are using " +
-					"the same card number, for example. For payment methods that tokenize card information " +
-					"(Apple Pay, Google Pay), the tokenized number might be provided " +
-					"instead of the underlying card number.",
-			},
-			"funding": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Card funding type. Can be credit, debit, prepaid, or unknown.",
-			},
-			"last4": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The last four digits of the card.",
+		CustomizeDiff: resourceStripeCardCustomizeDiff,
+		Schema:        resourceStripeCardSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceStripeCardV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceStripeCardStateUpgradeV0,
 			},
+		},
+	}
+}
+
+func resourceStripeCardSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Unique identifier for the object.",
+		},
+		"stripe_account": stripeAccountSchema(),
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Cardholder name.",
+		},
+		"customer": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "The customer that this card belongs to. ",
+		},
+		"token": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"token", "number"},
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^tok_[A-Za-z0-9_]+$`), "must be a card token, e.g. tok_visa"),
+			Description: "The card token created by Stripe.js, Elements or the Tokens API (e.g. tok_visa in test mode). " +
+				"The card number never reaches the Terraform plan or state.",
+		},
+		"number": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			Sensitive:        true,
+			DiffSuppressFunc: suppressCardWriteOnly,
+			Deprecated:       "Raw card numbers require unsafe processing in Stripe, use token instead.",
+			Description: "The card number, as a string without any separators. " +
+				"It's only sent when the card is created and never kept in the state.",
+		},
+		"exp_month": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 12),
+			Description: "Two-digit number representing the card's expiration month. " +
+				"Required with number, taken from the token otherwise.",
+		},
+		"exp_year": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1000, 9999),
+			Description: "Four-digit number representing the card's expiration year. " +
+				"Required with number, taken from the token otherwise.",
+		},
+		"cvc": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			Sensitive:        true,
+			ConflictsWith:    []string{"token"},
+			DiffSuppressFunc: suppressCardWriteOnly,
+			Deprecated:       "Raw card data require unsafe processing in Stripe, use token instead.",
+			Description: "Card security code, only used with number. " +
+				"It's only sent when the card is created and never kept in the state.",
+		},
+		"address": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			ValidateDiagFunc: validation.MapKeyMatch(
+				regexp.MustCompile(`^(line1|line2|city|state|zip|country)$`),
+				"must be one of line1, line2, city, state, zip or country",
+			),
+			Description: "Address map with fields related to the address: line1, line2, city, state, " +
+				"zip and country",
+		},
+		"address_line1_check": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "If address_line1 was provided, results of the check: pass, fail, " +
+				"unavailable, or unchecked.",
+		},
+		"address_zip_check": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "If address_zip was provided, results of the check: pass, fail, unavailable, " +
+				"or unchecked.",
+		},
+		"brand": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Card brand. Can be American Express, Diners Club, Discover, JCB, MasterCard, UnionPay, " +
+				"Visa, or Unknown.",
+		},
+		"country": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Two-letter ISO code representing the country of the card. " +
+				"You could use this attribute to get a sense of the international " +
+				"breakdown of cards you’ve collected.",
+		},
+		"cvc_check": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "If a CVC was provided, results of the check: pass, fail, unavailable, or unchecked. " +
+				"A result of unchecked indicates that CVC was provided but hasn’t been checked yet.",
+		},
+		"fingerprint": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Uniquely identifies this particular card number. " +
+				"You can use this attribute to check whether two customers who’ve signed up with you are using " +
+				"the same card number, for example. For payment methods that tokenize card information " +
+				"(Apple Pay, Google Pay), the tokenized number might be provided " +
+				"instead of the underlying card number.",
+		},
+		"funding": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Card funding type. Can be credit, debit, prepaid, or unknown.",
+		},
+		"last4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The last four digits of the card.",
+		},
+		"available_payout_methods": {
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+			Description: "A set of available payout methods for this card. " +
+				"Only values from this set should be passed as the method when creating a payout.",
+		},
+		"tokenization_method": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "If the card number is tokenized, " +
+				"this is the method that was used. Can be android_pay (includes Google Pay), apple_pay, " +
+				"masterpass, visa_checkout, or null.",
+		},
+		"metadata": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Description: "Set of key-value pairs that you can attach to an object. " +
+				"This can be useful for storing additional information about the object in a structured format.",
+		},
+	}
+}
+
+// suppressCardWriteOnly hides the raw card data of existing cards, they are only sent on create
+// and never kept in the state.
+func suppressCardWriteOnly(_, old, _ string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+// resourceStripeCardV0 is the schema of the state version 0, which kept the card number and security code.
+func resourceStripeCardV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id":                  {Type: schema.TypeString, Computed: true},
+			"name":                {Type: schema.TypeString, Optional: true},
+			"customer":            {Type: schema.TypeString, Required: true, ForceNew: true},
+			"number":              {Type: schema.TypeString, Required: true, ForceNew: true, Sensitive: true},
+			"exp_month":           {Type: schema.TypeInt, Required: true},
+			"exp_year":            {Type: schema.TypeInt, Required: true},
+			"cvc":                 {Type: schema.TypeInt, Optional: true, ForceNew: true, Sensitive: true},
+			"address":             {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"address_line1_check": {Type: schema.TypeString, Computed: true},
+			"address_zip_check":   {Type: schema.TypeString, Computed: true},
+			"brand":               {Type: schema.TypeString, Computed: true},
+			"country":             {Type: schema.TypeString, Computed: true},
+			"cvc_check":           {Type: schema.TypeString, Computed: true},
+			"fingerprint":         {Type: schema.TypeString, Computed: true},
+			"funding":             {Type: schema.TypeString, Computed: true},
+			"last4":               {Type: schema.TypeString, Computed: true},
 			"available_payout_methods": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Computed: true,
-				Description: "A set of available payout methods for this card. " +
-					"Only values from this set should be passed as the method when creating a payout.",
-			},
-			"tokenization_method": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Description: "If the card number is tokenized, " +
-					"this is the method that was used. Can be android_pay (includes Google Pay), apple_pay, " +
-					"masterpass, visa_checkout, or null.",
-			},
-			"metadata": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Description: "Set of key-value pairs that you can attach to an object. " +
-					"This can be useful for storing additional information about the object in a structured format.",
 			},
+			"tokenization_method": {Type: schema.TypeString, Computed: true},
+			"metadata":            {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
 		},
 	}
 }
 
-func resourceStripeCardRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeCardStateUpgradeV0 removes the card number and security code stored by the previous versions.
+func resourceStripeCardStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	delete(rawState, "number")
+	delete(rawState, "cvc")
+	return rawState, nil
+}
+
+// resourceStripeCardCustomizeDiff checks the expiration is configured for cards created from a number.
+func resourceStripeCardCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" || !diffKnown(d, "number") || ToString(d.Get("number")) == "" {
+		return nil
+	}
+	// the expiration is computed from the token, only the configuration tells whether it's set
+	for _, key := range []string{"exp_month", "exp_year"} {
+		if diffConfigNull(d, key) {
+			return diffError(key, "required when number is set")
+		}
+	}
+	return nil
+}
+
+func resourceStripeCardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var card *stripe.Card
 	var err error
 
@@ -152,7 +250,8 @@ func resourceStripeCardRead(_ context.Context, d *schema.ResourceData, m interfa
 		Customer: stripe.String(ExtractString(d, "customer")),
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		card, err = c.Cards.Get(d.Id(), params)
 		return err
 	})
@@ -169,14 +268,23 @@ func resourceStripeCardRead(_ context.Context, d *schema.ResourceData, m interfa
 		d.Set("customer", card.Customer.ID),
 		d.Set("exp_month", int(card.ExpMonth)),
 		d.Set("exp_year", int(card.ExpYear)),
-		d.Set("address", map[string]interface{}{
-			"line1":   card.AddressLine1,
-			"line2":   card.AddressLine2,
-			"city":    card.AddressCity,
-			"state":   card.AddressState,
-			"zip":     card.AddressZip,
-			"country": card.AddressCountry,
-		}),
+		func() error {
+			addressMap := make(map[string]interface{})
+			for k, v := range map[string]string{
+				"line1":   card.AddressLine1,
+				"line2":   card.AddressLine2,
+				"city":    card.AddressCity,
+				"state":   card.AddressState,
+				"zip":     card.AddressZip,
+				"country": card.AddressCountry,
+			} {
+				// only the filled fields are kept, the address is usually partial
+				if v != "" {
+					addressMap[k] = v
+				}
+			}
+			return d.Set("address", addressMap)
+		}(),
 		d.Set("address_line1_check", card.AddressLine1Check),
 		d.Set("address_zip_check", card.AddressZipCheck),
 		d.Set("brand", card.Brand),
@@ -192,21 +300,30 @@ func resourceStripeCardRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceStripeCardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var card *stripe.Card
 	var err error
 
 	params := &stripe.CardParams{
 		Customer: stripe.String(ExtractString(d, "customer")),
-		Number:   stripe.String(ExtractString(d, "number")),
-		ExpMonth: stripe.String(fmt.Sprintf("%02d", ExtractInt(d, "exp_month"))),
-		ExpYear:  stripe.String(fmt.Sprintf("%04d", ExtractInt(d, "exp_year"))),
+	}
+	if token, set := d.GetOk("token"); set {
+		params.Token = stripe.String(ToString(token))
+	}
+	if number, set := d.GetOk("number"); set {
+		params.Number = stripe.String(ToString(number))
+	}
+	if expMonth, set := d.GetOk("exp_month"); set {
+		params.ExpMonth = stripe.String(fmt.Sprintf("%02d", ToInt(expMonth)))
+	}
+	if expYear, set := d.GetOk("exp_year"); set {
+		params.ExpYear = stripe.String(fmt.Sprintf("%04d", ToInt(expYear)))
 	}
 	if name, set := d.GetOk("name"); set {
 		params.Name = stripe.String(ToString(name))
 	}
 	if cvc, set := d.GetOk("cvc"); set {
-		params.CVC = stripe.String(fmt.Sprintf("%d", ToInt(cvc)))
+		params.CVC = stripe.String(ToString(cvc))
 	}
 	if address, set := d.GetOk("address"); set {
 		addressMap := ToMap(address)
@@ -234,7 +351,10 @@ func resourceStripeCardCreate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		card, err = c.Cards.New(params)
 		return err
 	})
@@ -242,17 +362,10 @@ func resourceStripeCardCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	// the raw card data are write-only, they never reach the state
 	dg := CallSet(
-		d.Set("number", *params.Number),
-		func() error {
-			// CVC is an optional field it needs to be checked before it is set to the state.
-			// Other option is to set this filed to the state when params are filled.
-			// This seems clearer to have all state sets in one place.
-			if cvc, set := d.GetOk("cvc"); set {
-				return d.Set("cvc", ToInt(cvc))
-			}
-			return nil
-		}(),
+		d.Set("number", nil),
+		d.Set("cvc", nil),
 	)
 	if len(dg) > 0 {
 		return dg
@@ -263,7 +376,7 @@ func resourceStripeCardCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceStripeCardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.CardParams{
@@ -304,7 +417,8 @@ func resourceStripeCardUpdate(ctx context.Context, d *schema.ResourceData, m int
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Cards.Update(d.Id(), params)
 		return err
 	})
@@ -314,15 +428,16 @@ func resourceStripeCardUpdate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceStripeCardRead(ctx, d, m)
 }
 
-func resourceStripeCardDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.CardParams{
 		Customer: stripe.String(ExtractString(d, "customer")),
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Cards.Del(d.Id(), params)
 		return err
 	})
@@ -0,0 +1,253 @@
+package stripe
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeCard(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_card",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer":  "cus_standin",
+				"number":    "4242424242424242",
+				"cvc":       "123",
+				"exp_month": 12,
+				"exp_year":  2030,
+				"name":      "Jane Doe",
+			},
+			checks: map[string]string{
+				"customer":  "cus_standin",
+				"number":    "",
+				"cvc":       "",
+				"exp_month": "12",
+				"last4":     "4242",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":  "cus_standin",
+				"number":    "4242424242424242",
+				"exp_month": 6,
+				"exp_year":  2031,
+				"name":      "Jane Roe",
+				"address":   map[string]interface{}{"zip": "2000", "country": "AU"},
+			},
+			checks: map[string]string{
+				"name":        "Jane Roe",
+				"exp_month":   "6",
+				"exp_year":    "2031",
+				"address.zip": "2000",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"token":    "tok_mastercard",
+			},
+			checks: map[string]string{
+				"brand": "MasterCard",
+				"last4": "4444",
+			},
+		},
+		skipImport: "the card is read through its customer, which isn't known from the identifier",
+	})
+}
+
+func TestAccStripeCardToken(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_card",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"token":    "tok_visa",
+			},
+			checks: map[string]string{
+				"brand":  "Visa",
+				"last4":  "4242",
+				"number": "",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":  "cus_standin",
+				"token":     "tok_visa",
+				"exp_month": 3,
+				"name":      "Jane Doe",
+			},
+			checks: map[string]string{
+				"exp_month": "3",
+				"name":      "Jane Doe",
+			},
+		},
+		skipImport: "the card is read through its customer, which isn't known from the identifier",
+	})
+}
+
+func TestAccStripeCardAddress(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_card", "test", map[string]interface{}{
+		"customer":  "cus_standin",
+		"number":    "4242424242424242",
+		"exp_month": 12,
+		"exp_year":  2030,
+		"address":   map[string]interface{}{"zip": "2000", "country": "AU"},
+	}))
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the fields left empty by Stripe aren't read into the address
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccKeepState("stripe_card.test", state),
+					testAccCheckAttributes("stripe_card.test", map[string]string{
+						"address.%":       "2",
+						"address.zip":     "2000",
+						"address.country": "AU",
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					card, _ := standIn.object(state.ID)
+					card["address_city"] = "Sydney"
+					standIn.load(state.ID, card)
+				},
+				RefreshState: true,
+				// the city set outside of Terraform is read back, the next apply removes it
+				ExpectNonEmptyPlan: true,
+				Check: testAccCheckAttributes("stripe_card.test", map[string]string{
+					"address.%":    "3",
+					"address.city": "Sydney",
+				}),
+			},
+		},
+	})
+}
+
+func TestResourceStripeCardStateUpgradeV0(t *testing.T) {
+	state := map[string]interface{}{
+		"id":        "card_1",
+		"customer":  "cus_1",
+		"number":    "4242424242424242",
+		"cvc":       "123",
+		"exp_month": 12,
+	}
+	expected := map[string]interface{}{
+		"id":        "card_1",
+		"customer":  "cus_1",
+		"exp_month": 12,
+	}
+
+	actual, err := resourceStripeCardStateUpgradeV0(context.Background(), state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceStripeCardUpgradeV0State(t *testing.T) {
+	testCases := map[string]*tfprotov5.RawState{
+		// the state written by the previous versions, with the security code stored as a number
+		"json": {JSON: []byte(`{
+			"id": "card_1", "name": "Jane Doe", "customer": "cus_1", "number": "4242424242424242",
+			"exp_month": 12, "exp_year": 2030, "cvc": 123, "address": {"zip": "2000"},
+			"address_line1_check": "", "address_zip_check": "pass", "brand": "Visa", "country": "US",
+			"cvc_check": "pass", "fingerprint": "Xt5EWLLDS7FJjR1c", "funding": "credit", "last4": "4242",
+			"available_payout_methods": null, "tokenization_method": "", "metadata": null
+		}`)},
+		// the state written by Terraform 0.11 and older
+		"flatmap": {Flatmap: map[string]string{
+			"id": "card_1", "name": "Jane Doe", "customer": "cus_1", "number": "4242424242424242",
+			"exp_month": "12", "exp_year": "2030", "cvc": "123", "address.%": "1", "address.zip": "2000",
+			"brand": "Visa", "last4": "4242", "metadata.%": "0",
+		}},
+	}
+
+	server := schema.NewGRPCProviderServer(Provider())
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemas.ResourceSchemas["stripe_card"].ValueType()
+
+	for name, raw := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+				TypeName: "stripe_card",
+				Version:  0,
+				RawState: raw,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+			}
+
+			value, err := resp.UpgradedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var state map[string]tftypes.Value
+			if err := value.As(&state); err != nil {
+				t.Fatal(err)
+			}
+			for _, key := range []string{"number", "cvc"} {
+				if !state[key].IsNull() {
+					t.Fatalf("expected %s to be removed, got %s", key, state[key])
+				}
+			}
+			for key, expected := range map[string]tftypes.Value{
+				"customer":  tftypes.NewValue(tftypes.String, "cus_1"),
+				"exp_month": tftypes.NewValue(tftypes.Number, 12),
+			} {
+				if !state[key].Equal(expected) {
+					t.Fatalf("expected %s to be kept as %s, got %s", key, expected, state[key])
+				}
+			}
+		})
+	}
+}
+
+func TestAccStripeCardPlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_card", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"token":    "tok_visa",
+				"address":  map[string]interface{}{"postal_code": "2000"},
+			},
+			err: "must be one of line1, line2, city, state, zip or country",
+		},
+		{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"number":   "4242424242424242",
+				"exp_year": 2030,
+			},
+			err: "exp_month: required when number is set",
+		},
+		{
+			config: map[string]interface{}{
+				"customer":  "cus_standin",
+				"number":    "4242424242424242",
+				"exp_month": 12,
+			},
+			err: "exp_year: required when number is set",
+		},
+	})
+}
@@ -0,0 +1,368 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const onDestroyExpire = "expire"
+
+func resourceStripeCheckoutSession() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCheckoutSessionRead,
+		CreateContext: resourceStripeCheckoutSessionCreate,
+		UpdateContext: resourceStripeCheckoutSessionUpdate,
+		DeleteContext: resourceStripeCheckoutSessionDelete,
+		CustomizeDiff: resourceStripeCheckoutSessionCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"mode": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.CheckoutSessionModePayment),
+					string(stripe.CheckoutSessionModeSetup),
+					string(stripe.CheckoutSessionModeSubscription),
+				}, false),
+				Description: "The mode of the Checkout Session. Either payment (one-time payments), " +
+					"setup (saving payment details for later) or subscription (recurring prices).",
+			},
+			"line_items": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The items the customer is purchasing. Required in payment and subscription mode.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The ID of the price object.",
+						},
+						"quantity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The quantity of the line item being purchased. Defaults to 1.",
+						},
+					},
+				},
+			},
+			"success_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "The URL to which Stripe should send customers when payment or setup is complete. " +
+					"The session ID is added when the URL contains {CHECKOUT_SESSION_ID}.",
+			},
+			"cancel_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "If set, Checkout displays a back button and customers will be directed " +
+					"to this URL if they decide to cancel payment and return to your website.",
+			},
+			"customer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of an existing customer the session is created for. " +
+					"Checkout creates a customer in subscription mode when it's not set.",
+			},
+			"discounts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The coupon or promotion code to apply to this session.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"coupon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The ID of the coupon to apply to this session.",
+						},
+						"promotion_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The ID of a promotion code to apply to this session.",
+						},
+					},
+				},
+			},
+			"automatic_tax": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Settings for automatic tax lookup for this session.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Set to true to enable automatic taxes.",
+						},
+					},
+				},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  onDestroyExpire,
+				ValidateFunc: validation.StringInSlice([]string{
+					onDestroyExpire,
+					onDestroyAbandon,
+				}, false),
+				Description: "What happens with the session when the resource is destroyed or replaced. " +
+					"Either expire (an open session is expired, so its URL can no longer be used) " +
+					"or abandon (the session is only removed from the Terraform state). Defaults to expire.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL to the Checkout Session, customers are redirected to it to complete the session.",
+			},
+			"expires_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "The timestamp at which the Checkout Session will expire. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the Checkout Session, one of open, complete, or expired.",
+			},
+			"payment_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The payment status of the Checkout Session, one of paid, unpaid, or " +
+					"no_payment_required.",
+			},
+			"amount_total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total of all items after discounts and taxes are applied.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+		},
+	}
+}
+
+// checkoutSessionExpired reports whether the session can no longer be used by customers.
+func checkoutSessionExpired(status string, expiresAt int64) bool {
+	return status == string(stripe.CheckoutSessionStatusExpired) ||
+		(expiresAt != 0 && time.Now().Unix() >= expiresAt)
+}
+
+// resourceStripeCheckoutSessionCustomizeDiff replaces an expired session, planning without a refresh
+// doesn't read the session again.
+func resourceStripeCheckoutSessionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !checkoutSessionExpired(ToString(d.Get("status")), ToInt64(d.Get("expires_at"))) {
+		return nil
+	}
+	for _, k := range []string{"url", "status", "payment_status"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	if err := d.SetNewComputed("expires_at"); err != nil {
+		return err
+	}
+	return d.ForceNew("expires_at")
+}
+
+func resourceStripeCheckoutSessionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var session *stripe.CheckoutSession
+	var err error
+
+	params := &stripe.CheckoutSessionParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		session, err = c.CheckoutSessions.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	if checkoutSessionExpired(string(session.Status), session.ExpiresAt) {
+		tflog.Info(ctx, "Checkout session has expired, it's removed from the Terraform state to be created again")
+		d.SetId("")
+		return nil
+	}
+
+	return CallSet(
+		d.Set("mode", session.Mode),
+		d.Set("success_url", session.SuccessURL),
+		d.Set("cancel_url", session.CancelURL),
+		func() error {
+			if session.Customer != nil {
+				return d.Set("customer", session.Customer.ID)
+			}
+			return nil
+		}(),
+		func() error {
+			if session.AutomaticTax != nil {
+				return d.Set("automatic_tax", []map[string]interface{}{
+					{
+						"enabled": session.AutomaticTax.Enabled,
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("metadata", session.Metadata),
+		d.Set("url", session.URL),
+		d.Set("expires_at", session.ExpiresAt),
+		d.Set("status", session.Status),
+		d.Set("payment_status", session.PaymentStatus),
+		d.Set("amount_total", session.AmountTotal),
+		d.Set("currency", session.Currency),
+	)
+}
+
+func resourceStripeCheckoutSessionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var session *stripe.CheckoutSession
+	var err error
+
+	params := &stripe.CheckoutSessionParams{
+		Mode:       stripe.String(ExtractString(d, "mode")),
+		SuccessURL: stripe.String(ExtractString(d, "success_url")),
+	}
+
+	if lineItems, set := d.GetOk("line_items"); set {
+		for _, lineItem := range ToMapSlice(lineItems) {
+			params.LineItems = append(params.LineItems, &stripe.CheckoutSessionLineItemParams{
+				Price:    NonZeroString(lineItem["price"]),
+				Quantity: NonZeroInt64(lineItem["quantity"]),
+			})
+		}
+	}
+	if cancelURL, set := d.GetOk("cancel_url"); set {
+		params.CancelURL = stripe.String(ToString(cancelURL))
+	}
+	if customer, set := d.GetOk("customer"); set {
+		params.Customer = stripe.String(ToString(customer))
+	}
+	if discounts, set := d.GetOk("discounts"); set {
+		for _, discount := range ToMapSlice(discounts) {
+			params.Discounts = append(params.Discounts, &stripe.CheckoutSessionDiscountParams{
+				Coupon:        NonZeroString(discount["coupon"]),
+				PromotionCode: NonZeroString(discount["promotion_code"]),
+			})
+		}
+	}
+	if automaticTax, set := d.GetOk("automatic_tax"); set {
+		params.AutomaticTax = &stripe.CheckoutSessionAutomaticTaxParams{
+			Enabled: stripe.Bool(ToBool(ToMap(automaticTax)["enabled"])),
+		}
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		session, err = c.CheckoutSessions.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(session.ID)
+	return resourceStripeCheckoutSessionRead(ctx, d, m)
+}
+
+// resourceStripeCheckoutSessionUpdate only records the on_destroy argument, a session can't be changed.
+func resourceStripeCheckoutSessionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceStripeCheckoutSessionRead(ctx, d, m)
+}
+
+func resourceStripeCheckoutSessionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var session *stripe.CheckoutSession
+	var err error
+
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Checkout session is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	params := &stripe.CheckoutSessionParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		session, err = c.CheckoutSessions.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	// completed and expired sessions can't be expired
+	if session.Status == stripe.CheckoutSessionStatusOpen {
+		expireParams := &stripe.CheckoutSessionExpireParams{}
+		setStripeAccount(d, expireParams)
+		err = c.retryWithBackOff(ctx, expireParams, func() error {
+			_, err = c.CheckoutSessions.Expire(d.Id(), expireParams)
+			return err
+		})
+		if err != nil && !isNotFoundErr(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,149 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeCheckoutSession(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_checkout_session",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"mode": "subscription",
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_standin", "quantity": 2},
+				},
+				"success_url": "https://example.com/success?session={CHECKOUT_SESSION_ID}",
+				"cancel_url":  "https://example.com/cancel",
+				"customer":    "cus_standin",
+				"discounts": []interface{}{
+					map[string]interface{}{"promotion_code": "promo_standin"},
+				},
+				"automatic_tax": []interface{}{
+					map[string]interface{}{"enabled": true},
+				},
+				"metadata": map[string]interface{}{"suite": "e2e"},
+			},
+			checks: map[string]string{
+				"mode":                    "subscription",
+				"customer":                "cus_standin",
+				"automatic_tax.0.enabled": "true",
+				"status":                  "open",
+				"url":                     "https://checkout.stripe.com/c/pay/cs_standin1",
+				"metadata.suite":          "e2e",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"mode": "subscription",
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_standin", "quantity": 2},
+				},
+				"success_url": "https://example.com/success?session={CHECKOUT_SESSION_ID}",
+				"cancel_url":  "https://example.com/cancel",
+				"customer":    "cus_standin",
+				"discounts": []interface{}{
+					map[string]interface{}{"promotion_code": "promo_standin"},
+				},
+				"automatic_tax": []interface{}{
+					map[string]interface{}{"enabled": true},
+				},
+				"metadata":   map[string]interface{}{"suite": "e2e"},
+				"on_destroy": "abandon",
+			},
+			checks: map[string]string{
+				"on_destroy": "abandon",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"mode": "payment",
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_standin"},
+				},
+				"success_url": "https://example.com/success",
+			},
+			checks: map[string]string{
+				"mode":                  "payment",
+				"line_items.0.quantity": "1",
+			},
+		},
+		skipImport: "checkout sessions are short-lived and their line items aren't returned by Stripe",
+	})
+}
+
+func TestAccStripeCheckoutSessionExpiry(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_checkout_session", "test", map[string]interface{}{
+		"mode": "payment",
+		"line_items": []interface{}{
+			map[string]interface{}{"price": "price_standin"},
+		},
+		"success_url": "https://example.com/success",
+	}))
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccKeepState("stripe_checkout_session.test", state),
+			},
+			{
+				// a session past its expiry is replaced, Stripe may not have expired it yet
+				PreConfig: func() {
+					session, _ := standIn.object(state.ID)
+					session["expires_at"] = time.Now().Add(-time.Minute).Unix()
+					standIn.load(state.ID, session)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSameObject("stripe_checkout_session.test", state, false),
+					testAccCheckFreshURL("stripe_checkout_session.test", state),
+					testAccKeepState("stripe_checkout_session.test", state),
+				),
+			},
+			{
+				// a session expired by Stripe is removed from the state on refresh
+				PreConfig: func() {
+					session, _ := standIn.object(state.ID)
+					session["status"] = "expired"
+					standIn.load(state.ID, session)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSameObject("stripe_checkout_session.test", state, false),
+					testAccCheckFreshURL("stripe_checkout_session.test", state),
+					testAccKeepState("stripe_checkout_session.test", state),
+				),
+			},
+		},
+		// an open session is expired on destroy
+		CheckDestroy: func(*terraform.State) error {
+			if session, _ := standIn.object(state.ID); session["status"] != "expired" {
+				return fmt.Errorf("expected %s to be expired, got %v", state.ID, session["status"])
+			}
+			return nil
+		},
+	})
+}
+
+// testAccCheckFreshURL checks the session of the address comes with another URL than the kept session.
+func testAccCheckFreshURL(address string, state *terraform.InstanceState) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		if primary.Attributes["url"] == state.Attributes["url"] {
+			return fmt.Errorf("expected a fresh URL, got %s again", primary.Attributes["url"])
+		}
+		return nil
+	}
+}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeCoupon() *schema.Resource {
@@ -16,8 +16,9 @@ func resourceStripeCoupon() *schema.Resource {
 		CreateContext: resourceStripeCouponCreate,
 		UpdateContext: resourceStripeCouponUpdate,
 		DeleteContext: resourceStripeCouponDelete,
+		CustomizeDiff: resourceStripeCouponCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"coupon_id": {
@@ -27,23 +28,26 @@ func resourceStripeCoupon() *schema.Resource {
 				ForceNew:    true,
 				Description: "Unique string of your choice that will be used to identify this coupon when applying it to a customer. If you don’t want to specify a particular code, you can leave the ID blank and we’ll generate a random code for you.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Name of the coupon displayed to customers on for instance invoices or receipts.",
 			},
 			"amount_off": {
-				Type:          schema.TypeInt,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"percent_off"},
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"amount_off", "percent_off"},
+				ValidateFunc: validation.IntAtLeast(1),
 				Description: "Amount (in the currency specified) that will be taken off the subtotal of any invoices " +
 					"for this customer.",
 			},
 			"currency": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
 				Description: "If amount_off has been set, " +
 					"the three-letter ISO code for the currency of the amount to take off.",
 			},
@@ -51,7 +55,9 @@ func resourceStripeCoupon() *schema.Resource {
 				Type:          schema.TypeFloat,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"amount_off", "currency"},
+				ExactlyOneOf:  []string{"amount_off", "percent_off"},
+				ConflictsWith: []string{"currency"},
+				ValidateFunc:  validation.FloatBetween(0, 100),
 				Description: "Percent that will be taken off the subtotal of any invoices for this customer " +
 					"for the duration of the coupon. " +
 					"For example, a coupon with percent_off of 50 will make a $100 invoice $50 instead.",
@@ -61,28 +67,36 @@ func resourceStripeCoupon() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 				Default:  "once",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.CouponDurationForever),
+					string(stripe.CouponDurationOnce),
+					string(stripe.CouponDurationRepeating),
+				}, false),
 				Description: "One of forever, once, and repeating. " +
 					"Describes how long a customer who applies this coupon will get the discount.",
 			},
 			"duration_in_months": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 				Description: "If duration is repeating, the number of months the coupon applies. " +
 					"Null if coupon duration is forever or once.",
 			},
 			"max_redemptions": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 				Description: "Maximum number of times this coupon can be redeemed, " +
 					"in total, across all customers, before it is no longer valid.",
 			},
 			"redeem_by": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Date after which the coupon can no longer be redeemed. Expected format is RFC3339",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateTimestamp,
+				Description:  "Date after which the coupon can no longer be redeemed. Expected format is RFC3339",
 			},
 			"times_redeemed": {
 				Type:        schema.TypeInt,
@@ -113,15 +127,38 @@ func resourceStripeCoupon() *schema.Resource {
 	}
 }
 
-func resourceStripeCouponRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeCouponCustomizeDiff checks the rules between the amount and the duration of the coupon.
+func resourceStripeCouponCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if diffKnown(d, "amount_off", "currency") {
+		if ToInt(d.Get("amount_off")) != 0 && ToString(d.Get("currency")) == "" {
+			return diffError("currency", "required when amount_off is set")
+		}
+	}
+
+	if diffKnown(d, "duration", "duration_in_months") {
+		duration := ToString(d.Get("duration"))
+		durationInMonths := ToInt(d.Get("duration_in_months"))
+		switch {
+		case duration == string(stripe.CouponDurationRepeating) && durationInMonths == 0:
+			return diffError("duration_in_months", "required when duration is repeating")
+		case duration != string(stripe.CouponDurationRepeating) && durationInMonths != 0:
+			return diffError("duration_in_months", "only allowed when duration is repeating, got duration %q", duration)
+		}
+	}
+
+	return nil
+}
+
+func resourceStripeCouponRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var coupon *stripe.Coupon
 	var err error
 
 	p := &stripe.CouponParams{}
 	p.AddExpand("applies_to")
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, p)
+	err = c.retryWithBackOff(ctx, p, func() error {
 		coupon, err = c.Coupons.Get(d.Id(), p)
 		return err
 	})
@@ -161,7 +198,7 @@ func resourceStripeCouponRead(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var coupon *stripe.Coupon
 	var err error
 
@@ -209,7 +246,10 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		coupon, err = c.Coupons.New(params)
 		return err
 	})
@@ -222,7 +262,7 @@ func resourceStripeCouponCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.CouponParams{}
@@ -235,7 +275,8 @@ func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m i
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Coupons.Update(d.Id(), params)
 		return err
 	})
@@ -246,12 +287,15 @@ func resourceStripeCouponUpdate(ctx context.Context, d *schema.ResourceData, m i
 	return resourceStripeCouponRead(ctx, d, m)
 }
 
-func resourceStripeCouponDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCouponDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var err error
 
-	err = retryWithBackOff(func() error {
-		_, err = c.Coupons.Del(d.Id(), nil)
+	params := &stripe.CouponParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Coupons.Del(d.Id(), params)
 		return err
 	})
 
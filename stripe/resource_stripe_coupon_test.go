@@ -0,0 +1,81 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeCoupon(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_coupon",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":               "10 off",
+				"amount_off":         1000,
+				"currency":           "usd",
+				"duration":           "repeating",
+				"duration_in_months": 3,
+				"max_redemptions":    10,
+				"metadata":           map[string]interface{}{"team": "growth"},
+			},
+			checks: map[string]string{
+				"amount_off":         "1000",
+				"currency":           "usd",
+				"duration":           "repeating",
+				"duration_in_months": "3",
+				"metadata.team":      "growth",
+				"valid":              "true",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":               "10 off for 3 months",
+				"amount_off":         1000,
+				"currency":           "usd",
+				"duration":           "repeating",
+				"duration_in_months": 3,
+				"max_redemptions":    10,
+				"metadata":           map[string]interface{}{"team": "sales"},
+			},
+			checks: map[string]string{
+				"name":          "10 off for 3 months",
+				"metadata.team": "sales",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"name":        "25% off",
+				"percent_off": 25.5,
+				"duration":    "forever",
+			},
+			checks: map[string]string{
+				"percent_off": "25.5",
+				"amount_off":  "0",
+				"duration":    "forever",
+				"metadata.%":  "0",
+			},
+		},
+	})
+}
+
+func TestAccStripeCouponPlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_coupon", []testAccPlanError{
+		{
+			config: map[string]interface{}{"amount_off": 1000, "duration": "once"},
+			err:    "currency: required when amount_off is set",
+		},
+		{
+			config: map[string]interface{}{"percent_off": 10, "duration": "repeating"},
+			err:    "duration_in_months: required when duration is repeating",
+		},
+		{
+			config: map[string]interface{}{"percent_off": 10, "duration": "forever", "duration_in_months": 3},
+			err:    `duration_in_months: only allowed when duration is repeating, got duration "forever"`,
+		},
+		{
+			config: map[string]interface{}{"duration": "once"},
+			err:    "one of `amount_off,percent_off` must be specified",
+		},
+		{
+			config: map[string]interface{}{"percent_off": 120, "duration": "once"},
+			err:    "expected percent_off to be in the range (0.000000 - 100.000000)",
+		},
+	})
+}
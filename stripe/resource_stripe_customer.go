@@ -2,11 +2,13 @@ package stripe
 
 import (
 	"context"
+	"regexp"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeCustomer() *schema.Resource {
@@ -16,7 +18,7 @@ func resourceStripeCustomer() *schema.Resource {
 		UpdateContext: resourceStripeCustomerUpdate,
 		DeleteContext: resourceStripeCustomerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -24,6 +26,7 @@ func resourceStripeCustomer() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -48,16 +51,18 @@ func resourceStripeCustomer() *schema.Resource {
 				Description: "The customer’s phone number.",
 			},
 			"address": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateAddress,
 				Description: "Address map with fields related to the address: line1, line2, city, state, " +
 					"postal_code and country",
 			},
 			"shipping": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateShippingAddress,
 				Description: "Shipping map with fields like name, phone and fields related to the address: " +
 					"line1, line2, city, state, postal_code and country. ",
 			},
@@ -92,20 +97,25 @@ func resourceStripeCustomer() *schema.Resource {
 			"invoice_prefix": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z0-9]{3,12}$`),
+					"must be 3–12 uppercase letters or numbers"),
 				Description: "The prefix for the customer used to generate unique invoice numbers. " +
 					"Must be 3–12 uppercase letters or numbers.",
 			},
 			"invoice_settings": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Default invoice settings for this customer.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Default invoice settings for this customer: footer, default_payment_method and " +
+					"amount_tax_display (rendering options of invoice PDFs, either exclude_tax or include_inclusive_tax). " +
+					"Other keys are custom fields displayed on invoices, up to 4.",
 			},
 			"next_invoice_sequence": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     1,
-				Description: "The sequence to be used on the customer’s next invoice. Defaults to 1.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The sequence to be used on the customer’s next invoice. Defaults to 1.",
 			},
 			"preferred_locales": {
 				Type:        schema.TypeList,
@@ -113,6 +123,86 @@ func resourceStripeCustomer() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "Customer’s preferred languages, ordered by preference.",
 			},
+			"tax_exempt": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.CustomerTaxExemptNone),
+					string(stripe.CustomerTaxExemptExempt),
+					string(stripe.CustomerTaxExemptReverse),
+				}, false),
+				Description: "The customer’s tax exemption. One of none, exempt, or reverse.",
+			},
+			"tax_id_data": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Description: "The customer’s tax IDs. Tax IDs can't be updated, " +
+					"a changed tax ID is removed and added again.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Type of the tax ID, e.g. eu_vat, gb_vat or us_ein.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Value of the tax ID.",
+						},
+					},
+				},
+			},
+			"test_clock": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the test clock to attach to the customer.",
+			},
+			"cash_balance": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Balance information and default balance settings for this customer.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"settings": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "Settings controlling the behavior of the customer’s cash balance.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"reconciliation_mode": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"automatic", "manual", "merchant_default",
+										}, false),
+										Description: "Controls how funds transferred by the customer are applied to " +
+											"payment intents and invoices. One of automatic, manual or merchant_default.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"source": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "A token (e.g. tok_visa) attached to the customer as its default source, " +
+					"a changed token replaces the default source. It can't be read back from Stripe.",
+			},
+			"default_source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the default payment source for the customer.",
+			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -124,13 +214,17 @@ func resourceStripeCustomer() *schema.Resource {
 	}
 }
 
-func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var customer *stripe.Customer
 	var err error
 
-	err = retryWithBackOff(func() error {
-		customer, err = c.Customers.Get(d.Id(), nil)
+	params := &stripe.CustomerParams{}
+	params.AddExpand("cash_balance")
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		customer, err = c.Customers.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -141,6 +235,11 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	taxIDs, err := listCustomerTaxIDs(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return CallSet(
 		d.Set("name", customer.Name),
 		d.Set("email", customer.Email),
@@ -218,9 +317,16 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 				if customer.InvoiceSettings.Footer != "" {
 					invoiceSettingsMap["footer"] = customer.InvoiceSettings.Footer
 				}
-				if customer.InvoiceSettings.DefaultPaymentMethod != nil {
+				// the default payment method is usually set by stripe_payment_method_attachment,
+				// it's only read back when it's part of the configuration
+				_, managed := ExtractMap(d, "invoice_settings")["default_payment_method"]
+				if customer.InvoiceSettings.DefaultPaymentMethod != nil && managed {
 					invoiceSettingsMap["default_payment_method"] = customer.InvoiceSettings.DefaultPaymentMethod.ID
 				}
+				if customer.InvoiceSettings.RenderingOptions != nil &&
+					customer.InvoiceSettings.RenderingOptions.AmountTaxDisplay != "" {
+					invoiceSettingsMap["amount_tax_display"] = customer.InvoiceSettings.RenderingOptions.AmountTaxDisplay
+				}
 				for _, field := range customer.InvoiceSettings.CustomFields {
 					invoiceSettingsMap[field.Name] = field.Value
 				}
@@ -231,12 +337,101 @@ func resourceStripeCustomerRead(_ context.Context, d *schema.ResourceData, m int
 		}(),
 		d.Set("next_invoice_sequence", customer.NextInvoiceSequence),
 		d.Set("preferred_locales", customer.PreferredLocales),
+		d.Set("tax_exempt", customer.TaxExempt),
+		func() error {
+			var taxIDData []map[string]interface{}
+			for _, taxID := range taxIDs {
+				taxIDData = append(taxIDData, map[string]interface{}{
+					"type":  taxID.Type,
+					"value": taxID.Value,
+				})
+			}
+			return d.Set("tax_id_data", taxIDData)
+		}(),
+		func() error {
+			if customer.TestClock != nil {
+				return d.Set("test_clock", customer.TestClock.ID)
+			}
+			return d.Set("test_clock", "")
+		}(),
+		func() error {
+			if customer.CashBalance == nil || customer.CashBalance.Settings == nil {
+				return d.Set("cash_balance", nil)
+			}
+			reconciliationMode := string(customer.CashBalance.Settings.ReconciliationMode)
+			if customer.CashBalance.Settings.UsingMerchantDefault {
+				reconciliationMode = "merchant_default"
+			}
+			return d.Set("cash_balance", []map[string]interface{}{
+				{
+					"settings": []map[string]interface{}{
+						{"reconciliation_mode": reconciliationMode},
+					},
+				},
+			})
+		}(),
+		func() error {
+			if customer.DefaultSource != nil {
+				return d.Set("default_source", customer.DefaultSource.ID)
+			}
+			return d.Set("default_source", "")
+		}(),
 		d.Set("metadata", customer.Metadata),
 	)
 }
 
+// listCustomerTaxIDs lists all tax IDs of the customer.
+func listCustomerTaxIDs(ctx context.Context, d *schema.ResourceData, c *stripeClient) ([]*stripe.TaxID, error) {
+	var taxIDs []*stripe.TaxID
+
+	params := &stripe.TaxIDListParams{
+		Customer: stripe.String(d.Id()),
+	}
+	setStripeAccount(d, params)
+
+	err := c.retryWithBackOff(ctx, params, func() error {
+		taxIDs = nil
+		iter := c.TaxIDs.List(params)
+		for iter.Next() {
+			taxIDs = append(taxIDs, iter.TaxID())
+		}
+		return iter.Err()
+	})
+	return taxIDs, err
+}
+
+// expandCustomerInvoiceSettings builds the invoice settings from the map, keys other than the footer,
+// the default payment method and the rendering options are custom fields.
+func expandCustomerInvoiceSettings(invoiceSettingsMap map[string]interface{}) *stripe.CustomerInvoiceSettingsParams {
+	params := &stripe.CustomerInvoiceSettingsParams{}
+	for k, v := range invoiceSettingsMap {
+		value := stripe.String(ToString(v))
+		switch k {
+		case "default_payment_method":
+			params.DefaultPaymentMethod = value
+		case "footer":
+			params.Footer = value
+		case "amount_tax_display":
+			params.RenderingOptions = &stripe.CustomerInvoiceSettingsRenderingOptionsParams{
+				AmountTaxDisplay: value,
+			}
+		default:
+			params.CustomFields = append(params.CustomFields,
+				&stripe.CustomerInvoiceSettingsCustomFieldParams{
+					Name:  stripe.String(k),
+					Value: value,
+				})
+		}
+	}
+	// map iteration order is random, keep the custom fields in a stable order
+	sort.Slice(params.CustomFields, func(i, j int) bool {
+		return *params.CustomFields[i].Name < *params.CustomFields[j].Name
+	})
+	return params
+}
+
 func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var customer *stripe.Customer
 	var err error
 
@@ -309,23 +504,7 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 		params.InvoicePrefix = stripe.String(ToString(invoicePrefix))
 	}
 	if invoiceSettings, set := d.GetOk("invoice_settings"); set {
-		params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{}
-		invoiceSettingsMap := ToMap(invoiceSettings)
-		for k, v := range invoiceSettingsMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "default_payment_method":
-				params.InvoiceSettings.DefaultPaymentMethod = value
-			case "footer":
-				params.InvoiceSettings.Footer = value
-			default:
-				params.InvoiceSettings.CustomFields = append(params.InvoiceSettings.CustomFields,
-					&stripe.CustomerInvoiceSettingsCustomFieldParams{
-						Name:  stripe.String(k),
-						Value: value,
-					})
-			}
-		}
+		params.InvoiceSettings = expandCustomerInvoiceSettings(ToMap(invoiceSettings))
 	}
 	if nextInvoiceSequence, set := d.GetOk("next_invoice_sequence"); set {
 		params.NextInvoiceSequence = stripe.Int64(ToInt64(nextInvoiceSequence))
@@ -333,13 +512,40 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 	if preferredLocales, set := d.GetOk("preferred_locales"); set {
 		params.PreferredLocales = stripe.StringSlice(ToStringSlice(preferredLocales))
 	}
+	if taxExempt, set := d.GetOk("tax_exempt"); set {
+		params.TaxExempt = stripe.String(ToString(taxExempt))
+	}
+	if taxIDData, set := d.GetOk("tax_id_data"); set {
+		for _, taxID := range ToMapSlice(taxIDData.(*schema.Set).List()) {
+			params.TaxIDData = append(params.TaxIDData, &stripe.CustomerTaxIDDataParams{
+				Type:  stripe.String(ToString(taxID["type"])),
+				Value: stripe.String(ToString(taxID["value"])),
+			})
+		}
+		// set order isn't stable, keep the tax IDs sorted
+		sort.Slice(params.TaxIDData, func(i, j int) bool {
+			return *params.TaxIDData[i].Type+*params.TaxIDData[i].Value < *params.TaxIDData[j].Type+*params.TaxIDData[j].Value
+		})
+	}
+	if testClock, set := d.GetOk("test_clock"); set {
+		params.TestClock = stripe.String(ToString(testClock))
+	}
+	if _, set := d.GetOk("cash_balance"); set {
+		params.CashBalance = expandCustomerCashBalance(d)
+	}
+	if source, set := d.GetOk("source"); set {
+		params.Source = stripe.String(ToString(source))
+	}
 	if meta, set := d.GetOk("metadata"); set {
 		for k, v := range ToMap(meta) {
 			params.AddMetadata(k, ToString(v))
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		customer, err = c.Customers.New(params)
 		return err
 	})
@@ -351,8 +557,16 @@ func resourceStripeCustomerCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceStripeCustomerRead(ctx, d, m)
 }
 
+func expandCustomerCashBalance(d *schema.ResourceData) *stripe.CustomerCashBalanceParams {
+	return &stripe.CustomerCashBalanceParams{
+		Settings: &stripe.CustomerCashBalanceSettingsParams{
+			ReconciliationMode: stripe.String(ExtractString(d, "cash_balance.0.settings.0.reconciliation_mode")),
+		},
+	}
+}
+
 func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.CustomerParams{}
@@ -369,9 +583,11 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 	if d.HasChange("phone") {
 		params.Phone = stripe.String(ExtractString(d, "phone"))
 	}
-	if d.HasChange("address") {
+	if addressMap := ExtractMap(d, "address"); d.HasChange("address") && len(addressMap) == 0 {
+		// an empty value unsets the address
+		params.AddExtra("address", "")
+	} else if d.HasChange("address") {
 		params.Address = &stripe.AddressParams{}
-		addressMap := ExtractMap(d, "address")
 		for k, v := range addressMap {
 			value := stripe.String(ToString(v))
 			switch k {
@@ -390,11 +606,13 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 			}
 		}
 	}
-	if d.HasChange("shipping") {
+	if shippingMap := ExtractMap(d, "shipping"); d.HasChange("shipping") && len(shippingMap) == 0 {
+		// an empty value unsets the shipping details
+		params.AddExtra("shipping", "")
+	} else if d.HasChange("shipping") {
 		params.Shipping = &stripe.CustomerShippingParams{
 			Address: &stripe.AddressParams{},
 		}
-		shippingMap := ExtractMap(d, "shipping")
 		for k, v := range shippingMap {
 			value := stripe.String(ToString(v))
 			switch k {
@@ -424,22 +642,18 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.InvoicePrefix = stripe.String(ExtractString(d, "invoice_prefix"))
 	}
 	if d.HasChange("invoice_settings") {
-		params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{}
-		invoiceSettingsMap := ExtractMap(d, "invoice_settings")
-		for k, v := range invoiceSettingsMap {
-			value := stripe.String(ToString(v))
-			switch k {
-			case "default_payment_method":
-				params.InvoiceSettings.DefaultPaymentMethod = value
-			case "footer":
-				params.InvoiceSettings.Footer = value
-			default:
-				params.InvoiceSettings.CustomFields = append(params.InvoiceSettings.CustomFields,
-					&stripe.CustomerInvoiceSettingsCustomFieldParams{
-						Name:  stripe.String(k),
-						Value: value,
-					})
-			}
+		oldInvoiceSettings, _ := d.GetChange("invoice_settings")
+		params.InvoiceSettings = expandCustomerInvoiceSettings(ExtractMap(d, "invoice_settings"))
+		removed := expandCustomerInvoiceSettings(ToMap(oldInvoiceSettings))
+		if len(params.InvoiceSettings.CustomFields) == 0 && len(removed.CustomFields) > 0 {
+			// an empty value removes the custom fields
+			params.AddExtra("invoice_settings[custom_fields]", "")
+		}
+		if params.InvoiceSettings.Footer == nil && removed.Footer != nil {
+			params.InvoiceSettings.Footer = stripe.String("")
+		}
+		if params.InvoiceSettings.RenderingOptions == nil && removed.RenderingOptions != nil {
+			params.AddExtra("invoice_settings[rendering_options]", "")
 		}
 	}
 	if d.HasChange("next_invoice_sequence") {
@@ -448,12 +662,22 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 	if d.HasChange("preferred_locales") {
 		params.PreferredLocales = stripe.StringSlice(ExtractStringSlice(d, "preferred_locales"))
 	}
+	if d.HasChange("tax_exempt") {
+		params.TaxExempt = stripe.String(ExtractString(d, "tax_exempt"))
+	}
+	if d.HasChange("cash_balance") {
+		params.CashBalance = expandCustomerCashBalance(d)
+	}
+	if d.HasChange("source") {
+		params.Source = stripe.String(ExtractString(d, "source"))
+	}
 	if d.HasChange("metadata") {
 		params.Metadata = nil
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Customers.Update(d.Id(), params)
 		return err
 	})
@@ -461,16 +685,73 @@ func resourceStripeCustomerUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("tax_id_data") {
+		if err = updateCustomerTaxIDs(ctx, d, c); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceStripeCustomerRead(ctx, d, m)
+}
+
+// updateCustomerTaxIDs removes and adds the changed tax IDs, Stripe can't update a tax ID in place.
+func updateCustomerTaxIDs(ctx context.Context, d *schema.ResourceData, c *stripeClient) error {
+	oldTaxIDData, newTaxIDData := d.GetChange("tax_id_data")
+	removed := ToMapSlice(oldTaxIDData.(*schema.Set).Difference(newTaxIDData.(*schema.Set)).List())
+	added := ToMapSlice(newTaxIDData.(*schema.Set).Difference(oldTaxIDData.(*schema.Set)).List())
+
+	if len(removed) > 0 {
+		taxIDs, err := listCustomerTaxIDs(ctx, d, c)
+		if err != nil {
+			return err
+		}
+		for _, taxIDData := range removed {
+			for _, taxID := range taxIDs {
+				if string(taxID.Type) != ToString(taxIDData["type"]) || taxID.Value != ToString(taxIDData["value"]) {
+					continue
+				}
+				params := &stripe.TaxIDParams{Customer: stripe.String(d.Id())}
+				setStripeAccount(d, params)
+				err = c.retryWithBackOff(ctx, params, func() error {
+					_, err = c.TaxIDs.Del(taxID.ID, params)
+					return err
+				})
+				if err != nil && !isNotFoundErr(err) {
+					return err
+				}
+			}
+		}
+	}
 
+	for _, taxIDData := range added {
+		params := &stripe.TaxIDParams{
+			Customer: stripe.String(d.Id()),
+			Type:     stripe.String(ToString(taxIDData["type"])),
+			Value:    stripe.String(ToString(taxIDData["value"])),
+		}
+		setStripeAccount(d, params)
+		params.IdempotencyKey = newIdempotencyKey(ctx)
+
+		err := c.retryWithBackOff(ctx, params, func() error {
+			_, err := c.TaxIDs.New(params)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func resourceStripeCustomerDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeCustomerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var err error
 
-	err = retryWithBackOff(func() error {
-		_, err = c.Customers.Del(d.Id(), nil)
+	params := &stripe.CustomerParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Customers.Del(d.Id(), params)
 		return err
 	})
 	if err != nil {
@@ -0,0 +1,162 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeCustomerTaxID() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeCustomerTaxIDRead,
+		CreateContext: resourceStripeCustomerTaxIDCreate,
+		DeleteContext: resourceStripeCustomerTaxIDDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the customer the tax ID belongs to.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Type of the tax ID, e.g. eu_vat, gb_vat, au_abn or us_ein. " +
+					"See the Stripe documentation for all supported types.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Value of the tax ID.",
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Two-letter ISO code representing the country of the tax ID.",
+			},
+			"verification_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Verification status, one of pending, verified, unverified, or unavailable.",
+			},
+			"verified_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Verified name.",
+			},
+			"verified_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Verified address.",
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the object was created. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+func resourceStripeCustomerTaxIDRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var taxID *stripe.TaxID
+	var err error
+
+	params := &stripe.TaxIDParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		taxID, err = c.TaxIDs.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	verification := &stripe.TaxIDVerification{}
+	if taxID.Verification != nil {
+		verification = taxID.Verification
+	}
+
+	return CallSet(
+		func() error {
+			if taxID.Customer != nil {
+				return d.Set("customer", taxID.Customer.ID)
+			}
+			return nil
+		}(),
+		d.Set("type", taxID.Type),
+		d.Set("value", taxID.Value),
+		d.Set("country", taxID.Country),
+		d.Set("verification_status", verification.Status),
+		d.Set("verified_name", verification.VerifiedName),
+		d.Set("verified_address", verification.VerifiedAddress),
+		d.Set("created", taxID.Created),
+	)
+}
+
+func resourceStripeCustomerTaxIDCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var taxID *stripe.TaxID
+	var err error
+
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+		Type:     stripe.String(ExtractString(d, "type")),
+		Value:    stripe.String(ExtractString(d, "value")),
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		taxID, err = c.TaxIDs.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(taxID.ID)
+	return resourceStripeCustomerTaxIDRead(ctx, d, m)
+}
+
+func resourceStripeCustomerTaxIDDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.TaxIDParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.TaxIDs.Del(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,31 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeCustomerTaxID(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_customer_tax_id",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"type":     "eu_vat",
+				"value":    "DE123456789",
+			},
+			checks: map[string]string{
+				"customer":            "cus_standin",
+				"country":             "EU",
+				"verification_status": "pending",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"type":     "eu_vat",
+				"value":    "DE987654321",
+			},
+			checks: map[string]string{
+				"value": "DE987654321",
+			},
+		},
+	})
+}
@@ -0,0 +1,196 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeCustomer(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_customer",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":        "Jane Doe",
+				"email":       "jane@example.com",
+				"description": "created by the acceptance tests",
+				"address": map[string]interface{}{
+					"line1":       "1 Main Street",
+					"city":        "Sydney",
+					"postal_code": "2000",
+					"country":     "AU",
+				},
+				"shipping": map[string]interface{}{
+					"name":    "Jane Doe",
+					"line1":   "1 Main Street",
+					"city":    "Sydney",
+					"country": "AU",
+				},
+				"invoice_prefix": "JANE01",
+				"metadata":       map[string]interface{}{"plan": "pro"},
+			},
+			checks: map[string]string{
+				"name":                "Jane Doe",
+				"address.postal_code": "2000",
+				"shipping.name":       "Jane Doe",
+				"invoice_prefix":      "JANE01",
+				"metadata.plan":       "pro",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":  "Jane Roe",
+				"email": "jane@example.com",
+				"address": map[string]interface{}{
+					"line1":       "2 Main Street",
+					"city":        "Sydney",
+					"postal_code": "2000",
+					"country":     "AU",
+				},
+				"invoice_prefix": "JANE01",
+				"metadata":       map[string]interface{}{"plan": "enterprise"},
+			},
+			checks: map[string]string{
+				"name":          "Jane Roe",
+				"address.line1": "2 Main Street",
+				"description":   "",
+				"metadata.plan": "enterprise",
+			},
+		},
+		// the prefix is only read back when it's configured, default_invoice_prefix holds the value
+		importIgnore: []string{"invoice_prefix"},
+	})
+}
+
+func TestAccStripeCustomerUnsetAddress(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	customer := func(values map[string]interface{}) string {
+		values["name"] = "Jane Doe"
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_customer", "test", values))
+	}
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: customer(map[string]interface{}{
+					"address": map[string]interface{}{"line1": "1 Main Street", "country": "AU"},
+					"shipping": map[string]interface{}{
+						"name":    "Jane Doe",
+						"line1":   "1 Main Street",
+						"country": "AU",
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKeepState("stripe_customer.test", state),
+					testAccCheckAttributes("stripe_customer.test", map[string]string{
+						"address.line1": "1 Main Street",
+						"shipping.name": "Jane Doe",
+					}),
+				),
+			},
+			{
+				// removing the blocks from the configuration unsets them in Stripe
+				Config: customer(map[string]interface{}{}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSameObject("stripe_customer.test", state, true),
+					testAccCheckAttributes("stripe_customer.test", map[string]string{
+						"address.%":  "0",
+						"shipping.%": "0",
+					}),
+					func(*terraform.State) error {
+						obj, _ := standIn.object(state.ID)
+						for _, key := range []string{"address", "shipping"} {
+							if obj[key] != nil {
+								return fmt.Errorf("expected the %s of %s to be unset, got %v", key, state.ID, obj[key])
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeCustomerTaxDetails(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_customer",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":       "Acme GmbH",
+				"tax_exempt": "reverse",
+				"tax_id_data": []interface{}{
+					map[string]interface{}{"type": "eu_vat", "value": "DE123456789"},
+					map[string]interface{}{"type": "gb_vat", "value": "GB123456789"},
+				},
+				"test_clock": "clock_standin",
+				"cash_balance": []interface{}{
+					map[string]interface{}{
+						"settings": []interface{}{
+							map[string]interface{}{"reconciliation_mode": "manual"},
+						},
+					},
+				},
+				"invoice_settings": map[string]interface{}{
+					"footer":             "Reverse charge",
+					"amount_tax_display": "exclude_tax",
+					"PO number":          "4711",
+				},
+				"source": "tok_visa",
+			},
+			checks: map[string]string{
+				"tax_exempt":    "reverse",
+				"tax_id_data.#": "2",
+				"test_clock":    "clock_standin",
+				"cash_balance.0.settings.0.reconciliation_mode": "manual",
+				"invoice_settings.%":                            "3",
+				"invoice_settings.PO number":                    "4711",
+				"invoice_settings.amount_tax_display":           "exclude_tax",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":       "Acme GmbH",
+				"tax_exempt": "none",
+				"tax_id_data": []interface{}{
+					map[string]interface{}{"type": "eu_vat", "value": "DE987654321"},
+					map[string]interface{}{"type": "gb_vat", "value": "GB123456789"},
+				},
+				"test_clock": "clock_standin",
+				"cash_balance": []interface{}{
+					map[string]interface{}{
+						"settings": []interface{}{
+							map[string]interface{}{"reconciliation_mode": "automatic"},
+						},
+					},
+				},
+				"invoice_settings": map[string]interface{}{
+					"footer": "Thank you",
+				},
+				"source": "tok_visa",
+			},
+			checks: map[string]string{
+				"tax_exempt":    "none",
+				"tax_id_data.#": "2",
+				"cash_balance.0.settings.0.reconciliation_mode": "automatic",
+				"invoice_settings.%":                            "1",
+				"invoice_settings.footer":                       "Thank you",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"name":       "Acme GmbH",
+				"test_clock": "clock_other",
+			},
+			checks: map[string]string{
+				"test_clock": "clock_other",
+			},
+		},
+		// the token can't be read back from Stripe
+		importIgnore: []string{"source"},
+	})
+}
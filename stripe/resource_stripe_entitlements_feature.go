@@ -6,8 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeEntitlementsFeature() *schema.Resource {
@@ -17,7 +17,7 @@ func resourceStripeEntitlementsFeature() *schema.Resource {
 		UpdateContext: resourceStripeEntitlementsFeatureUpdate,
 		DeleteContext: resourceStripeEntitlementsFeatureDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -25,6 +25,7 @@ func resourceStripeEntitlementsFeature() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"lookup_key": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -52,6 +53,15 @@ func resourceStripeEntitlementsFeature() *schema.Resource {
 				Description: "Has the value true if the object exists in live mode or the value false " +
 					"if the object exists in test mode",
 			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the feature when the resource is destroyed, " +
+					"Stripe doesn't support its deletion. Either archive (the feature is set inactive) " +
+					"or abandon (the feature is only removed from the Terraform state). Defaults to archive.",
+			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -63,13 +73,16 @@ func resourceStripeEntitlementsFeature() *schema.Resource {
 	}
 }
 
-func resourceStripeEntitlementsFeatureRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeEntitlementsFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var entitlementsFeature *stripe.EntitlementsFeature
 	var err error
 
-	err = retryWithBackOff(func() error {
-		entitlementsFeature, err = c.EntitlementsFeatures.Get(d.Id(), nil)
+	params := &stripe.EntitlementsFeatureParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		entitlementsFeature, err = c.EntitlementsFeatures.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -91,7 +104,7 @@ func resourceStripeEntitlementsFeatureRead(_ context.Context, d *schema.Resource
 }
 
 func resourceStripeEntitlementsFeatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var entitlementsFeature *stripe.EntitlementsFeature
 	var err error
 
@@ -106,7 +119,10 @@ func resourceStripeEntitlementsFeatureCreate(ctx context.Context, d *schema.Reso
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		entitlementsFeature, err = c.EntitlementsFeatures.New(params)
 		return err
 	})
@@ -118,7 +134,7 @@ func resourceStripeEntitlementsFeatureCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceStripeEntitlementsFeatureUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.EntitlementsFeatureParams{}
@@ -132,7 +148,8 @@ func resourceStripeEntitlementsFeatureUpdate(ctx context.Context, d *schema.Reso
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.EntitlementsFeatures.Update(d.Id(), params)
 		return err
 	})
@@ -142,8 +159,29 @@ func resourceStripeEntitlementsFeatureUpdate(ctx context.Context, d *schema.Reso
 	return resourceStripeEntitlementsFeatureRead(ctx, d, m)
 }
 
-func resourceStripeEntitlementsFeatureDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
-	tflog.Warn(ctx, "[WARN] Stripe API doesn't support deletion of entitlements feature")
+func resourceStripeEntitlementsFeatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Entitlements feature is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.EntitlementsFeatureParams{
+		Active: stripe.Bool(false),
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.EntitlementsFeatures.Update(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
@@ -0,0 +1,34 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeEntitlementsFeature(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_entitlements_feature",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":       "Single sign-on",
+				"lookup_key": "sso",
+				"metadata":   map[string]interface{}{"team": "identity"},
+			},
+			checks: map[string]string{
+				"name":          "Single sign-on",
+				"lookup_key":    "sso",
+				"active":        "true",
+				"metadata.team": "identity",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":       "SAML single sign-on",
+				"lookup_key": "sso",
+				"metadata":   map[string]interface{}{"team": "platform"},
+			},
+			checks: map[string]string{
+				"name":          "SAML single sign-on",
+				"metadata.team": "platform",
+			},
+		},
+		importIgnore: []string{"on_destroy"},
+	})
+}
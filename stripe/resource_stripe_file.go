@@ -8,8 +8,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeFile() *schema.Resource {
@@ -17,8 +17,9 @@ func resourceStripeFile() *schema.Resource {
 		ReadContext:   resourceStripeFileRead,
 		CreateContext: resourceStripeFileCreate,
 		DeleteContext: resourceStripeFileDelete,
+		CustomizeDiff: resourceStripeFileCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -26,6 +27,7 @@ func resourceStripeFile() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -45,9 +47,21 @@ func resourceStripeFile() *schema.Resource {
 				Description: "A content file to upload encoded by base64.",
 			},
 			"purpose": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.FilePurposeAccountRequirement),
+					string(stripe.FilePurposeAdditionalVerification),
+					string(stripe.FilePurposeBusinessIcon),
+					string(stripe.FilePurposeBusinessLogo),
+					string(stripe.FilePurposeCustomerSignature),
+					string(stripe.FilePurposeDisputeEvidence),
+					string(stripe.FilePurposeIdentityDocument),
+					string(stripe.FilePurposePCIDocument),
+					string(stripe.FilePurposeTaxDocumentUserUpload),
+					string(stripe.FilePurposeTerminalReaderSplashscreen),
+				}, false),
 				Description: "The purpose of the uploaded file.",
 			},
 			"link_data": {
@@ -164,13 +178,40 @@ func resourceStripeFile() *schema.Resource {
 	}
 }
 
-func resourceStripeFileRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeFileCustomizeDiff checks a file link is only requested for the purposes supporting it.
+func resourceStripeFileCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !diffKnown(d, "purpose", "link_data") {
+		return nil
+	}
+
+	purpose := ToString(d.Get("purpose"))
+	if !ToBool(ToMap(d.Get("link_data"))["create"]) {
+		return nil
+	}
+	switch stripe.FilePurpose(purpose) {
+	case stripe.FilePurposeBusinessIcon,
+		stripe.FilePurposeBusinessLogo,
+		stripe.FilePurposeCustomerSignature,
+		stripe.FilePurposeDisputeEvidence,
+		stripe.FilePurposePCIDocument,
+		stripe.FilePurposeTaxDocumentUserUpload,
+		stripe.FilePurposeTerminalReaderSplashscreen:
+		return nil
+	default:
+		return diffError("link_data.0.create", "a file link can't be created for a file with purpose %q", purpose)
+	}
+}
+
+func resourceStripeFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var file *stripe.File
 	var err error
 
-	err = retryWithBackOff(func() error {
-		file, err = c.Files.Get(d.Id(), nil)
+	params := &stripe.FileParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		file, err = c.Files.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -213,7 +254,7 @@ func resourceStripeFileRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceStripeFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var file *stripe.File
 	var err error
 
@@ -248,7 +289,9 @@ func resourceStripeFileCreate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		file, err = c.Files.New(params)
 		return err
 	})
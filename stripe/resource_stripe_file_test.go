@@ -0,0 +1,50 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeFile(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_file",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"filename":      "logo.png",
+				"purpose":       "business_logo",
+				"base64content": "iVBORw0KGgo=",
+			},
+			checks: map[string]string{
+				"filename": "logo.png",
+				"purpose":  "business_logo",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"filename":      "icon.png",
+				"purpose":       "business_icon",
+				"base64content": "iVBORw0KGgo=",
+				"link_data": []interface{}{
+					map[string]interface{}{"create": true},
+				},
+			},
+			checks: map[string]string{
+				"filename": "icon.png",
+				"purpose":  "business_icon",
+			},
+		},
+		// the content is uploaded once and never returned
+		importIgnore: []string{"base64content", "link_data"},
+	})
+}
+
+func TestAccStripeFilePlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_file", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"filename":      "passport.png",
+				"purpose":       "identity_document",
+				"base64content": "iVBORw0KGgo=",
+				"link_data":     []interface{}{map[string]interface{}{"create": true}},
+			},
+			err: `link_data.0.create: a file link can't be created for a file with purpose "identity_document"`,
+		},
+	})
+}
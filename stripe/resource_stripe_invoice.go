@@ -0,0 +1,537 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	invoiceActionFinalize = "finalize"
+	invoiceActionSend     = "send"
+	invoiceActionVoid     = "void"
+
+	onDestroyVoid        = "void"
+	onDestroyDeleteDraft = "delete_draft"
+)
+
+// invoiceActions are the lifecycle actions in the order an invoice goes through them.
+var invoiceActions = []string{"", invoiceActionFinalize, invoiceActionSend, invoiceActionVoid}
+
+func resourceStripeInvoice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeInvoiceRead,
+		CreateContext: resourceStripeInvoiceCreate,
+		UpdateContext: resourceStripeInvoiceUpdate,
+		DeleteContext: resourceStripeInvoiceDelete,
+		CustomizeDiff: resourceStripeInvoiceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer who will be billed.",
+			},
+			"collection_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(stripe.InvoiceCollectionMethodChargeAutomatically),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.InvoiceCollectionMethodChargeAutomatically),
+					string(stripe.InvoiceCollectionMethodSendInvoice),
+				}, false),
+				Description: "Either charge_automatically, or send_invoice. " +
+					"Defaults to charge_automatically.",
+			},
+			"days_until_due": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The number of days from when the invoice is created until it is due. " +
+					"Valid only for invoices where collection_method is send_invoice. " +
+					"Stripe only returns the resulting due_date.",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "An arbitrary string attached to the object. " +
+					"Referenced as ‘memo’ in the Dashboard.",
+			},
+			"custom_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    4,
+				Description: "A list of up to 4 custom fields to be displayed on the invoice.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 40),
+							Description:  "The name of the custom field. This may be up to 40 characters.",
+						},
+						"value": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 140),
+							Description:  "The value of the custom field. This may be up to 140 characters.",
+						},
+					},
+				},
+			},
+			"footer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "Footer to be displayed on the invoice. " +
+					"Defaults to the invoice footer of the customer or the account.",
+			},
+			"auto_advance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Controls whether Stripe performs automatic collection of the invoice. " +
+					"If false, the invoice's state doesn't automatically advance without an explicit action.",
+			},
+			"pending_invoice_items_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// only sent on creation, the items of existing invoices are managed by stripe_invoice_item
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+				ValidateFunc: validation.StringInSlice([]string{
+					"exclude",
+					"include",
+				}, false),
+				Description: "How to handle pending invoice items on invoice creation. " +
+					"Either include (the pending invoice items of the customer are added to the invoice) " +
+					"or exclude. Stripe defaults to exclude when not set. Only used when the invoice is created.",
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					invoiceActionFinalize,
+					invoiceActionSend,
+					invoiceActionVoid,
+				}, false),
+				Description: "The lifecycle action applied to the invoice, it stays a draft when not set. " +
+					"Either finalize (the invoice is finalized), send (the finalized invoice is emailed to the customer) " +
+					"or void (the finalized invoice is voided). Actions only go forward, a finalized invoice can't " +
+					"become a draft again.",
+			},
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  onDestroyVoid,
+				ValidateFunc: validation.StringInSlice([]string{
+					onDestroyVoid,
+					onDestroyDeleteDraft,
+					onDestroyAbandon,
+				}, false),
+				Description: "What happens with the invoice when the resource is destroyed. " +
+					"Either void (draft invoices are deleted, finalized invoices are voided), " +
+					"delete_draft (draft invoices are deleted, destroying a finalized invoice fails) " +
+					"or abandon (the invoice is only removed from the Terraform state). Defaults to void.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the invoice, one of draft, open, paid, uncollectible, or void.",
+			},
+			"due_date": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "The date on which payment for this invoice is due, measured in seconds since the " +
+					"Unix epoch. Only set for invoices where collection_method is send_invoice.",
+			},
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "A unique, identifying string that appears on emails sent to the customer for this invoice. " +
+					"It's assigned when the invoice is finalized.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Three-letter ISO currency code, in lowercase.",
+			},
+			"amount_due": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Final amount due at this time for this invoice.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total after discounts and taxes.",
+			},
+			"hosted_invoice_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The URL for the hosted invoice page, which allows customers to view and pay an invoice. " +
+					"It's available once the invoice is finalized.",
+			},
+			"invoice_pdf": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The link to download the PDF for the invoice once it's finalized.",
+			},
+		},
+	}
+}
+
+// resourceStripeInvoiceCustomizeDiff checks the invoice due days and the lifecycle actions,
+// actions can't be undone.
+func resourceStripeInvoiceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if diffKnown(d, "collection_method", "days_until_due", "action") {
+		collectionMethod := ToString(d.Get("collection_method"))
+		sendInvoice := collectionMethod == string(stripe.InvoiceCollectionMethodSendInvoice)
+		if d.HasChange("days_until_due") && ToInt(d.Get("days_until_due")) != 0 && !sendInvoice {
+			return diffError("days_until_due", "only allowed when collection_method is send_invoice, got %q",
+				collectionMethod)
+		}
+		if d.HasChange("action") && ToString(d.Get("action")) == invoiceActionSend && !sendInvoice {
+			return diffError("action", "send is only allowed when collection_method is send_invoice, got %q",
+				collectionMethod)
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("days_until_due") {
+		if err := d.SetNewComputed("due_date"); err != nil {
+			return err
+		}
+	}
+
+	// a new customer or account creates a new invoice, the actions start over
+	if d.Id() == "" || !d.HasChange("action") || d.HasChanges("customer", "stripe_account") {
+		return nil
+	}
+	oldAction, newAction := d.GetChange("action")
+	if invoiceActionOrder(ToString(newAction)) < invoiceActionOrder(ToString(oldAction)) {
+		return diffError("action", "the invoice can't go back from %s to %q", oldAction, newAction)
+	}
+	for _, k := range []string{"status", "number", "hosted_invoice_url", "invoice_pdf"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func invoiceActionOrder(action string) int {
+	for i, a := range invoiceActions {
+		if a == action {
+			return i
+		}
+	}
+	return 0
+}
+
+func resourceStripeInvoiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoice *stripe.Invoice
+	var err error
+
+	params := &stripe.InvoiceParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoice, err = c.Invoices.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", invoice.Customer.ID),
+		d.Set("collection_method", invoice.CollectionMethod),
+		d.Set("description", invoice.Description),
+		func() error {
+			var customFields []map[string]interface{}
+			for _, customField := range invoice.CustomFields {
+				customFields = append(customFields, map[string]interface{}{
+					"name":  customField.Name,
+					"value": customField.Value,
+				})
+			}
+			return d.Set("custom_fields", customFields)
+		}(),
+		d.Set("footer", invoice.Footer),
+		d.Set("auto_advance", invoice.AutoAdvance),
+		d.Set("metadata", invoice.Metadata),
+		d.Set("status", invoice.Status),
+		d.Set("due_date", invoice.DueDate),
+		d.Set("number", invoice.Number),
+		d.Set("currency", invoice.Currency),
+		d.Set("amount_due", invoice.AmountDue),
+		d.Set("total", invoice.Total),
+		d.Set("hosted_invoice_url", invoice.HostedInvoiceURL),
+		d.Set("invoice_pdf", invoice.InvoicePDF),
+	)
+}
+
+func resourceStripeInvoiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoice *stripe.Invoice
+	var err error
+
+	params := &stripe.InvoiceParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if collectionMethod, set := d.GetOk("collection_method"); set {
+		params.CollectionMethod = stripe.String(ToString(collectionMethod))
+	}
+	if daysUntilDue, set := d.GetOk("days_until_due"); set {
+		params.DaysUntilDue = stripe.Int64(ToInt64(daysUntilDue))
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if customFields, set := d.GetOk("custom_fields"); set {
+		params.CustomFields = expandInvoiceCustomFields(customFields)
+	}
+	if footer, set := d.GetOk("footer"); set {
+		params.Footer = stripe.String(ToString(footer))
+	}
+	if autoAdvance, set := d.GetOkExists("auto_advance"); set {
+		params.AutoAdvance = stripe.Bool(ToBool(autoAdvance))
+	}
+	if pendingInvoiceItemsBehavior, set := d.GetOk("pending_invoice_items_behavior"); set {
+		params.PendingInvoiceItemsBehavior = stripe.String(ToString(pendingInvoiceItemsBehavior))
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoice, err = c.Invoices.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(invoice.ID)
+	if err = applyInvoiceAction(ctx, d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+// expandInvoiceCustomFields builds the custom fields of the invoice.
+func expandInvoiceCustomFields(customFields interface{}) []*stripe.InvoiceCustomFieldParams {
+	var params []*stripe.InvoiceCustomFieldParams
+	for _, customField := range ToMapSlice(customFields) {
+		params = append(params, &stripe.InvoiceCustomFieldParams{
+			Name:  stripe.String(ToString(customField["name"])),
+			Value: stripe.String(ToString(customField["value"])),
+		})
+	}
+	return params
+}
+
+func resourceStripeInvoiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.InvoiceParams{}
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(ExtractString(d, "collection_method"))
+	}
+	if d.HasChange("days_until_due") {
+		params.DaysUntilDue = NonZeroInt64(ExtractInt64(d, "days_until_due"))
+	}
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("custom_fields") {
+		customFields := expandInvoiceCustomFields(d.Get("custom_fields"))
+		if len(customFields) == 0 {
+			params.AddExtra("custom_fields", "")
+		} else {
+			params.CustomFields = customFields
+		}
+	}
+	if d.HasChange("footer") {
+		params.Footer = stripe.String(ExtractString(d, "footer"))
+	}
+	if d.HasChange("auto_advance") {
+		params.AutoAdvance = stripe.Bool(ExtractBool(d, "auto_advance"))
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	// finalized invoices take few updates, voided ones none, a change of the action only doesn't update
+	if d.HasChanges("collection_method", "days_until_due", "description", "custom_fields", "footer",
+		"auto_advance", "metadata") {
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Invoices.Update(d.Id(), params)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("action") {
+		if err = applyInvoiceAction(ctx, d, c); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceStripeInvoiceRead(ctx, d, m)
+}
+
+// applyInvoiceAction moves the invoice forward to the configured action, drafts are finalized on the way.
+// It's only applied when the action is new to the configuration, sending again emails the customer again.
+func applyInvoiceAction(ctx context.Context, d *schema.ResourceData, c *stripeClient) error {
+	var invoice *stripe.Invoice
+	var err error
+
+	action := ExtractString(d, "action")
+	if action == "" {
+		return nil
+	}
+
+	// the invoice may have been finalized by Stripe already, e.g. by auto_advance
+	invoiceParams := &stripe.InvoiceParams{}
+	setStripeAccount(d, invoiceParams)
+	err = c.retryWithBackOff(ctx, invoiceParams, func() error {
+		invoice, err = c.Invoices.Get(d.Id(), invoiceParams)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if invoice.Status == stripe.InvoiceStatusDraft {
+		params := &stripe.InvoiceFinalizeInvoiceParams{}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Invoices.FinalizeInvoice(d.Id(), params)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("finalizing invoice %s: %w", d.Id(), err)
+		}
+	}
+
+	switch {
+	case action == invoiceActionSend:
+		params := &stripe.InvoiceSendInvoiceParams{}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Invoices.SendInvoice(d.Id(), params)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("sending invoice %s: %w", d.Id(), err)
+		}
+	case action == invoiceActionVoid && invoice.Status != stripe.InvoiceStatusVoid:
+		params := &stripe.InvoiceVoidInvoiceParams{}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Invoices.VoidInvoice(d.Id(), params)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("voiding invoice %s: %w", d.Id(), err)
+		}
+	}
+	return nil
+}
+
+func resourceStripeInvoiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoice *stripe.Invoice
+	var err error
+
+	onDestroy := ExtractString(d, "on_destroy")
+	if onDestroy == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Invoice is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	params := &stripe.InvoiceParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoice, err = c.Invoices.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	switch invoice.Status {
+	case stripe.InvoiceStatusDraft:
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Invoices.Del(d.Id(), params)
+			return err
+		})
+	case stripe.InvoiceStatusOpen, stripe.InvoiceStatusUncollectible:
+		if onDestroy == onDestroyDeleteDraft {
+			return diag.Errorf("invoice %s is %s, only draft invoices are deleted with on_destroy = %q, "+
+				"set on_destroy to %q to void it or %q to keep it", d.Id(), invoice.Status, onDestroyDeleteDraft,
+				onDestroyVoid, onDestroyAbandon)
+		}
+		voidParams := &stripe.InvoiceVoidInvoiceParams{}
+		setStripeAccount(d, voidParams)
+		err = c.retryWithBackOff(ctx, voidParams, func() error {
+			_, err = c.Invoices.VoidInvoice(d.Id(), voidParams)
+			return err
+		})
+	default:
+		// paid and void invoices are final
+		tflog.Warn(ctx, "[WARN] Invoice can't be voided, it's only removed from the Terraform state",
+			map[string]interface{}{"status": invoice.Status})
+	}
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
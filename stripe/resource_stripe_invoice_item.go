@@ -0,0 +1,418 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeInvoiceItem() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeInvoiceItemRead,
+		CreateContext: resourceStripeInvoiceItemCreate,
+		UpdateContext: resourceStripeInvoiceItemUpdate,
+		DeleteContext: resourceStripeInvoiceItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer who will be billed when this invoice item is billed.",
+			},
+			"invoice": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of an existing draft invoice to add this invoice item to. " +
+					"When omitted, the item is pending and added to the next invoice of the customer.",
+			},
+			"price": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"price", "amount"},
+				Description: "The ID of the price object. One of price or amount is required, " +
+					"Stripe generates a price for items created by amount.",
+			},
+			"amount": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"currency"},
+				ConflictsWith: []string{"quantity"},
+				Description: "The integer amount in cents of the charge to be applied to the upcoming invoice. " +
+					"Passing in a negative amount will reduce the amount due on the invoice.",
+			},
+			"currency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase. Required with amount.",
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Non-negative integer. The quantity of units of the price. Defaults to 1.",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "An arbitrary string which you can attach to the invoice item. " +
+					"The description is displayed in the invoice for easy tracking.",
+			},
+			"period": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Description: "The period associated with this invoice item. " +
+					"Defaults to the moment of the creation.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The start of the period, measured in seconds since the Unix epoch.",
+						},
+						"end": {
+							Type:     schema.TypeInt,
+							Required: true,
+							Description: "The end of the period, which must be greater than or equal to the start. " +
+								"Measured in seconds since the Unix epoch.",
+						},
+					},
+				},
+			},
+			"tax_rates": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The tax rates which apply to the invoice item.",
+			},
+			"discounts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The coupons and promotion codes to redeem into discounts for the invoice item.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"coupon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the coupon to create a new discount for.",
+						},
+						"promotion_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the promotion code to create a new discount for.",
+						},
+					},
+				},
+			},
+			"discountable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Controls whether discounts apply to this invoice item. " +
+					"Defaults to false for prorations or negative invoice items, and true for all other invoice items.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"date": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the invoice item was created. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+func resourceStripeInvoiceItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoiceItem *stripe.InvoiceItem
+	var err error
+
+	params := &stripe.InvoiceItemParams{}
+	params.AddExpand("discounts")
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoiceItem, err = c.InvoiceItems.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("customer", invoiceItem.Customer.ID),
+		func() error {
+			if invoiceItem.Invoice != nil {
+				return d.Set("invoice", invoiceItem.Invoice.ID)
+			}
+			return d.Set("invoice", "")
+		}(),
+		func() error {
+			if invoiceItem.Price != nil {
+				return d.Set("price", invoiceItem.Price.ID)
+			}
+			return d.Set("price", "")
+		}(),
+		d.Set("amount", invoiceItem.Amount),
+		d.Set("currency", invoiceItem.Currency),
+		d.Set("quantity", invoiceItem.Quantity),
+		d.Set("description", invoiceItem.Description),
+		func() error {
+			if invoiceItem.Period != nil {
+				return d.Set("period", []map[string]interface{}{
+					{
+						"start": invoiceItem.Period.Start,
+						"end":   invoiceItem.Period.End,
+					},
+				})
+			}
+			return nil
+		}(),
+		func() error {
+			var taxRates []string
+			for _, taxRate := range invoiceItem.TaxRates {
+				taxRates = append(taxRates, taxRate.ID)
+			}
+			return d.Set("tax_rates", taxRates)
+		}(),
+		d.Set("discounts", flattenInvoiceItemDiscounts(invoiceItem.Discounts)),
+		d.Set("discountable", invoiceItem.Discountable),
+		d.Set("metadata", invoiceItem.Metadata),
+		d.Set("date", invoiceItem.Date),
+	)
+}
+
+// flattenInvoiceItemDiscounts turns the discounts back into the coupons and promotion codes they were created for.
+func flattenInvoiceItemDiscounts(discounts []*stripe.Discount) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, discount := range discounts {
+		flat := map[string]interface{}{}
+		if discount.Coupon != nil {
+			flat["coupon"] = discount.Coupon.ID
+		}
+		if discount.PromotionCode != nil {
+			// the discount of a promotion code applies its coupon
+			flat["coupon"] = ""
+			flat["promotion_code"] = discount.PromotionCode.ID
+		}
+		flattened = append(flattened, flat)
+	}
+	return flattened
+}
+
+// expandInvoiceItemDiscounts builds the discounts of the invoice item, an empty list removes them.
+func expandInvoiceItemDiscounts(discounts interface{}) []*stripe.InvoiceItemDiscountParams {
+	var params []*stripe.InvoiceItemDiscountParams
+	for _, discount := range ToMapSlice(discounts) {
+		params = append(params, &stripe.InvoiceItemDiscountParams{
+			Coupon:        NonZeroString(discount["coupon"]),
+			PromotionCode: NonZeroString(discount["promotion_code"]),
+		})
+	}
+	return params
+}
+
+func resourceStripeInvoiceItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoiceItem *stripe.InvoiceItem
+	var err error
+
+	params := &stripe.InvoiceItemParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	if invoice, set := d.GetOk("invoice"); set {
+		params.Invoice = stripe.String(ToString(invoice))
+	}
+	if price, set := d.GetOk("price"); set {
+		params.Price = stripe.String(ToString(price))
+	}
+	if amount, set := d.GetOk("amount"); set {
+		params.Amount = stripe.Int64(ToInt64(amount))
+	}
+	if currency, set := d.GetOk("currency"); set {
+		params.Currency = stripe.String(ToString(currency))
+	}
+	if quantity, set := d.GetOk("quantity"); set {
+		params.Quantity = stripe.Int64(ToInt64(quantity))
+	}
+	if description, set := d.GetOk("description"); set {
+		params.Description = stripe.String(ToString(description))
+	}
+	if period, set := d.GetOk("period"); set {
+		periodMap := ToMap(period)
+		params.Period = &stripe.InvoiceItemPeriodParams{
+			Start: stripe.Int64(ToInt64(periodMap["start"])),
+			End:   stripe.Int64(ToInt64(periodMap["end"])),
+		}
+	}
+	if taxRates, set := d.GetOk("tax_rates"); set {
+		params.TaxRates = stripe.StringSlice(ToStringSlice(taxRates))
+	}
+	if discounts, set := d.GetOk("discounts"); set {
+		params.Discounts = expandInvoiceItemDiscounts(discounts)
+	}
+	if discountable, set := d.GetOkExists("discountable"); set {
+		params.Discountable = stripe.Bool(ToBool(discountable))
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoiceItem, err = c.InvoiceItems.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(invoiceItem.ID)
+	return resourceStripeInvoiceItemRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.InvoiceItemParams{}
+
+	// price and amount are computed from each other, only the configured one changes
+	if d.HasChange("price") {
+		params.Price = stripe.String(ExtractString(d, "price"))
+	}
+	if d.HasChange("amount") {
+		params.Amount = stripe.Int64(ExtractInt64(d, "amount"))
+	}
+	if d.HasChange("quantity") {
+		params.Quantity = stripe.Int64(ExtractInt64(d, "quantity"))
+	}
+	if d.HasChange("description") {
+		params.Description = stripe.String(ExtractString(d, "description"))
+	}
+	if d.HasChange("period") {
+		if period, set := d.GetOk("period"); set {
+			periodMap := ToMap(period)
+			params.Period = &stripe.InvoiceItemPeriodParams{
+				Start: stripe.Int64(ToInt64(periodMap["start"])),
+				End:   stripe.Int64(ToInt64(periodMap["end"])),
+			}
+		}
+	}
+	if d.HasChange("tax_rates") {
+		taxRates := ExtractStringSlice(d, "tax_rates")
+		if len(taxRates) == 0 {
+			params.AddExtra("tax_rates", "")
+		} else {
+			params.TaxRates = stripe.StringSlice(taxRates)
+		}
+	}
+	if d.HasChange("discounts") {
+		discounts := expandInvoiceItemDiscounts(d.Get("discounts"))
+		if len(discounts) == 0 {
+			params.AddExtra("discounts", "")
+		} else {
+			params.Discounts = discounts
+		}
+	}
+	if d.HasChange("discountable") {
+		params.Discountable = stripe.Bool(ExtractBool(d, "discountable"))
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.InvoiceItems.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeInvoiceItemRead(ctx, d, m)
+}
+
+func resourceStripeInvoiceItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var invoiceItem *stripe.InvoiceItem
+	var err error
+
+	// items of finalized invoices can't be deleted, they're gone with the voided invoice
+	params := &stripe.InvoiceItemParams{}
+	params.AddExpand("invoice")
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		invoiceItem, err = c.InvoiceItems.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+	if invoiceItem.Invoice != nil && invoiceItem.Invoice.Status != "" &&
+		invoiceItem.Invoice.Status != stripe.InvoiceStatusDraft {
+		// the status is only known when the invoice is expanded
+		tflog.Warn(ctx, "[WARN] Invoice item of a finalized invoice is only removed from the Terraform state",
+			map[string]interface{}{"invoice": invoiceItem.Invoice.ID, "status": invoiceItem.Invoice.Status})
+		d.SetId("")
+		return nil
+	}
+
+	deleteParams := &stripe.InvoiceItemParams{}
+	setStripeAccount(d, deleteParams)
+	err = c.retryWithBackOff(ctx, deleteParams, func() error {
+		_, err = c.InvoiceItems.Del(d.Id(), deleteParams)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
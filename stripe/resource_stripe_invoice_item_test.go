@@ -0,0 +1,69 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeInvoiceItem(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_invoice_item",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer":    "cus_standin",
+				"amount":      500000,
+				"currency":    "usd",
+				"description": "Onboarding workshop",
+				"period": []interface{}{
+					map[string]interface{}{"start": 1767225600, "end": 1769904000},
+				},
+				"tax_rates": []interface{}{"txr_standin"},
+				"discounts": []interface{}{
+					map[string]interface{}{"coupon": "co_standin"},
+				},
+				"metadata": map[string]interface{}{"contract": "ENT-42"},
+			},
+			checks: map[string]string{
+				"amount":             "500000",
+				"currency":           "usd",
+				"description":        "Onboarding workshop",
+				"period.0.end":       "1769904000",
+				"tax_rates.0":        "txr_standin",
+				"discounts.0.coupon": "co_standin",
+				"discountable":       "true",
+				"metadata.contract":  "ENT-42",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":    "cus_standin",
+				"amount":      450000,
+				"currency":    "usd",
+				"description": "Onboarding workshop (2 days)",
+				"period": []interface{}{
+					map[string]interface{}{"start": 1767225600, "end": 1769904000},
+				},
+				"discountable": false,
+				"metadata":     map[string]interface{}{"contract": "ENT-43"},
+			},
+			checks: map[string]string{
+				"amount":            "450000",
+				"description":       "Onboarding workshop (2 days)",
+				"tax_rates.#":       "0",
+				"discounts.#":       "0",
+				"discountable":      "false",
+				"metadata.contract": "ENT-43",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"price":    "price_standin",
+				"quantity": 3,
+				"invoice":  "in_standin",
+			},
+			checks: map[string]string{
+				"price":    "price_standin",
+				"quantity": "3",
+				"invoice":  "in_standin",
+			},
+		},
+	})
+}
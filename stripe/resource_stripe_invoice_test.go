@@ -0,0 +1,157 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeInvoice(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_invoice",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer":          "cus_standin",
+				"collection_method": "send_invoice",
+				"days_until_due":    30,
+				"description":       "Enterprise contract ENT-42",
+				"custom_fields": []interface{}{
+					map[string]interface{}{"name": "PO number", "value": "4711"},
+				},
+				"footer":                         "Thank you for your business",
+				"pending_invoice_items_behavior": "include",
+				"metadata":                       map[string]interface{}{"contract": "ENT-42"},
+			},
+			checks: map[string]string{
+				"status":                "draft",
+				"collection_method":     "send_invoice",
+				"custom_fields.0.value": "4711",
+				"footer":                "Thank you for your business",
+				"number":                "",
+				"metadata.contract":     "ENT-42",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":          "cus_standin",
+				"collection_method": "send_invoice",
+				"days_until_due":    14,
+				"description":       "Enterprise contract ENT-42",
+				"footer":            "Thank you for your business",
+				"action":            "finalize",
+				"metadata":          map[string]interface{}{"contract": "ENT-42"},
+			},
+			checks: map[string]string{
+				"status":          "open",
+				"number":          "IN_STANDIN1",
+				"custom_fields.#": "0",
+				"invoice_pdf":     "https://pay.stripe.com/invoice/in_standin1/pdf",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_other",
+			},
+			checks: map[string]string{
+				"customer": "cus_other",
+				"status":   "draft",
+			},
+		},
+		// the arguments only apply to changes, Stripe doesn't return them
+		importIgnore: []string{"on_destroy", "action", "days_until_due", "pending_invoice_items_behavior"},
+	})
+}
+
+func TestAccStripeInvoiceLifecycle(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := func(action string, blocks ...string) string {
+		invoice := testAccResource(t, "stripe_invoice", "test", map[string]interface{}{
+			"customer":          "cus_standin",
+			"collection_method": "send_invoice",
+			"days_until_due":    30,
+			"action":            action,
+		})
+		return testAccConfig(t, standIn, nil, append([]string{invoice}, blocks...)...)
+	}
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("send"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_invoice.test", "status", "open"),
+					testAccKeepState("stripe_invoice.test", state),
+				),
+			},
+			{
+				Config: config("void"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_invoice.test", "status", "void"),
+					func(*terraform.State) error {
+						if invoice, _ := standIn.object(state.ID); ToInt(invoice["sends"]) != 1 {
+							return fmt.Errorf("expected the invoice to be sent once, got %v", invoice["sends"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// voided invoices are final
+				Config:      config("finalize"),
+				ExpectError: regexp.MustCompile(`action: the invoice can't go back from void to "finalize"`),
+			},
+			{
+				// sending requires the invoice to be emailed
+				Config: config("void", testAccResource(t, "stripe_invoice", "charged", map[string]interface{}{
+					"customer": "cus_standin",
+					"action":   "send",
+				})),
+				ExpectError: regexp.MustCompile(`send is only allowed when collection_method is send_invoice`),
+			},
+		},
+	})
+}
+
+func TestAccStripeInvoiceOnDestroy(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := func(onDestroy string) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_invoice", "test", map[string]interface{}{
+			"customer":   "cus_standin",
+			"action":     "finalize",
+			"on_destroy": onDestroy,
+		}))
+	}
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("delete_draft"),
+				Check:  testAccKeepState("stripe_invoice.test", state),
+			},
+			{
+				// the guard keeps finalized invoices from being voided on destroy
+				Config:      config("delete_draft"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`only draft invoices are deleted`),
+			},
+			{
+				Config: config("void"),
+				Check:  resource.TestCheckResourceAttr("stripe_invoice.test", "status", "open"),
+			},
+		},
+		// finalized invoices are voided on destroy
+		CheckDestroy: func(*terraform.State) error {
+			if invoice, _ := standIn.object(state.ID); invoice["status"] != "void" {
+				return fmt.Errorf("expected %s to be voided, got %v", state.ID, invoice["status"])
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeLoginLink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeLoginLinkRead,
+		CreateContext: resourceStripeLoginLinkCreate,
+		DeleteContext: resourceStripeLoginLinkDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the link in the Terraform state, the account followed by the creation time.",
+			},
+			"account": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+				Description:  "The identifier of the Express account to create a login link for.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL for the login link to the Express Dashboard.",
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the object was created. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+// resourceStripeLoginLinkRead keeps the link as created, login links can't be retrieved from the API.
+func resourceStripeLoginLinkRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeLoginLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var link *stripe.LoginLink
+	var err error
+
+	account := ExtractString(d, "account")
+	params := &stripe.LoginLinkParams{
+		Account: stripe.String(account),
+	}
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		link, err = c.LoginLinks.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", account, link.Created))
+	return CallSet(
+		d.Set("url", link.URL),
+		d.Set("created", link.Created),
+	)
+}
+
+func resourceStripeLoginLinkDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "[WARN] Login link can't be deleted, it's only removed from the Terraform state")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,23 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStripeLoginLink(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(t, standIn, nil, testAccResource(t, "stripe_login_link", "test", map[string]interface{}{
+					"account": "acct_connected1",
+				})),
+				Check: resource.TestMatchResourceAttr("stripe_login_link.test", "url", regexp.MustCompile(`acct_connected1`)),
+			},
+		},
+	})
+}
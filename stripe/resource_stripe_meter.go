@@ -6,8 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeMeter() *schema.Resource {
@@ -16,8 +16,9 @@ func resourceStripeMeter() *schema.Resource {
 		CreateContext: resourceStripeMeterCreate,
 		UpdateContext: resourceStripeMeterUpdate,
 		DeleteContext: resourceStripeMeterDelete,
+		CustomizeDiff: resourceStripeMeterCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -25,6 +26,7 @@ func resourceStripeMeter() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"default_aggregation": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -36,6 +38,10 @@ func resourceStripeMeter() *schema.Resource {
 						"formula": {
 							Type:     schema.TypeString,
 							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.BillingMeterDefaultAggregationFormulaCount),
+								string(stripe.BillingMeterDefaultAggregationFormulaSum),
+							}, false),
 							Description: "Specifies how events are aggregated. Allowed values " +
 								"are count to count the number of events and sum to sum each " +
 								"event’s value.",
@@ -72,6 +78,9 @@ func resourceStripeMeter() *schema.Resource {
 						"type": {
 							Type:     schema.TypeString,
 							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.BillingMeterCustomerMappingTypeByID),
+							}, false),
 							Description: "The method for mapping a meter event to a customer. " +
 								"Must be by_id",
 						},
@@ -79,9 +88,13 @@ func resourceStripeMeter() *schema.Resource {
 				},
 			},
 			"event_time_window": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.BillingMeterEventTimeWindowDay),
+					string(stripe.BillingMeterEventTimeWindowHour),
+				}, false),
 				Description: "The time window to pre-aggregate meter events for, if any.",
 			},
 			"value_settings": {
@@ -103,18 +116,42 @@ func resourceStripeMeter() *schema.Resource {
 					},
 				},
 			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the meter when the resource is destroyed, " +
+					"Stripe doesn't support its deletion. Either archive (the meter is deactivated) " +
+					"or abandon (the meter is only removed from the Terraform state). Defaults to archive.",
+			},
 		},
 	}
 }
 
-func resourceStripeMeterRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripeMeterCustomizeDiff checks the value settings are only used by meters summing the event values.
+func resourceStripeMeterCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !diffKnown(d, "default_aggregation", "value_settings") {
+		return nil
+	}
+
+	formula := ToString(ToMap(d.Get("default_aggregation"))["formula"])
+	if len(ToSlice(d.Get("value_settings"))) > 0 && formula != string(stripe.BillingMeterDefaultAggregationFormulaSum) {
+		return diffError("value_settings", "only allowed when default_aggregation.0.formula is sum, got %q", formula)
+	}
+
+	return nil
+}
+
+func resourceStripeMeterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var meter *stripe.BillingMeter
 	var err error
 
-	err = retryWithBackOff(func() error {
-		params := &stripe.BillingMeterParams{}
+	params := &stripe.BillingMeterParams{}
+	setStripeAccount(d, params)
 
+	err = c.retryWithBackOff(ctx, params, func() error {
 		meter, err = c.BillingMeters.Get(d.Id(), params)
 		return err
 	})
@@ -165,7 +202,7 @@ func resourceStripeMeterRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceStripeMeterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var meter *stripe.BillingMeter
 	var err error
 
@@ -213,7 +250,10 @@ func resourceStripeMeterCreate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		meter, err = c.BillingMeters.New(params)
 		return err
 	})
@@ -226,7 +266,7 @@ func resourceStripeMeterCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceStripeMeterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.BillingMeterParams{}
@@ -278,7 +318,8 @@ func resourceStripeMeterUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.BillingMeters.Update(d.Id(), params)
 		return err
 	})
@@ -289,8 +330,27 @@ func resourceStripeMeterUpdate(ctx context.Context, d *schema.ResourceData, m in
 	return resourceStripeMeterRead(ctx, d, m)
 }
 
-func resourceStripeMeterDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
-	tflog.Warn(ctx, "[WARN] Stripe API doesn't support deletion of billing meters")
+func resourceStripeMeterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Billing meter is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.BillingMeterDeactivateParams{}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.BillingMeters.Deactivate(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
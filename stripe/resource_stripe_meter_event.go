@@ -0,0 +1,170 @@
+package stripe
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	// meterEventCustomerKey and meterEventValueKey are the payload keys of meters without
+	// customer_mapping or value_settings.
+	meterEventCustomerKey = "stripe_customer_id"
+	meterEventValueKey    = "value"
+)
+
+func resourceStripeMeterEvent() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeMeterEventRead,
+		CreateContext: resourceStripeMeterEventCreate,
+		DeleteContext: resourceStripeMeterEventDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier of the event, meter events have no identifier of their own.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"event_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The name of the meter event. " +
+					"Corresponds with the event_name field on a meter.",
+			},
+			"meter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The ID of the meter whose customer_mapping and value_settings name the payload keys " +
+					"of customer and value. Stripe's default keys stripe_customer_id and value are used when not set.",
+			},
+			"customer": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"customer", "payload"},
+				Description:  "The ID of the customer the usage is recorded for, added to the payload.",
+			},
+			"value": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The usage recorded by the event, added to the payload.",
+			},
+			"payload": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The payload of the event. It must contain the keys of the meter's customer_mapping " +
+					"and value_settings unless they are given by customer and value.",
+			},
+			"timestamp": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The time of the event, measured in seconds since the Unix epoch. " +
+					"Must be within the past 35 days or up to 5 minutes in the future. Defaults to the time of creation.",
+			},
+			"identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "A unique identifier for the event, Stripe only records the first event of an identifier. " +
+					"Generated by Stripe when not set.",
+			},
+		},
+	}
+}
+
+// resourceStripeMeterEventRead keeps the event, meter events can't be retrieved from the API.
+func resourceStripeMeterEventRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeMeterEventCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var event *stripe.BillingMeterEvent
+	var err error
+
+	customerKey, valueKey := meterEventCustomerKey, meterEventValueKey
+	if meterID, set := d.GetOk("meter"); set {
+		var meter *stripe.BillingMeter
+		params := &stripe.BillingMeterParams{}
+		setStripeAccount(d, params)
+
+		err = c.retryWithBackOff(ctx, params, func() error {
+			meter, err = c.BillingMeters.Get(ToString(meterID), params)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if eventName := ExtractString(d, "event_name"); meter.EventName != eventName {
+			return diag.Errorf("meter %s records the events %q, got event_name %q", meter.ID, meter.EventName,
+				eventName)
+		}
+		if meter.CustomerMapping != nil && meter.CustomerMapping.EventPayloadKey != "" {
+			customerKey = meter.CustomerMapping.EventPayloadKey
+		}
+		if meter.ValueSettings != nil && meter.ValueSettings.EventPayloadKey != "" {
+			valueKey = meter.ValueSettings.EventPayloadKey
+		}
+	}
+
+	params := &stripe.BillingMeterEventParams{
+		EventName: stripe.String(ExtractString(d, "event_name")),
+		Payload:   map[string]string{},
+	}
+
+	if payload, set := d.GetOk("payload"); set {
+		for k, v := range ToMap(payload) {
+			params.Payload[k] = ToString(v)
+		}
+	}
+	if customer, set := d.GetOk("customer"); set {
+		params.Payload[customerKey] = ToString(customer)
+	}
+	if value, set := d.GetOkExists("value"); set {
+		params.Payload[valueKey] = strconv.Itoa(ToInt(value))
+	}
+	if timestamp, set := d.GetOk("timestamp"); set {
+		params.Timestamp = stripe.Int64(ToInt64(timestamp))
+	}
+	if identifier, set := d.GetOk("identifier"); set {
+		params.Identifier = stripe.String(ToString(identifier))
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		event, err = c.BillingMeterEvents.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(event.Identifier)
+	return CallSet(
+		d.Set("timestamp", event.Timestamp),
+		d.Set("identifier", event.Identifier),
+	)
+}
+
+func resourceStripeMeterEventDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "[WARN] Meter event can't be deleted, it's only removed from the Terraform state. "+
+		"Use stripe_meter_event_adjustment to cancel it")
+	d.SetId("")
+	return nil
+}
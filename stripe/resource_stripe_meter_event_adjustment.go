@@ -0,0 +1,110 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeMeterEventAdjustment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeMeterEventAdjustmentRead,
+		CreateContext: resourceStripeMeterEventAdjustmentCreate,
+		DeleteContext: resourceStripeMeterEventAdjustmentDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier of the cancelled event, adjustments have no identifier of their own.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"event_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The name of the meter event. " +
+					"Corresponds with the event_name field on a meter.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(stripe.BillingMeterEventAdjustmentTypeCancel),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.BillingMeterEventAdjustmentTypeCancel),
+				}, false),
+				Description: "Specifies whether to cancel a single event or a range of events for a time period. " +
+					"Must be cancel.",
+			},
+			"cancel": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Specifies which event to cancel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							Description: "Unique identifier for the event. " +
+								"You can only cancel events within 24 hours of Stripe receiving them.",
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The meter event adjustment's status, either pending or complete.",
+			},
+		},
+	}
+}
+
+// resourceStripeMeterEventAdjustmentRead keeps the adjustment, adjustments can't be retrieved from the API.
+func resourceStripeMeterEventAdjustmentRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceStripeMeterEventAdjustmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var adjustment *stripe.BillingMeterEventAdjustment
+	var err error
+
+	identifier := ToString(ExtractMap(d, "cancel")["identifier"])
+	params := &stripe.BillingMeterEventAdjustmentParams{
+		EventName: stripe.String(ExtractString(d, "event_name")),
+		Type:      stripe.String(ExtractString(d, "type")),
+		Cancel: &stripe.BillingMeterEventAdjustmentCancelParams{
+			Identifier: stripe.String(identifier),
+		},
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		adjustment, err = c.BillingMeterEventAdjustments.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(identifier)
+	return CallSet(
+		d.Set("status", adjustment.Status),
+	)
+}
+
+func resourceStripeMeterEventAdjustmentDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "[WARN] Meter event adjustment can't be undone, it's only removed from the Terraform state")
+	d.SetId("")
+	return nil
+}
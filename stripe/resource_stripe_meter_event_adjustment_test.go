@@ -0,0 +1,45 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeMeterEventAdjustment(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := func(identifier string) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_meter_event_adjustment", "test", map[string]interface{}{
+			"event_name": "api_requests",
+			"cancel": []interface{}{
+				map[string]interface{}{"identifier": identifier},
+			},
+		}))
+	}
+
+	state := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("seed-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_meter_event_adjustment.test", map[string]string{
+						"id":     "seed-1",
+						"type":   "cancel",
+						"status": "complete",
+					}),
+					testAccKeepState("stripe_meter_event_adjustment.test", state),
+				),
+			},
+			{
+				Config: config("seed-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_meter_event_adjustment.test", "id", "seed-2"),
+					testAccCheckSameObject("stripe_meter_event_adjustment.test", state, false),
+				),
+			},
+		},
+	})
+}
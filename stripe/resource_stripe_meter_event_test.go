@@ -0,0 +1,98 @@
+package stripe
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccMeterFixture is a meter summing the requests of the customer_id payload key.
+var testAccMeterFixture = map[string]interface{}{
+	"object":              "billing.meter",
+	"event_name":          "api_requests",
+	"status":              "active",
+	"default_aggregation": map[string]interface{}{"formula": "sum"},
+	"customer_mapping":    map[string]interface{}{"event_payload_key": "customer_id", "type": "by_id"},
+	"value_settings":      map[string]interface{}{"event_payload_key": "requests"},
+}
+
+func TestAccStripeMeterEvent(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("mtr_standin", testAccMeterFixture)
+
+	// customer and value are added to the payload under the keys of the meter
+	seeded := testAccResource(t, "stripe_meter_event", "test", map[string]interface{}{
+		"event_name": "api_requests",
+		"meter":      "mtr_standin",
+		"customer":   "cus_standin",
+		"value":      25,
+		"payload":    map[string]interface{}{"region": "eu"},
+		"timestamp":  1767225600,
+		"identifier": "seed-1",
+	})
+	// the default keys are used without a meter, the identifier is generated by Stripe
+	generated := testAccResource(t, "stripe_meter_event", "generated", map[string]interface{}{
+		"event_name": "api_requests",
+		"customer":   "cus_standin",
+		"value":      0,
+	})
+	// the meter must record the events of the name
+	misnamed := testAccResource(t, "stripe_meter_event", "misnamed", map[string]interface{}{
+		"event_name": "api_calls",
+		"meter":      "mtr_standin",
+		"customer":   "cus_standin",
+		"value":      1,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(t, standIn, nil, seeded),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_meter_event.test", map[string]string{
+						"id":         "seed-1",
+						"identifier": "seed-1",
+						"timestamp":  "1767225600",
+					}),
+					testAccCheckMeterEventPayload(standIn, "mtrevt_standin1", map[string]interface{}{
+						"customer_id": "cus_standin",
+						"requests":    "25",
+						"region":      "eu",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(t, standIn, nil, seeded, generated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("stripe_meter_event.generated", "id",
+						"stripe_meter_event.generated", "identifier"),
+					resource.TestMatchResourceAttr("stripe_meter_event.generated", "timestamp", regexp.MustCompile(`^[1-9]`)),
+					testAccCheckMeterEventPayload(standIn, "mtrevt_standin2", map[string]interface{}{
+						"stripe_customer_id": "cus_standin",
+						"value":              "0",
+					}),
+				),
+			},
+			{
+				Config:      testAccConfig(t, standIn, nil, seeded, generated, misnamed),
+				ExpectError: regexp.MustCompile(`meter mtr_standin records the events "api_requests"`),
+			},
+		},
+	})
+}
+
+// testAccCheckMeterEventPayload compares the payload of the event recorded by the stand-in.
+func testAccCheckMeterEventPayload(standIn *stripeStandIn, id string, expected map[string]interface{}) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		event, _ := standIn.object(id)
+		if payload := ToMap(event["payload"]); !reflect.DeepEqual(payload, expected) {
+			return fmt.Errorf("expected the payload of %s to be %v, got %v", id, expected, payload)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeMeter(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_meter",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"display_name":        "API requests",
+				"event_name":          "api_requests",
+				"default_aggregation": []interface{}{map[string]interface{}{"formula": "count"}},
+				"customer_mapping": []interface{}{
+					map[string]interface{}{"event_payload_key": "stripe_customer_id", "type": "by_id"},
+				},
+			},
+			checks: map[string]string{
+				"display_name":                  "API requests",
+				"default_aggregation.0.formula": "count",
+				"customer_mapping.0.type":       "by_id",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"display_name":        "API calls",
+				"event_name":          "api_requests",
+				"default_aggregation": []interface{}{map[string]interface{}{"formula": "count"}},
+				"customer_mapping": []interface{}{
+					map[string]interface{}{"event_payload_key": "stripe_customer_id", "type": "by_id"},
+				},
+			},
+			checks: map[string]string{
+				"display_name": "API calls",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"display_name":        "Transferred bytes",
+				"event_name":          "bytes_transferred",
+				"default_aggregation": []interface{}{map[string]interface{}{"formula": "sum"}},
+				"customer_mapping": []interface{}{
+					map[string]interface{}{"event_payload_key": "stripe_customer_id", "type": "by_id"},
+				},
+				"value_settings": []interface{}{map[string]interface{}{"event_payload_key": "bytes"}},
+			},
+			checks: map[string]string{
+				"event_name":                         "bytes_transferred",
+				"default_aggregation.0.formula":      "sum",
+				"value_settings.0.event_payload_key": "bytes",
+			},
+		},
+		importIgnore: []string{"on_destroy"},
+	})
+}
+
+func TestAccStripeMeterPlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_meter", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"display_name":        "API requests",
+				"event_name":          "api_requests",
+				"default_aggregation": []interface{}{map[string]interface{}{"formula": "count"}},
+				"value_settings":      []interface{}{map[string]interface{}{"event_payload_key": "requests"}},
+			},
+			err: `value_settings: only allowed when default_aggregation.0.formula is sum, got "count"`,
+		},
+	})
+}
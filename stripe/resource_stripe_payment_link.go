@@ -0,0 +1,792 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripePaymentLink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePaymentLinkRead,
+		CreateContext: resourceStripePaymentLinkCreate,
+		UpdateContext: resourceStripePaymentLinkUpdate,
+		DeleteContext: resourceStripePaymentLinkDelete,
+		CustomizeDiff: resourceStripePaymentLinkCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"line_items": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 20,
+				Description: "The line items representing what is being sold. " +
+					"Adding or removing line items and changing their price creates a new payment link.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier of the line item.",
+						},
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The ID of the price object.",
+						},
+						"quantity": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The quantity of the line item being purchased.",
+						},
+						"adjustable_quantity": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "When set, provides configuration for this item's quantity to be adjusted by the customer during checkout.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:        schema.TypeBool,
+										Required:    true,
+										Description: "Set to true if the quantity can be adjusted to any non-negative Integer.",
+									},
+									"maximum": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Description: "The maximum quantity the customer can purchase. " +
+											"By default this value is 99.",
+									},
+									"minimum": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Description: "The minimum quantity the customer can purchase. " +
+											"By default this value is 0.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the payment link's url is active. " +
+					"If false, customers visiting the URL will be shown a page saying that the link has been deactivated.",
+			},
+			"after_completion": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Behavior after the purchase is complete.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PaymentLinkAfterCompletionTypeHostedConfirmation),
+								string(stripe.PaymentLinkAfterCompletionTypeRedirect),
+							}, false),
+							Description: "The specified behavior after the purchase is complete. " +
+								"Either redirect or hosted_confirmation.",
+						},
+						"hosted_confirmation": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Configuration when type=hosted_confirmation.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"custom_message": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "A custom message to display to the customer after the purchase is complete.",
+									},
+								},
+							},
+						},
+						"redirect": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Configuration when type=redirect.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"url": {
+										Type:     schema.TypeString,
+										Required: true,
+										Description: "The URL the customer will be redirected to after the purchase is complete. " +
+											"You can embed {CHECKOUT_SESSION_ID} into the URL to have the id " +
+											"of the completed checkout session included.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"allow_promotion_codes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables user redeemable promotion codes.",
+			},
+			"automatic_tax": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Configuration for automatic tax collection.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "If true, tax will be calculated automatically using the customer's location.",
+						},
+					},
+				},
+			},
+			"billing_address_collection": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PaymentLinkBillingAddressCollectionAuto),
+					string(stripe.PaymentLinkBillingAddressCollectionRequired),
+				}, false),
+				Description: "Configuration for collecting the customer's billing address. " +
+					"Either auto or required. Defaults to auto.",
+			},
+			"custom_fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 3,
+				Description: "Collect additional information from your customer using custom fields. " +
+					"Up to 3 fields are supported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "String of your choice that your integration can use to reconcile this field. " +
+								"Must be unique to this field, alphanumeric, and up to 200 characters.",
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PaymentLinkCustomFieldTypeDropdown),
+								string(stripe.PaymentLinkCustomFieldTypeNumeric),
+								string(stripe.PaymentLinkCustomFieldTypeText),
+							}, false),
+							Description: "The type of the field. Either dropdown, numeric or text.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Custom text for the label, displayed to the customer. Up to 50 characters.",
+						},
+						"optional": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Whether the customer is required to complete the field before completing " +
+								"the Checkout Session. Defaults to false.",
+						},
+						"dropdown": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Configuration for type=dropdown fields.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"options": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										MaxItems:    200,
+										Description: "The options available for the customer to select. Up to 200 options allowed.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"label": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "The label for the option, displayed to the customer. Up to 100 characters.",
+												},
+												"value": {
+													Type:     schema.TypeString,
+													Required: true,
+													Description: "The value for this option, not displayed to the customer, " +
+														"used by your integration to reconcile the option selected by the customer. " +
+														"Must be unique to this option, alphanumeric, and up to 100 characters.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"numeric": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Configuration for type=numeric fields.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"maximum_length": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The maximum character length constraint for the customer's input.",
+									},
+									"minimum_length": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The minimum character length requirement for the customer's input.",
+									},
+								},
+							},
+						},
+						"text": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Configuration for type=text fields.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"maximum_length": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The maximum character length constraint for the customer's input.",
+									},
+									"minimum_length": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The minimum character length requirement for the customer's input.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"shipping_options": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The shipping rate options to apply to checkout sessions created by this payment link.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"shipping_rate": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The ID of the Shipping Rate to use for this shipping option.",
+						},
+						"shipping_amount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "A non-negative integer in cents representing how much to charge.",
+						},
+					},
+				},
+			},
+			"subscription_data": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "When creating a subscription, the specified configuration data will be used. " +
+					"There must be at least one line item with a recurring price to use subscription_data.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "The subscription's description, meant to be displayable to the customer. " +
+								"Use this field to optionally store an explanation of the subscription.",
+						},
+						"trial_period_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description: "Integer representing the number of trial period days " +
+								"before the customer is charged for the first time. Has to be at least 1.",
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs that will set metadata on subscriptions " +
+								"generated from this payment link.",
+						},
+					},
+				},
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the payment link when the resource is destroyed, " +
+					"Stripe doesn't support its deletion. Either archive (the payment link is deactivated) " +
+					"or abandon (the payment link is only removed from the Terraform state). Defaults to archive.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public URL that can be shared with customers.",
+			},
+		},
+	}
+}
+
+// resourceStripePaymentLinkCustomizeDiff recreates the payment link when line items are added or removed,
+// Stripe only allows updating the quantities of the existing line items.
+func resourceStripePaymentLinkCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("line_items") {
+		return nil
+	}
+	oldItems, newItems := d.GetChange("line_items")
+	if len(ToSlice(oldItems)) != len(ToSlice(newItems)) {
+		return d.ForceNew("line_items")
+	}
+	return nil
+}
+
+func resourceStripePaymentLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var paymentLink *stripe.PaymentLink
+	var lineItems []*stripe.LineItem
+	var err error
+
+	params := &stripe.PaymentLinkParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		paymentLink, err = c.PaymentLinks.Get(d.Id(), params)
+		if err != nil {
+			return err
+		}
+
+		lineItems = nil
+		listParams := &stripe.PaymentLinkListLineItemsParams{
+			PaymentLink: stripe.String(d.Id()),
+		}
+		setStripeAccount(d, listParams)
+		setRequestContext(ctx, listParams)
+		i := c.PaymentLinks.ListLineItems(listParams)
+		for i.Next() {
+			lineItems = append(lineItems, i.LineItem())
+		}
+		return i.Err()
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			// adjustable quantity isn't returned by Stripe, the Terraform input is kept
+			known := ExtractMapSlice(d, "line_items")
+			var items []map[string]interface{}
+			for i, lineItem := range lineItems {
+				item := map[string]interface{}{
+					"id":       lineItem.ID,
+					"price":    lineItem.Price.ID,
+					"quantity": lineItem.Quantity,
+				}
+				if i < len(known) {
+					item["adjustable_quantity"] = known[i]["adjustable_quantity"]
+				}
+				items = append(items, item)
+			}
+			return d.Set("line_items", items)
+		}(),
+		d.Set("active", paymentLink.Active),
+		func() error {
+			if paymentLink.AfterCompletion == nil {
+				return nil
+			}
+			afterCompletion := map[string]interface{}{
+				"type": paymentLink.AfterCompletion.Type,
+			}
+			if paymentLink.AfterCompletion.HostedConfirmation != nil {
+				afterCompletion["hosted_confirmation"] = []map[string]interface{}{
+					{
+						"custom_message": paymentLink.AfterCompletion.HostedConfirmation.CustomMessage,
+					},
+				}
+			}
+			if paymentLink.AfterCompletion.Redirect != nil {
+				afterCompletion["redirect"] = []map[string]interface{}{
+					{
+						"url": paymentLink.AfterCompletion.Redirect.URL,
+					},
+				}
+			}
+			return d.Set("after_completion", []map[string]interface{}{afterCompletion})
+		}(),
+		d.Set("allow_promotion_codes", paymentLink.AllowPromotionCodes),
+		func() error {
+			if paymentLink.AutomaticTax != nil {
+				return d.Set("automatic_tax", []map[string]interface{}{
+					{
+						"enabled": paymentLink.AutomaticTax.Enabled,
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("billing_address_collection", paymentLink.BillingAddressCollection),
+		func() error {
+			var customFields []map[string]interface{}
+			for _, field := range paymentLink.CustomFields {
+				customField := map[string]interface{}{
+					"key":      field.Key,
+					"type":     field.Type,
+					"optional": field.Optional,
+				}
+				if field.Label != nil {
+					customField["label"] = field.Label.Custom
+				}
+				if field.Dropdown != nil {
+					var options []map[string]interface{}
+					for _, option := range field.Dropdown.Options {
+						options = append(options, map[string]interface{}{
+							"label": option.Label,
+							"value": option.Value,
+						})
+					}
+					customField["dropdown"] = []map[string]interface{}{{"options": options}}
+				}
+				if field.Numeric != nil && (field.Numeric.MaximumLength != 0 || field.Numeric.MinimumLength != 0) {
+					customField["numeric"] = []map[string]interface{}{
+						{
+							"maximum_length": field.Numeric.MaximumLength,
+							"minimum_length": field.Numeric.MinimumLength,
+						},
+					}
+				}
+				if field.Text != nil && (field.Text.MaximumLength != 0 || field.Text.MinimumLength != 0) {
+					customField["text"] = []map[string]interface{}{
+						{
+							"maximum_length": field.Text.MaximumLength,
+							"minimum_length": field.Text.MinimumLength,
+						},
+					}
+				}
+				customFields = append(customFields, customField)
+			}
+			return d.Set("custom_fields", customFields)
+		}(),
+		func() error {
+			var shippingOptions []map[string]interface{}
+			for _, option := range paymentLink.ShippingOptions {
+				shippingOptions = append(shippingOptions, map[string]interface{}{
+					"shipping_rate":   option.ShippingRate.ID,
+					"shipping_amount": option.ShippingAmount,
+				})
+			}
+			return d.Set("shipping_options", shippingOptions)
+		}(),
+		func() error {
+			if paymentLink.SubscriptionData != nil && len(ExtractMapSlice(d, "subscription_data")) > 0 {
+				return d.Set("subscription_data", []map[string]interface{}{
+					{
+						"description":       paymentLink.SubscriptionData.Description,
+						"trial_period_days": paymentLink.SubscriptionData.TrialPeriodDays,
+						"metadata":          paymentLink.SubscriptionData.Metadata,
+					},
+				})
+			}
+			return nil
+		}(),
+		d.Set("metadata", paymentLink.Metadata),
+		d.Set("url", paymentLink.URL),
+	)
+}
+
+func resourceStripePaymentLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var paymentLink *stripe.PaymentLink
+	var err error
+
+	params := &stripe.PaymentLinkParams{
+		Active: stripe.Bool(ExtractBool(d, "active")),
+	}
+
+	for _, item := range ExtractMapSlice(d, "line_items") {
+		params.LineItems = append(params.LineItems, &stripe.PaymentLinkLineItemParams{
+			Price:              stripe.String(ToString(item["price"])),
+			Quantity:           stripe.Int64(ToInt64(item["quantity"])),
+			AdjustableQuantity: expandPaymentLinkAdjustableQuantity(item["adjustable_quantity"]),
+		})
+	}
+	if afterCompletion, set := d.GetOk("after_completion"); set {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(afterCompletion)
+	}
+	if allowPromotionCodes, set := d.GetOk("allow_promotion_codes"); set {
+		params.AllowPromotionCodes = stripe.Bool(ToBool(allowPromotionCodes))
+	}
+	if automaticTax, set := d.GetOk("automatic_tax"); set {
+		params.AutomaticTax = &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(ToBool(ToMap(automaticTax)["enabled"])),
+		}
+	}
+	if billingAddressCollection, set := d.GetOk("billing_address_collection"); set {
+		params.BillingAddressCollection = stripe.String(ToString(billingAddressCollection))
+	}
+	if customFields, set := d.GetOk("custom_fields"); set {
+		params.CustomFields = expandPaymentLinkCustomFields(customFields)
+	}
+	if shippingOptions, set := d.GetOk("shipping_options"); set {
+		for _, option := range ToMapSlice(shippingOptions) {
+			params.ShippingOptions = append(params.ShippingOptions, &stripe.PaymentLinkShippingOptionParams{
+				ShippingRate: stripe.String(ToString(option["shipping_rate"])),
+			})
+		}
+	}
+	if subscriptionData, set := d.GetOk("subscription_data"); set {
+		params.SubscriptionData = &stripe.PaymentLinkSubscriptionDataParams{}
+		for k, v := range ToMap(subscriptionData) {
+			switch k {
+			case "description":
+				params.SubscriptionData.Description = NonZeroString(v)
+			case "trial_period_days":
+				params.SubscriptionData.TrialPeriodDays = NonZeroInt64(v)
+			case "metadata":
+				for mk, mv := range ToMap(v) {
+					params.SubscriptionData.AddMetadata(mk, ToString(mv))
+				}
+			}
+		}
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		paymentLink, err = c.PaymentLinks.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(paymentLink.ID)
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+func resourceStripePaymentLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.PaymentLinkParams{}
+
+	if d.HasChange("line_items") {
+		// prices and the number of line items can't change, the CustomizeDiff forces a new resource
+		for _, item := range ExtractMapSlice(d, "line_items") {
+			params.LineItems = append(params.LineItems, &stripe.PaymentLinkLineItemParams{
+				ID:                 stripe.String(ToString(item["id"])),
+				Quantity:           stripe.Int64(ToInt64(item["quantity"])),
+				AdjustableQuantity: expandPaymentLinkAdjustableQuantity(item["adjustable_quantity"]),
+			})
+		}
+	}
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(ExtractBool(d, "active"))
+	}
+	if d.HasChange("after_completion") {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(d.Get("after_completion"))
+	}
+	if d.HasChange("allow_promotion_codes") {
+		params.AllowPromotionCodes = stripe.Bool(ExtractBool(d, "allow_promotion_codes"))
+	}
+	if d.HasChange("automatic_tax") {
+		params.AutomaticTax = &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(ToBool(ExtractMap(d, "automatic_tax")["enabled"])),
+		}
+	}
+	if d.HasChange("billing_address_collection") {
+		params.BillingAddressCollection = stripe.String(ExtractString(d, "billing_address_collection"))
+	}
+	if d.HasChange("custom_fields") {
+		// an empty list removes all the custom fields
+		params.CustomFields = []*stripe.PaymentLinkCustomFieldParams{}
+		params.CustomFields = append(params.CustomFields, expandPaymentLinkCustomFields(d.Get("custom_fields"))...)
+	}
+	if d.HasChange("subscription_data") {
+		params.SubscriptionData = &stripe.PaymentLinkSubscriptionDataParams{
+			TrialPeriodDays: NonZeroInt64(ExtractMap(d, "subscription_data")["trial_period_days"]),
+		}
+		oldData, newData := d.GetChange("subscription_data")
+		for k := range ToMap(ToMap(oldData)["metadata"]) {
+			// when meta is empty string it's going be removed
+			params.SubscriptionData.AddMetadata(k, "")
+		}
+		for k, v := range ToMap(ToMap(newData)["metadata"]) {
+			params.SubscriptionData.AddMetadata(k, ToString(v))
+		}
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.PaymentLinks.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripePaymentLinkRead(ctx, d, m)
+}
+
+func expandPaymentLinkAdjustableQuantity(value interface{}) *stripe.PaymentLinkLineItemAdjustableQuantityParams {
+	adjustableQuantity := ToMap(value)
+	if len(adjustableQuantity) == 0 {
+		return nil
+	}
+	return &stripe.PaymentLinkLineItemAdjustableQuantityParams{
+		Enabled: stripe.Bool(ToBool(adjustableQuantity["enabled"])),
+		Maximum: NonZeroInt64(adjustableQuantity["maximum"]),
+		Minimum: NonZeroInt64(adjustableQuantity["minimum"]),
+	}
+}
+
+func expandPaymentLinkAfterCompletion(value interface{}) *stripe.PaymentLinkAfterCompletionParams {
+	afterCompletion := &stripe.PaymentLinkAfterCompletionParams{}
+	for k, v := range ToMap(value) {
+		switch k {
+		case "type":
+			afterCompletion.Type = stripe.String(ToString(v))
+		case "hosted_confirmation":
+			if hostedConfirmation := ToMap(v); len(hostedConfirmation) > 0 {
+				afterCompletion.HostedConfirmation = &stripe.PaymentLinkAfterCompletionHostedConfirmationParams{
+					CustomMessage: NonZeroString(hostedConfirmation["custom_message"]),
+				}
+			}
+		case "redirect":
+			if redirect := ToMap(v); len(redirect) > 0 {
+				afterCompletion.Redirect = &stripe.PaymentLinkAfterCompletionRedirectParams{
+					URL: stripe.String(ToString(redirect["url"])),
+				}
+			}
+		}
+	}
+	return afterCompletion
+}
+
+func expandPaymentLinkCustomFields(value interface{}) []*stripe.PaymentLinkCustomFieldParams {
+	var customFields []*stripe.PaymentLinkCustomFieldParams
+	for _, field := range ToMapSlice(value) {
+		customField := &stripe.PaymentLinkCustomFieldParams{}
+		for k, v := range field {
+			switch k {
+			case "key":
+				customField.Key = stripe.String(ToString(v))
+			case "type":
+				customField.Type = stripe.String(ToString(v))
+			case "label":
+				customField.Label = &stripe.PaymentLinkCustomFieldLabelParams{
+					Type:   stripe.String("custom"),
+					Custom: stripe.String(ToString(v)),
+				}
+			case "optional":
+				customField.Optional = stripe.Bool(ToBool(v))
+			case "dropdown":
+				if dropdown := ToMap(v); len(dropdown) > 0 {
+					customField.Dropdown = &stripe.PaymentLinkCustomFieldDropdownParams{}
+					for _, option := range ToMapSlice(dropdown["options"]) {
+						customField.Dropdown.Options = append(customField.Dropdown.Options,
+							&stripe.PaymentLinkCustomFieldDropdownOptionParams{
+								Label: stripe.String(ToString(option["label"])),
+								Value: stripe.String(ToString(option["value"])),
+							})
+					}
+				}
+			case "numeric":
+				if numeric := ToMap(v); len(numeric) > 0 {
+					customField.Numeric = &stripe.PaymentLinkCustomFieldNumericParams{
+						MaximumLength: NonZeroInt64(numeric["maximum_length"]),
+						MinimumLength: NonZeroInt64(numeric["minimum_length"]),
+					}
+				}
+			case "text":
+				if text := ToMap(v); len(text) > 0 {
+					customField.Text = &stripe.PaymentLinkCustomFieldTextParams{
+						MaximumLength: NonZeroInt64(text["maximum_length"]),
+						MinimumLength: NonZeroInt64(text["minimum_length"]),
+					}
+				}
+			}
+		}
+		customFields = append(customFields, customField)
+	}
+	return customFields
+}
+
+func resourceStripePaymentLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Payment Link is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.PaymentLinkParams{
+		Active: stripe.Bool(false),
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.PaymentLinks.Update(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,131 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripePaymentLink(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_payment_link",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_standin", "quantity": 1},
+				},
+				"allow_promotion_codes": true,
+				"metadata":              map[string]interface{}{"campaign": "spring"},
+			},
+			checks: map[string]string{
+				"active":                "true",
+				"url":                   "https://buy.stripe.com/test_plink_standin1",
+				"line_items.#":          "1",
+				"line_items.0.price":    "price_standin",
+				"line_items.0.quantity": "1",
+				"allow_promotion_codes": "true",
+				"metadata.campaign":     "spring",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_standin", "quantity": 3},
+				},
+				"active":   false,
+				"metadata": map[string]interface{}{"campaign": "summer"},
+			},
+			checks: map[string]string{
+				"active":                "false",
+				"line_items.0.quantity": "3",
+				"allow_promotion_codes": "false",
+				"metadata.campaign":     "summer",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"line_items": []interface{}{
+					map[string]interface{}{"price": "price_other", "quantity": 1},
+				},
+			},
+			checks: map[string]string{
+				"active":             "true",
+				"line_items.0.price": "price_other",
+			},
+		},
+		importIgnore: []string{"on_destroy"},
+	})
+}
+
+func TestAccStripePaymentLinkActive(t *testing.T) {
+	testCases := map[string]struct {
+		onDestroy string
+		// active is the state of the link once it's destroyed.
+		active bool
+	}{
+		"archive": {
+			onDestroy: onDestroyArchive,
+			active:    false,
+		},
+		"abandon": {
+			onDestroy: onDestroyAbandon,
+			active:    true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			link := func(active bool) string {
+				return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_payment_link", "test",
+					map[string]interface{}{
+						"line_items": []interface{}{
+							map[string]interface{}{"price": "price_standin", "quantity": 1},
+						},
+						"active":     active,
+						"on_destroy": tc.onDestroy,
+					}))
+			}
+
+			state := &terraform.InstanceState{}
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						// a link can be created deactivated
+						Config: link(false),
+						Check: resource.ComposeTestCheckFunc(
+							testAccKeepState("stripe_payment_link.test", state),
+							testAccCheckPaymentLinkActive(standIn, state, false),
+						),
+					},
+					{
+						Config: link(true),
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckSameObject("stripe_payment_link.test", state, true),
+							testAccCheckPaymentLinkActive(standIn, state, true),
+						),
+					},
+				},
+				CheckDestroy: testAccCheckPaymentLinkActive(standIn, state, tc.active),
+			})
+		})
+	}
+}
+
+// testAccCheckPaymentLinkActive checks whether Stripe keeps the payment link of the state active.
+func testAccCheckPaymentLinkActive(standIn *stripeStandIn, state *terraform.InstanceState,
+	active bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		link, ok := standIn.object(state.ID)
+		if !ok {
+			return fmt.Errorf("payment link %s is missing", state.ID)
+		}
+		if got := fmt.Sprint(link["active"]); got != fmt.Sprint(active) {
+			return fmt.Errorf("expected payment link %s to be active %t, got %s", state.ID, active, got)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,258 @@
+package stripe
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripePaymentMethodAttachment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePaymentMethodAttachmentRead,
+		CreateContext: resourceStripePaymentMethodAttachmentCreate,
+		UpdateContext: resourceStripePaymentMethodAttachmentUpdate,
+		DeleteContext: resourceStripePaymentMethodAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier of the attached PaymentMethod.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer to which to attach the PaymentMethod.",
+			},
+			"payment_method": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^(pm|tok|card)_[A-Za-z0-9_]+$`),
+					"must be a PaymentMethod (pm_...), a card token (tok_...) or a card (card_...)",
+				),
+				Description: "The PaymentMethod to attach, e.g. pm_card_visa in test mode. " +
+					"A card token (tok_...) is turned into a PaymentMethod first.",
+			},
+			"set_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the PaymentMethod is the default payment method of the customer for " +
+					"subscriptions and invoices (invoice_settings.default_payment_method).",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the PaymentMethod, e.g. card.",
+			},
+			"brand": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Card brand, for card payment methods.",
+			},
+			"last4": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last four digits of the card, for card payment methods.",
+			},
+			"exp_month": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Two-digit number representing the card's expiration month, for card payment methods.",
+			},
+			"exp_year": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Four-digit number representing the card's expiration year, for card payment methods.",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Uniquely identifies this particular card number, for card payment methods.",
+			},
+		},
+	}
+}
+
+func resourceStripePaymentMethodAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var paymentMethod *stripe.PaymentMethod
+	var customer *stripe.Customer
+	var err error
+
+	params := &stripe.PaymentMethodParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		paymentMethod, err = c.PaymentMethods.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	// a detached payment method isn't attached anymore, the attachment is gone
+	customerID := ExtractString(d, "customer")
+	if paymentMethod.Customer == nil || (customerID != "" && paymentMethod.Customer.ID != customerID) {
+		d.SetId("")
+		return nil
+	}
+
+	customerParams := &stripe.CustomerParams{}
+	setStripeAccount(d, customerParams)
+
+	err = c.retryWithBackOff(ctx, customerParams, func() error {
+		customer, err = c.Customers.Get(paymentMethod.Customer.ID, customerParams)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	card := &stripe.PaymentMethodCard{}
+	if paymentMethod.Card != nil {
+		card = paymentMethod.Card
+	}
+
+	return CallSet(
+		d.Set("customer", paymentMethod.Customer.ID),
+		func() error {
+			// the token the payment method was created from can't be read back, imports use the identifier
+			if ExtractString(d, "payment_method") == "" {
+				return d.Set("payment_method", paymentMethod.ID)
+			}
+			return nil
+		}(),
+		d.Set("set_default", customer.InvoiceSettings != nil &&
+			customer.InvoiceSettings.DefaultPaymentMethod != nil &&
+			customer.InvoiceSettings.DefaultPaymentMethod.ID == paymentMethod.ID),
+		d.Set("type", paymentMethod.Type),
+		d.Set("brand", card.Brand),
+		d.Set("last4", card.Last4),
+		d.Set("exp_month", card.ExpMonth),
+		d.Set("exp_year", card.ExpYear),
+		d.Set("fingerprint", card.Fingerprint),
+	)
+}
+
+func resourceStripePaymentMethodAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var paymentMethod *stripe.PaymentMethod
+	var err error
+
+	paymentMethodID := ExtractString(d, "payment_method")
+	if strings.HasPrefix(paymentMethodID, "tok_") {
+		params := &stripe.PaymentMethodParams{
+			Type: stripe.String(string(stripe.PaymentMethodTypeCard)),
+			Card: &stripe.PaymentMethodCardParams{Token: stripe.String(paymentMethodID)},
+		}
+		setStripeAccount(d, params)
+		params.IdempotencyKey = newIdempotencyKey(ctx)
+
+		err = c.retryWithBackOff(ctx, params, func() error {
+			paymentMethod, err = c.PaymentMethods.New(params)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		paymentMethodID = paymentMethod.ID
+	}
+
+	params := &stripe.PaymentMethodAttachParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		paymentMethod, err = c.PaymentMethods.Attach(paymentMethodID, params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(paymentMethod.ID)
+	if ExtractBool(d, "set_default") {
+		if dg := setDefaultPaymentMethod(ctx, d, c, paymentMethod.ID); len(dg) > 0 {
+			return dg
+		}
+	}
+	return resourceStripePaymentMethodAttachmentRead(ctx, d, m)
+}
+
+func resourceStripePaymentMethodAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+
+	if d.HasChange("set_default") {
+		defaultPaymentMethod := ""
+		if ExtractBool(d, "set_default") {
+			defaultPaymentMethod = d.Id()
+		}
+		if dg := setDefaultPaymentMethod(ctx, d, c, defaultPaymentMethod); len(dg) > 0 {
+			return dg
+		}
+	}
+
+	return resourceStripePaymentMethodAttachmentRead(ctx, d, m)
+}
+
+// setDefaultPaymentMethod sets the default payment method of the customer for subscriptions and invoices,
+// an empty identifier unsets it.
+func setDefaultPaymentMethod(ctx context.Context, d *schema.ResourceData, c *stripeClient, paymentMethodID string) diag.Diagnostics {
+	var err error
+
+	params := &stripe.CustomerParams{}
+	if paymentMethodID == "" {
+		params.AddExtra("invoice_settings[default_payment_method]", "")
+	} else {
+		params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(paymentMethodID),
+		}
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Customers.Update(ExtractString(d, "customer"), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceStripePaymentMethodAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	// detaching clears the default payment method of the customer as well
+	params := &stripe.PaymentMethodDetachParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.PaymentMethods.Detach(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
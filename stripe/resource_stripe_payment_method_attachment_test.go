@@ -0,0 +1,95 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripePaymentMethodAttachment(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_payment_method_attachment",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer":       "cus_standin",
+				"payment_method": "tok_visa",
+			},
+			checks: map[string]string{
+				"customer":    "cus_standin",
+				"type":        "card",
+				"brand":       "Visa",
+				"last4":       "4242",
+				"set_default": "false",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":       "cus_standin",
+				"payment_method": "tok_visa",
+				"set_default":    true,
+			},
+			checks: map[string]string{
+				"set_default": "true",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer":       "cus_standin",
+				"payment_method": "tok_mastercard",
+			},
+			checks: map[string]string{
+				"brand":       "MasterCard",
+				"set_default": "false",
+			},
+		},
+		fixtures: map[string]map[string]interface{}{
+			"cus_standin": {"object": "customer"},
+		},
+		// the token the payment method was created from can't be read back
+		importIgnore: []string{"payment_method"},
+	})
+}
+
+func TestAccStripePaymentMethodAttachmentToken(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("cus_standin", map[string]interface{}{"object": "customer"})
+	attachment := func(name string) string {
+		return testAccResource(t, "stripe_payment_method_attachment", name, map[string]interface{}{
+			"customer":       "cus_standin",
+			"payment_method": "tok_visa",
+		})
+	}
+	config := testAccConfig(t, standIn, nil, attachment("first"), attachment("second"))
+
+	first := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// every use of a token creates its own payment method
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccKeepState("stripe_payment_method_attachment.first", first),
+					func(s *terraform.State) error {
+						second, err := testAccPrimary(s, "stripe_payment_method_attachment.second")
+						if err != nil {
+							return err
+						}
+						if second.ID == first.ID {
+							return fmt.Errorf("expected distinct payment methods, both attachments use %s", first.ID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// the replacement doesn't replay the detached payment method, which can't be attached again
+				Config: config,
+				Taint:  []string{"stripe_payment_method_attachment.first"},
+				Check:  testAccCheckSameObject("stripe_payment_method_attachment.first", first, false),
+			},
+		},
+	})
+}
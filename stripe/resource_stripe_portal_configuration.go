@@ -6,8 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripePortalConfiguration() *schema.Resource {
@@ -17,7 +17,7 @@ func resourceStripePortalConfiguration() *schema.Resource {
 		UpdateContext: resourceStripePortalConfigurationUpdate,
 		DeleteContext: resourceStripePortalConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -25,6 +25,7 @@ func resourceStripePortalConfiguration() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"active": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -109,7 +110,17 @@ func resourceStripePortalConfiguration() *schema.Resource {
 										Type:        schema.TypeList,
 										Optional:    true,
 										Description: "The types of customer updates that are supported. When empty, customers are not updatable.",
-										Elem:        &schema.Schema{Type: schema.TypeString},
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdateAddress),
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdateEmail),
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdateName),
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdatePhone),
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdateShipping),
+												string(stripe.BillingPortalConfigurationFeaturesCustomerUpdateAllowedUpdateTaxID),
+											}, false),
+										},
 									},
 								},
 							},
@@ -175,19 +186,40 @@ func resourceStripePortalConfiguration() *schema.Resource {
 													Type:        schema.TypeList,
 													Required:    true,
 													Description: "Which cancellation reasons will be given as options to the customer.",
-													Elem:        &schema.Schema{Type: schema.TypeString},
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+														ValidateFunc: validation.StringInSlice([]string{
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionCustomerService),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionLowQuality),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionMissingFeatures),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionOther),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionSwitchedService),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionTooComplex),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionTooExpensive),
+															string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelCancellationReasonOptionUnused),
+														}, false),
+													},
 												},
 											},
 										},
 									},
 									"mode": {
-										Type:        schema.TypeString,
-										Optional:    true,
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelModeAtPeriodEnd),
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelModeImmediately),
+										}, false),
 										Description: "Whether to cancel subscriptions immediately or at the end of the billing period.",
 									},
 									"proration_behavior": {
-										Type:        schema.TypeString,
-										Optional:    true,
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelProrationBehaviorAlwaysInvoice),
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelProrationBehaviorCreateProrations),
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionCancelProrationBehaviorNone),
+										}, false),
 										Description: "Whether to create prorations when canceling subscriptions.",
 									},
 								},
@@ -204,7 +236,14 @@ func resourceStripePortalConfiguration() *schema.Resource {
 										Type:        schema.TypeList,
 										Required:    true,
 										Description: "The types of subscription updates that are supported. When empty, subscriptions are not updateable.",
-										Elem:        &schema.Schema{Type: schema.TypeString},
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateDefaultAllowedUpdatePrice),
+												string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateDefaultAllowedUpdatePromotionCode),
+												string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateDefaultAllowedUpdateQuantity),
+											}, false),
+										},
 									},
 									"enabled": {
 										Type:        schema.TypeBool,
@@ -232,8 +271,13 @@ func resourceStripePortalConfiguration() *schema.Resource {
 										},
 									},
 									"proration_behavior": {
-										Type:        schema.TypeString,
-										Optional:    true,
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProrationBehaviorAlwaysInvoice),
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProrationBehaviorCreateProrations),
+											string(stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProrationBehaviorNone),
+										}, false),
 										Description: "Determines how to handle prorations resulting from subscription updates",
 									},
 								},
@@ -260,15 +304,16 @@ func resourceStripePortalConfiguration() *schema.Resource {
 	}
 }
 
-func resourceStripePortalConfigurationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripePortalConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var portal *stripe.BillingPortalConfiguration
 	var err error
 
 	params := &stripe.BillingPortalConfigurationParams{}
 	params.AddExpand("features.subscription_update.products")
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		portal, err = c.BillingPortalConfigurations.Get(d.Id(), params)
 		return err
 	})
@@ -383,7 +428,7 @@ func resourceStripePortalConfigurationRead(_ context.Context, d *schema.Resource
 }
 
 func resourceStripePortalConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var portal *stripe.BillingPortalConfiguration
 	var err error
 
@@ -521,7 +566,10 @@ func resourceStripePortalConfigurationCreate(ctx context.Context, d *schema.Reso
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		portal, err = c.BillingPortalConfigurations.New(params)
 		return err
 	})
@@ -534,7 +582,7 @@ func resourceStripePortalConfigurationCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceStripePortalConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.BillingPortalConfigurationParams{}
@@ -673,7 +721,8 @@ func resourceStripePortalConfigurationUpdate(ctx context.Context, d *schema.Reso
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.BillingPortalConfigurations.Update(d.Id(), params)
 		return err
 	})
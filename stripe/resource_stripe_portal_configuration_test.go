@@ -0,0 +1,53 @@
+package stripe
+
+import "testing"
+
+func TestAccStripePortalConfiguration(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_portal_configuration",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"business_profile": []interface{}{
+					map[string]interface{}{
+						"headline":           "Manage your subscription",
+						"privacy_policy_url": "https://example.com/privacy",
+					},
+				},
+				"features": []interface{}{
+					map[string]interface{}{
+						"invoice_history": []interface{}{map[string]interface{}{"enabled": true}},
+					},
+				},
+				"default_return_url": "https://example.com/account",
+			},
+			checks: map[string]string{
+				"active":                               "true",
+				"business_profile.0.headline":          "Manage your subscription",
+				"features.0.invoice_history.0.enabled": "true",
+				"default_return_url":                   "https://example.com/account",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"business_profile": []interface{}{
+					map[string]interface{}{
+						"headline":           "Manage your plan",
+						"privacy_policy_url": "https://example.com/privacy",
+					},
+				},
+				"features": []interface{}{
+					map[string]interface{}{
+						"invoice_history": []interface{}{map[string]interface{}{"enabled": false}},
+					},
+				},
+				"default_return_url": "https://example.com/account",
+				"metadata":           map[string]interface{}{"team": "billing"},
+			},
+			checks: map[string]string{
+				"business_profile.0.headline":          "Manage your plan",
+				"features.0.invoice_history.0.enabled": "false",
+				"metadata.team":                        "billing",
+			},
+		},
+	})
+}
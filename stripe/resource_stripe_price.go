@@ -2,12 +2,13 @@ package stripe
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripePrice() *schema.Resource {
@@ -16,8 +17,9 @@ func resourceStripePrice() *schema.Resource {
 		CreateContext: resourceStripePriceCreate,
 		UpdateContext: resourceStripePriceUpdate,
 		DeleteContext: resourceStripePriceDelete,
+		CustomizeDiff: resourceStripePriceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -25,11 +27,13 @@ func resourceStripePrice() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"currency": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Three-letter ISO currency code, in lowercase.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCurrency,
+				Description:  "Three-letter ISO currency code, in lowercase.",
 			},
 			"product": {
 				Type:        schema.TypeString,
@@ -43,6 +47,7 @@ func resourceStripePrice() *schema.Resource {
 				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"unit_amount_decimal"},
+				ValidateFunc:  validation.IntAtLeast(-1),
 				Description:   "A positive integer in cents (or -1 for a free price) representing how much to charge.",
 			},
 			"unit_amount_decimal": {
@@ -75,13 +80,25 @@ func resourceStripePrice() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"interval": {
-							Type:        schema.TypeString,
-							Required:    true,
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PriceRecurringIntervalDay),
+								string(stripe.PriceRecurringIntervalWeek),
+								string(stripe.PriceRecurringIntervalMonth),
+								string(stripe.PriceRecurringIntervalYear),
+							}, false),
 							Description: "Specifies billing frequency. Either day, week, month or year.",
 						},
 						"aggregate_usage": {
 							Type:     schema.TypeString,
 							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PriceRecurringAggregateUsageSum),
+								string(stripe.PriceRecurringAggregateUsageLastDuringPeriod),
+								string(stripe.PriceRecurringAggregateUsageLastEver),
+								string(stripe.PriceRecurringAggregateUsageMax),
+							}, false),
 							Description: "Specifies a usage aggregation strategy for prices of usage_type=metered. " +
 								"Allowed values are sum for summing up all usage during a period, " +
 								"last_during_period for using the last usage record reported within a period, " +
@@ -89,8 +106,9 @@ func resourceStripePrice() *schema.Resource {
 								"uses the usage record with the maximum reported usage during a period. ",
 						},
 						"interval_count": {
-							Type:     schema.TypeInt,
-							Optional: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(1, 365),
 							Description: "The number of intervals between subscription billings. " +
 								"For example, interval=month and interval_count=3 bills every 3 months. " +
 								"Maximum of one year interval allowed (1 year, 12 months, or 52 weeks).",
@@ -99,6 +117,10 @@ func resourceStripePrice() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 							Default:  "licensed",
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PriceRecurringUsageTypeLicensed),
+								string(stripe.PriceRecurringUsageTypeMetered),
+							}, false),
 							Description: "Configures how the quantity per period should be determined. " +
 								"Can be either metered or licensed. licensed automatically bills the quantity " +
 								"set when adding it to a subscription. metered aggregates the total usage " +
@@ -171,6 +193,10 @@ func resourceStripePrice() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PriceTiersModeGraduated),
+					string(stripe.PriceTiersModeVolume),
+				}, false),
 				Description: "Defines if the tiering price should be graduated or volume based. " +
 					"In volume-based tiering, the maximum quantity within a period determines the per unit price, " +
 					"in graduated tiering pricing can successively change as the quantity grows.",
@@ -180,6 +206,10 @@ func resourceStripePrice() *schema.Resource {
 				Optional: true,
 				Computed: true,
 				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PriceBillingSchemePerUnit),
+					string(stripe.PriceBillingSchemeTiered),
+				}, false),
 				Description: "Describes how to compute the price per period. " +
 					"Either per_unit or tiered. per_unit indicates that the fixed amount " +
 					"(specified in unit_amount or unit_amount_decimal) will be charged per unit in quantity " +
@@ -196,13 +226,19 @@ func resourceStripePrice() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"currency": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Each currency must be a three-letter ISO currency code and a supported currency",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Each currency must be a three-letter ISO currency code and a supported currency",
 						},
 						"tax_behavior": {
 							Type:     schema.TypeString,
 							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PriceCurrencyOptionsTaxBehaviorExclusive),
+								string(stripe.PriceCurrencyOptionsTaxBehaviorInclusive),
+								string(stripe.PriceCurrencyOptionsTaxBehaviorUnspecified),
+							}, false),
 							Description: "Only required if a default tax behavior was not provided in the Stripe Tax settings." +
 								" Specifies whether the price is considered inclusive of taxes or exclusive of taxes." +
 								" One of inclusive, exclusive, or unspecified." +
@@ -349,6 +385,11 @@ func resourceStripePrice() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  stripe.PriceTaxBehaviorUnspecified,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PriceTaxBehaviorExclusive),
+					string(stripe.PriceTaxBehaviorInclusive),
+					string(stripe.PriceTaxBehaviorUnspecified),
+				}, false),
 				Description: "Specifies whether the price is considered inclusive of taxes or exclusive of taxes. " +
 					"One of inclusive, exclusive, or unspecified. " +
 					"Once specified as either inclusive or exclusive, it cannot be changed.",
@@ -363,15 +404,20 @@ func resourceStripePrice() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"divide_by": {
-							Type:        schema.TypeInt,
-							Required:    true,
-							ForceNew:    true,
-							Description: "Divide usage by this number.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Divide usage by this number.",
 						},
 						"round": {
-							Type:        schema.TypeString,
-							ForceNew:    true,
-							Required:    true,
+							Type:     schema.TypeString,
+							ForceNew: true,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.PriceTransformQuantityRoundDown),
+								string(stripe.PriceTransformQuantityRoundUp),
+							}, false),
 							Description: "After division, either round the result up or down",
 						},
 					},
@@ -383,6 +429,24 @@ func resourceStripePrice() *schema.Resource {
 				Description: "One of one_time or recurring depending on whether the price is for a one-time purchase " +
 					"or a recurring (subscription) purchase",
 			},
+			"previous_price_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "ID of the price this price replaced, i.e. the price holding the lookup key when " +
+					"this price was created. A change of an immutable component (e.g. unit_amount, recurring or tiers) " +
+					"replaces the price by a new one taking over the lookup key.",
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the price when the resource is destroyed, " +
+					"Stripe doesn't support its deletion. Either archive (the price is set inactive, so it can no longer be used for new purchases) " +
+					"or abandon (the price is only removed from the Terraform state). Defaults to archive. " +
+					"It applies to the price replaced on a change of an immutable component as well.",
+			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -394,15 +458,76 @@ func resourceStripePrice() *schema.Resource {
 	}
 }
 
-func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripePriceCustomizeDiff checks the rules between the billing scheme, tiers and recurring components.
+func resourceStripePriceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// billing_scheme is computed, when omitted from the configuration it's unknown until the price is created
+	if diffKnown(d, "tiers", "tiers_mode") && (d.NewValueKnown("billing_scheme") || diffConfigNull(d, "billing_scheme")) {
+		tiers := ToMapSlice(d.Get("tiers"))
+		billingScheme := ToString(d.Get("billing_scheme"))
+		switch {
+		case len(tiers) > 0 && billingScheme != string(stripe.PriceBillingSchemeTiered):
+			return diffError("billing_scheme", "must be set to tiered when tiers are set")
+		case len(tiers) == 0 && billingScheme == string(stripe.PriceBillingSchemeTiered):
+			return diffError("tiers", "required when billing_scheme is tiered")
+		case len(tiers) > 0 && ToString(d.Get("tiers_mode")) == "":
+			return diffError("tiers_mode", "required when tiers are set")
+		case len(tiers) == 0 && ToString(d.Get("tiers_mode")) != "":
+			return diffError("tiers_mode", "only allowed when tiers are set")
+		}
+
+		if len(tiers) > 0 {
+			// the amounts are computed and carry the values of a replaced price, only the configured ones conflict
+			if diffConfigSet(d, "unit_amount") || diffConfigSet(d, "unit_amount_decimal") {
+				return diffError("unit_amount", "can't be combined with tiers, set the amounts on the tiers instead")
+			}
+			if diffKnown(d, "transform_quantity") && len(ToSlice(d.Get("transform_quantity"))) > 0 {
+				return diffError("transform_quantity", "can't be combined with tiers")
+			}
+		}
+
+		var previousUpTo int64
+		for i, tier := range tiers {
+			path := fmt.Sprintf("tiers.%d.up_to", i)
+			if !d.NewValueKnown(path) {
+				continue
+			}
+			upTo := ToInt64(tier["up_to"])
+			switch {
+			case i == len(tiers)-1 && upTo != -1:
+				return diffError(path, "the last tier must be the fallback tier with up_to -1, got %d", upTo)
+			case i < len(tiers)-1 && upTo <= previousUpTo:
+				return diffError(path, "must be greater than the upper bound of the previous tier (%d), got %d",
+					previousUpTo, upTo)
+			}
+			previousUpTo = upTo
+		}
+	}
+
+	if diffKnown(d, "recurring") {
+		if recurring := ToMapSlice(d.Get("recurring")); len(recurring) > 0 {
+			if ToString(recurring[0]["usage_type"]) != string(stripe.PriceRecurringUsageTypeMetered) {
+				for _, k := range []string{"aggregate_usage", "meter"} {
+					if ToString(recurring[0][k]) != "" {
+						return diffError("recurring.0."+k, "only allowed when usage_type is metered")
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceStripePriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var price *stripe.Price
 	var err error
 
-	err = retryWithBackOff(func() error {
-		params := &stripe.PriceParams{}
-		params.AddExpand("tiers")
+	params := &stripe.PriceParams{}
+	params.AddExpand("tiers")
+	setStripeAccount(d, params)
 
+	err = c.retryWithBackOff(ctx, params, func() error {
 		price, err = c.Prices.Get(d.Id(), params)
 		return err
 	})
@@ -432,6 +557,8 @@ func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interf
 		}(),
 		d.Set("active", price.Active),
 		d.Set("nickname", price.Nickname),
+		// the replaced price is only known to Terraform
+		d.Set("previous_price_ids", ToStringSlice(d.Get("previous_price_ids"))),
 		func() error {
 			if price.Recurring != nil {
 				return d.Set("recurring", []map[string]interface{}{
@@ -555,10 +682,76 @@ func resourceStripePriceRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var price *stripe.Price
 	var err error
 
+	params := expandPriceParams(d)
+	dg := CallSet(func() error {
+		if params.TransferLookupKey != nil {
+			return d.Set("transfer_lookup_key", *params.TransferLookupKey)
+		}
+		return nil
+	}())
+	if len(dg) > 0 {
+		return dg
+	}
+
+	// the price replacing another one takes over its lookup key, the replaced price is archived by its destroy.
+	// The transfer is requested whether the key is held or not, so a retried creation sends the same parameters.
+	previousPriceID, err := lookupKeyHolder(ctx, d, c, params.LookupKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if params.LookupKey != nil {
+		params.TransferLookupKey = stripe.Bool(true)
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		price, err = c.Prices.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(price.ID)
+	// the holder of a retried creation is the price created by the first attempt
+	if previousPriceID != "" && previousPriceID != price.ID {
+		if dg := CallSet(d.Set("previous_price_ids", []string{previousPriceID})); len(dg) > 0 {
+			return dg
+		}
+	}
+
+	return resourceStripePriceRead(ctx, d, m)
+}
+
+// lookupKeyHolder returns the ID of the price holding the lookup key, if any.
+func lookupKeyHolder(ctx context.Context, d *schema.ResourceData, c *stripeClient, lookupKey *string) (string, error) {
+	if lookupKey == nil {
+		return "", nil
+	}
+
+	var priceID string
+	params := &stripe.PriceListParams{
+		LookupKeys: stripe.StringSlice([]string{*lookupKey}),
+	}
+	setStripeAccount(d, params)
+	err := c.retryWithBackOff(ctx, params, func() error {
+		priceID = ""
+		i := c.Prices.List(params)
+		for i.Next() {
+			priceID = i.Price().ID
+		}
+		return i.Err()
+	})
+	return priceID, err
+}
+
+// expandPriceParams builds the parameters creating the price of the configuration.
+func expandPriceParams(d *schema.ResourceData) *stripe.PriceParams {
 	params := &stripe.PriceParams{
 		Product:  stripe.String(ExtractString(d, "product")),
 		Currency: stripe.String(ExtractString(d, "currency")),
@@ -633,62 +826,7 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if currencyOptions, set := d.GetOk("currency_options"); set {
-		params.CurrencyOptions = make(map[string]*stripe.PriceCurrencyOptionsParams)
-		for _, coMap := range ToMapSlice(currencyOptions) {
-			currencyOption := &stripe.PriceCurrencyOptionsParams{}
-			for k, v := range coMap {
-				switch k {
-				case "currency":
-					params.CurrencyOptions[ToString(v)] = currencyOption
-				case "tax_behavior":
-					currencyOption.TaxBehavior = NonZeroString(v)
-				case "unit_amount":
-					currencyOption.UnitAmount = NonZeroInt64(v)
-				case "unit_amount_decimal":
-					currencyOption.UnitAmountDecimal = NonZeroFloat64(v)
-				case "custom_unit_amount":
-					for _, cuaMap := range ToMapSlice(v) {
-						currencyOption.CustomUnitAmount = &stripe.PriceCurrencyOptionsCustomUnitAmountParams{}
-						for k, v := range cuaMap {
-							switch k {
-							case "enabled":
-								currencyOption.CustomUnitAmount.Enabled = stripe.Bool(ToBool(v))
-							case "maximum":
-								currencyOption.CustomUnitAmount.Maximum = NonZeroInt64(v)
-							case "minimum":
-								currencyOption.CustomUnitAmount.Minimum = NonZeroInt64(v)
-							case "preset":
-								currencyOption.CustomUnitAmount.Preset = NonZeroInt64(v)
-							}
-						}
-					}
-				case "tiers":
-					for _, tiersMap := range ToMapSlice(v) {
-						priceTier := &stripe.PriceCurrencyOptionsTierParams{}
-						for k, v := range tiersMap {
-							switch {
-							case k == "up_to" && ToInt64(v) != 0:
-								upTo := ToInt64(v)
-								if upTo < 0 {
-									priceTier.UpToInf = stripe.Bool(true)
-								} else {
-									priceTier.UpTo = stripe.Int64(ToInt64(v))
-								}
-							case k == "flat_amount":
-								priceTier.FlatAmount = NonZeroInt64(v)
-							case k == "flat_amount_decimal":
-								priceTier.FlatAmountDecimal = NonZeroFloat64(v)
-							case k == "unit_amount":
-								priceTier.UnitAmount = NonZeroInt64(v)
-							case k == "unit_amount_decimal":
-								priceTier.UnitAmountDecimal = NonZeroFloat64(v)
-							}
-						}
-						currencyOption.Tiers = append(currencyOption.Tiers, priceTier)
-					}
-				}
-			}
-		}
+		params.CurrencyOptions = expandPriceCurrencyOptions(currencyOptions)
 	}
 
 	if customUnitAmount, set := d.GetOk("custom_unit_amount"); set {
@@ -735,31 +873,72 @@ func resourceStripePriceCreate(ctx context.Context, d *schema.ResourceData, m in
 			params.AddMetadata(k, ToString(v))
 		}
 	}
+	return params
+}
 
-	err = retryWithBackOff(func() error {
-		price, err = c.Prices.New(params)
-		return err
-	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	dg := CallSet(func() error {
-		if params.TransferLookupKey != nil {
-			return d.Set("transfer_lookup_key", *params.TransferLookupKey)
+// expandPriceCurrencyOptions builds the prices in other currencies of the currency_options blocks.
+func expandPriceCurrencyOptions(options interface{}) map[string]*stripe.PriceCurrencyOptionsParams {
+	currencyOptions := make(map[string]*stripe.PriceCurrencyOptionsParams)
+	for _, coMap := range ToMapSlice(options) {
+		currencyOption := &stripe.PriceCurrencyOptionsParams{}
+		for k, v := range coMap {
+			switch k {
+			case "currency":
+				currencyOptions[ToString(v)] = currencyOption
+			case "tax_behavior":
+				currencyOption.TaxBehavior = NonZeroString(v)
+			case "unit_amount":
+				currencyOption.UnitAmount = NonZeroInt64(v)
+			case "unit_amount_decimal":
+				currencyOption.UnitAmountDecimal = NonZeroFloat64(v)
+			case "custom_unit_amount":
+				for _, cuaMap := range ToMapSlice(v) {
+					currencyOption.CustomUnitAmount = &stripe.PriceCurrencyOptionsCustomUnitAmountParams{}
+					for k, v := range cuaMap {
+						switch k {
+						case "enabled":
+							currencyOption.CustomUnitAmount.Enabled = stripe.Bool(ToBool(v))
+						case "maximum":
+							currencyOption.CustomUnitAmount.Maximum = NonZeroInt64(v)
+						case "minimum":
+							currencyOption.CustomUnitAmount.Minimum = NonZeroInt64(v)
+						case "preset":
+							currencyOption.CustomUnitAmount.Preset = NonZeroInt64(v)
+						}
+					}
+				}
+			case "tiers":
+				for _, tiersMap := range ToMapSlice(v) {
+					priceTier := &stripe.PriceCurrencyOptionsTierParams{}
+					for k, v := range tiersMap {
+						switch {
+						case k == "up_to" && ToInt64(v) != 0:
+							upTo := ToInt64(v)
+							if upTo < 0 {
+								priceTier.UpToInf = stripe.Bool(true)
+							} else {
+								priceTier.UpTo = stripe.Int64(ToInt64(v))
+							}
+						case k == "flat_amount":
+							priceTier.FlatAmount = NonZeroInt64(v)
+						case k == "flat_amount_decimal":
+							priceTier.FlatAmountDecimal = NonZeroFloat64(v)
+						case k == "unit_amount":
+							priceTier.UnitAmount = NonZeroInt64(v)
+						case k == "unit_amount_decimal":
+							priceTier.UnitAmountDecimal = NonZeroFloat64(v)
+						}
+					}
+					currencyOption.Tiers = append(currencyOption.Tiers, priceTier)
+				}
+			}
 		}
-		return nil
-	}())
-	if len(dg) > 0 {
-		return dg
 	}
-
-	d.SetId(price.ID)
-	return resourceStripePriceRead(ctx, d, m)
+	return currencyOptions
 }
 
 func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.PriceParams{}
@@ -771,62 +950,7 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		params.Nickname = stripe.String(ExtractString(d, "nickname"))
 	}
 	if d.HasChange("currency_options") {
-		params.CurrencyOptions = make(map[string]*stripe.PriceCurrencyOptionsParams)
-		for _, coMap := range ExtractMapSlice(d, "currency_options") {
-			currencyOption := &stripe.PriceCurrencyOptionsParams{}
-			for k, v := range coMap {
-				switch k {
-				case "currency":
-					params.CurrencyOptions[ToString(v)] = currencyOption
-				case "tax_behavior":
-					currencyOption.TaxBehavior = NonZeroString(v)
-				case "unit_amount":
-					currencyOption.UnitAmount = NonZeroInt64(v)
-				case "unit_amount_decimal":
-					currencyOption.UnitAmountDecimal = NonZeroFloat64(v)
-				case "custom_unit_amount":
-					for _, cuaMap := range ToMapSlice(v) {
-						currencyOption.CustomUnitAmount = &stripe.PriceCurrencyOptionsCustomUnitAmountParams{}
-						for k, v := range cuaMap {
-							switch k {
-							case "enabled":
-								currencyOption.CustomUnitAmount.Enabled = stripe.Bool(ToBool(v))
-							case "maximum":
-								currencyOption.CustomUnitAmount.Maximum = NonZeroInt64(v)
-							case "minimum":
-								currencyOption.CustomUnitAmount.Minimum = NonZeroInt64(v)
-							case "preset":
-								currencyOption.CustomUnitAmount.Preset = NonZeroInt64(v)
-							}
-						}
-					}
-				case "tiers":
-					for _, tiersMap := range ToMapSlice(v) {
-						priceTier := &stripe.PriceCurrencyOptionsTierParams{}
-						for k, v := range tiersMap {
-							switch {
-							case k == "up_to" && ToInt64(v) != 0:
-								upTo := ToInt64(v)
-								if upTo < 0 {
-									priceTier.UpToInf = stripe.Bool(true)
-								} else {
-									priceTier.UpTo = stripe.Int64(ToInt64(v))
-								}
-							case k == "flat_amount":
-								priceTier.FlatAmount = NonZeroInt64(v)
-							case k == "flat_amount_decimal":
-								priceTier.FlatAmountDecimal = NonZeroFloat64(v)
-							case k == "unit_amount":
-								priceTier.UnitAmount = NonZeroInt64(v)
-							case k == "unit_amount_decimal":
-								priceTier.UnitAmountDecimal = NonZeroFloat64(v)
-							}
-						}
-						currencyOption.Tiers = append(currencyOption.Tiers, priceTier)
-					}
-				}
-			}
-		}
+		params.CurrencyOptions = expandPriceCurrencyOptions(d.Get("currency_options"))
 	}
 	if d.HasChange("lookup_key") {
 		params.LookupKey = stripe.String(ExtractString(d, "lookup_key"))
@@ -842,7 +966,8 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Prices.Update(d.Id(), params)
 		return err
 	})
@@ -854,7 +979,37 @@ func resourceStripePriceUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceStripePriceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	tflog.Warn(ctx, "[WARN] Stripe API doesn't support deletion of promotion code")
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Price is abandoned, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	if err := archivePrice(ctx, d, c, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+// archivePrice sets the price inactive, Stripe doesn't support the deletion of prices.
+// A price which doesn't exist anymore is fine.
+func archivePrice(ctx context.Context, d *schema.ResourceData, c *stripeClient, id string) error {
+	var err error
+
+	params := &stripe.PriceParams{
+		Active: stripe.Bool(false),
+	}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Prices.Update(id, params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripePrice(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_price",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"product":     "prod_standin",
+				"currency":    "usd",
+				"unit_amount": 1500,
+				"nickname":    "monthly",
+				"recurring": []interface{}{
+					map[string]interface{}{"interval": "month", "interval_count": 1},
+				},
+				"metadata": map[string]interface{}{"plan": "gold"},
+			},
+			checks: map[string]string{
+				"unit_amount":                "1500",
+				"type":                       "recurring",
+				"billing_scheme":             "per_unit",
+				"recurring.0.interval":       "month",
+				"recurring.0.usage_type":     "licensed",
+				"metadata.plan":              "gold",
+				"active":                     "true",
+				"recurring.0.interval_count": "1",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"product":     "prod_standin",
+				"currency":    "usd",
+				"unit_amount": 1500,
+				"nickname":    "monthly gold",
+				"lookup_key":  "gold_monthly",
+				"recurring": []interface{}{
+					map[string]interface{}{"interval": "month", "interval_count": 1},
+				},
+				"metadata": map[string]interface{}{"plan": "gold", "v": "2"},
+			},
+			checks: map[string]string{
+				"nickname":   "monthly gold",
+				"lookup_key": "gold_monthly",
+				"metadata.v": "2",
+			},
+		},
+		importIgnore: []string{"on_destroy", "transfer_lookup_key"},
+	})
+}
+
+func TestAccStripePriceReplacement(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	arguments := map[string]interface{}{
+		"product":     "prod_standin",
+		"currency":    "usd",
+		"unit_amount": 1500,
+		"lookup_key":  "gold_monthly",
+		"recurring": []interface{}{
+			map[string]interface{}{"interval": "month"},
+		},
+	}
+	config := func(createBeforeDestroy bool, changes map[string]interface{}) string {
+		values := map[string]interface{}{}
+		for k, v := range arguments {
+			values[k] = v
+		}
+		for k, v := range changes {
+			values[k] = v
+		}
+		price := testAccResource(t, "stripe_price", "test", values)
+		if createBeforeDestroy {
+			price = strings.TrimSuffix(price, "}\n") + "  lifecycle {\n    create_before_destroy = true\n  }\n}\n"
+		}
+		return testAccConfig(t, standIn, nil, price, testAccDependent(t, "stripe_price.test"))
+	}
+	tiered := map[string]interface{}{
+		"unit_amount":    nil,
+		"billing_scheme": "tiered",
+		"tiers_mode":     "graduated",
+		"tiers": []interface{}{
+			map[string]interface{}{"up_to": 10, "unit_amount": 0},
+			map[string]interface{}{"up_to": -1, "unit_amount": 500},
+		},
+	}
+	// the replaced prices are archived unless abandoned, only the price of the state holds the lookup key
+	checkPrices := func(current *terraform.InstanceState, archived, abandoned []*terraform.InstanceState,
+	) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if err := testAccKeepState("stripe_price.test", current)(s); err != nil {
+				return err
+			}
+			active := map[string]bool{current.ID: true}
+			for _, previous := range abandoned {
+				active[previous.ID] = true
+			}
+			for _, previous := range append(append([]*terraform.InstanceState{current}, archived...), abandoned...) {
+				price, _ := standIn.object(previous.ID)
+				if fmt.Sprint(price["active"]) != fmt.Sprint(active[previous.ID]) {
+					return fmt.Errorf("expected %s to be active %t, got %v", previous.ID, active[previous.ID],
+						price["active"])
+				}
+				if holds := previous.ID == current.ID; (ToString(price["lookup_key"]) == "gold_monthly") != holds {
+					return fmt.Errorf("expected %s to hold the lookup key %t, got %v", previous.ID, holds,
+						price["lookup_key"])
+				}
+			}
+			return nil
+		}
+	}
+
+	first, second, third := &terraform.InstanceState{}, &terraform.InstanceState{}, &terraform.InstanceState{}
+	fourth, fifth := &terraform.InstanceState{}, &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false, nil),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_price.test", map[string]string{"previous_price_ids.#": "0"}),
+					testAccCheckDependent("stripe_price.test"),
+					testAccKeepState("stripe_price.test", first),
+				),
+			},
+			{
+				// repricing replaces the price, the previous one is destroyed first
+				Config: config(false, map[string]interface{}{"unit_amount": 1800}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_price.test", map[string]string{
+						"unit_amount":          "1800",
+						"lookup_key":           "gold_monthly",
+						"transfer_lookup_key":  "false",
+						"previous_price_ids.#": "1",
+					}),
+					testAccCheckSameObject("stripe_price.test", first, false),
+					testAccCheckPreviousPrice("stripe_price.test", 0, first),
+					testAccCheckDependent("stripe_price.test"),
+					checkPrices(second, []*terraform.InstanceState{first}, nil),
+				),
+			},
+			{
+				Config: config(false, map[string]interface{}{
+					"unit_amount":    1800,
+					"billing_scheme": "tiered",
+					"tiers_mode":     "graduated",
+					"tiers":          tiered["tiers"],
+				}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unit_amount: can't be combined with tiers`),
+			},
+			{
+				// the new price takes over the lookup key while the previous one is still active
+				Config: config(true, tiered),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_price.test", map[string]string{
+						"billing_scheme":       "tiered",
+						"tiers.#":              "2",
+						"tiers.1.unit_amount":  "500",
+						"previous_price_ids.#": "1",
+					}),
+					testAccCheckPreviousPrice("stripe_price.test", 0, second),
+					testAccCheckDependent("stripe_price.test"),
+					checkPrices(third, []*terraform.InstanceState{first, second}, nil),
+				),
+			},
+			{
+				// on_destroy of the replaced price applies, it was set to archive
+				Config: config(true, map[string]interface{}{
+					"unit_amount":    nil,
+					"billing_scheme": "tiered",
+					"tiers_mode":     "volume",
+					"tiers":          tiered["tiers"],
+					"on_destroy":     onDestroyAbandon,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPreviousPrice("stripe_price.test", 0, third),
+					testAccCheckDependent("stripe_price.test"),
+					checkPrices(fourth, []*terraform.InstanceState{first, second, third}, nil),
+				),
+			},
+			{
+				// the abandoned price stays active, it no longer holds the lookup key
+				Config: config(false, map[string]interface{}{
+					"unit_amount":    nil,
+					"billing_scheme": "tiered",
+					"tiers_mode":     "graduated",
+					"tiers":          tiered["tiers"],
+					"on_destroy":     onDestroyAbandon,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPreviousPrice("stripe_price.test", 0, fourth),
+					testAccCheckDependent("stripe_price.test"),
+					checkPrices(fifth, []*terraform.InstanceState{first, second, third},
+						[]*terraform.InstanceState{fourth}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePricePlanErrors(t *testing.T) {
+	price := func(changes map[string]interface{}) map[string]interface{} {
+		values := map[string]interface{}{"product": "prod_standin", "currency": "usd"}
+		for k, v := range changes {
+			values[k] = v
+		}
+		return values
+	}
+	tiers := []interface{}{
+		map[string]interface{}{"up_to": 10, "unit_amount": 1000},
+		map[string]interface{}{"up_to": -1, "unit_amount": 800},
+	}
+
+	testAccRunPlanErrors(t, "stripe_price", []testAccPlanError{
+		{
+			config: price(map[string]interface{}{"billing_scheme": "per_unit", "tiers_mode": "volume", "tiers": tiers}),
+			err:    "billing_scheme: must be set to tiered when tiers are set",
+		},
+		{
+			config: price(map[string]interface{}{"billing_scheme": "tiered", "tiers_mode": "volume"}),
+			err:    "tiers: required when billing_scheme is tiered",
+		},
+		{
+			config: price(map[string]interface{}{"billing_scheme": "tiered", "tiers": tiers}),
+			err:    "tiers_mode: required when tiers are set",
+		},
+		{
+			config: price(map[string]interface{}{"unit_amount": 1000, "tiers_mode": "volume"}),
+			err:    "tiers_mode: only allowed when tiers are set",
+		},
+		{
+			config: price(map[string]interface{}{
+				"unit_amount":    1000,
+				"billing_scheme": "tiered",
+				"tiers_mode":     "volume",
+				"tiers":          tiers,
+			}),
+			err: "unit_amount: can't be combined with tiers, set the amounts on the tiers instead",
+		},
+		{
+			config: price(map[string]interface{}{
+				"billing_scheme":     "tiered",
+				"tiers_mode":         "volume",
+				"tiers":              tiers,
+				"transform_quantity": []interface{}{map[string]interface{}{"divide_by": 10, "round": "up"}},
+			}),
+			err: "transform_quantity: can't be combined with tiers",
+		},
+		{
+			config: price(map[string]interface{}{
+				"billing_scheme": "tiered",
+				"tiers_mode":     "graduated",
+				"tiers": []interface{}{
+					map[string]interface{}{"up_to": 10, "unit_amount": 1000},
+					map[string]interface{}{"up_to": 20, "unit_amount": 800},
+				},
+			}),
+			err: "tiers.1.up_to: the last tier must be the fallback tier with up_to -1, got 20",
+		},
+		{
+			config: price(map[string]interface{}{
+				"billing_scheme": "tiered",
+				"tiers_mode":     "graduated",
+				"tiers": []interface{}{
+					map[string]interface{}{"up_to": 10, "unit_amount": 1000},
+					map[string]interface{}{"up_to": 5, "unit_amount": 800},
+					map[string]interface{}{"up_to": -1, "unit_amount": 600},
+				},
+			}),
+			err: "tiers.1.up_to: must be greater than the upper bound of the previous tier (10), got 5",
+		},
+		{
+			config: price(map[string]interface{}{
+				"unit_amount": 1000,
+				"recurring": []interface{}{
+					map[string]interface{}{"interval": "month", "usage_type": "licensed", "aggregate_usage": "sum"},
+				},
+			}),
+			err: "recurring.0.aggregate_usage: only allowed when usage_type is metered",
+		},
+	})
+}
+
+// testAccCheckPreviousPrice checks the price of the kept state is the previous price at the index.
+func testAccCheckPreviousPrice(address string, index int, previous *terraform.InstanceState) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return resource.TestCheckResourceAttr(address, fmt.Sprintf("previous_price_ids.%d", index), previous.ID)(s)
+	}
+}
@@ -0,0 +1,603 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+// pricingMatrixIntervals are the billing intervals of a pricing matrix in their natural order.
+var pricingMatrixIntervals = []string{
+	string(stripe.PriceRecurringIntervalDay),
+	string(stripe.PriceRecurringIntervalWeek),
+	string(stripe.PriceRecurringIntervalMonth),
+	string(stripe.PriceRecurringIntervalYear),
+}
+
+func resourceStripePricingMatrix() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripePricingMatrixRead,
+		CreateContext: resourceStripePricingMatrixCreate,
+		UpdateContext: resourceStripePricingMatrixUpdate,
+		DeleteContext: resourceStripePricingMatrixDelete,
+		CustomizeDiff: resourceStripePricingMatrixCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripePricingMatrix,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the product, a product has a single pricing matrix.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"product": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the product that the prices of the matrix belong to.",
+			},
+			"intervals": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(pricingMatrixIntervals, false),
+				},
+				Description: "The billing intervals of the prices, any of day, week, month or year. " +
+					"Every interval gets a price in every currency of amount.",
+			},
+			"amount": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Description: "The amounts of the prices in a currency, " +
+					"there is a price for every interval in every currency.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO currency code, in lowercase.",
+						},
+						"unit_amounts": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+							Description: "The unit amount in cents (0 for a free price) per interval, " +
+								"e.g. { month = 1000, year = 10000 }. Every interval needs an amount.",
+						},
+						"currency_options": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Description: "Prices defined in other currencies, " +
+								"they're added to the prices of this currency as currency options.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateCurrency,
+										Description:  "Three-letter ISO currency code, in lowercase.",
+									},
+									"unit_amounts": {
+										Type:     schema.TypeMap,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeInt},
+										Description: "The unit amount in cents (0 for a free price) per interval. " +
+											"Every interval needs an amount.",
+									},
+									"tax_behavior": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(stripe.PriceCurrencyOptionsTaxBehaviorExclusive),
+											string(stripe.PriceCurrencyOptionsTaxBehaviorInclusive),
+											string(stripe.PriceCurrencyOptionsTaxBehaviorUnspecified),
+										}, false),
+										Description: "Specifies whether the price is considered inclusive of taxes " +
+											"or exclusive of taxes. One of inclusive, exclusive, or unspecified.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tax_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  stripe.PriceTaxBehaviorUnspecified,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.PriceTaxBehaviorExclusive),
+					string(stripe.PriceTaxBehaviorInclusive),
+					string(stripe.PriceTaxBehaviorUnspecified),
+				}, false),
+				Description: "Specifies whether the prices are considered inclusive of taxes or exclusive of taxes. " +
+					"One of inclusive, exclusive, or unspecified.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs attached to every price of the matrix.",
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyArchive,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyArchive, onDestroyAbandon}, false),
+				Description: "What happens with the prices when the resource is destroyed, " +
+					"Stripe doesn't support their deletion. Either archive (the prices are set inactive) " +
+					"or abandon (the prices are only removed from the Terraform state). Defaults to archive. " +
+					"Replaced prices and the prices of removed intervals and currencies are always archived.",
+			},
+			"prices": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the prices keyed by <interval>/<currency>, " +
+					"e.g. prices[\"month/usd\"].",
+			},
+		},
+	}
+}
+
+// pricingMatrixKey identifies the price of the interval and currency within the matrix.
+func pricingMatrixKey(interval, currency string) string {
+	return interval + "/" + currency
+}
+
+// resourceStripePricingMatrixCustomizeDiff checks every interval has an amount in every currency.
+func resourceStripePricingMatrixCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && d.HasChanges("intervals", "amount", "tax_behavior") {
+		if err := d.SetNewComputed("prices"); err != nil {
+			return err
+		}
+	}
+
+	if !diffKnown(d, "intervals", "amount") {
+		return nil
+	}
+
+	intervals := ToStringSlice(d.Get("intervals"))
+	for i, interval := range intervals {
+		if containsString(intervals[:i], interval) {
+			return diffError(fmt.Sprintf("intervals.%d", i), "interval %s is listed more than once", interval)
+		}
+	}
+
+	checkUnitAmounts := func(path string, unitAmounts map[string]interface{}) error {
+		for _, interval := range intervals {
+			if _, ok := unitAmounts[interval]; !ok {
+				return diffError(path, "missing the amount of interval %s", interval)
+			}
+		}
+		for interval, unitAmount := range unitAmounts {
+			if !containsString(intervals, interval) {
+				return diffError(path, "%s isn't one of the intervals %s", interval, strings.Join(intervals, ", "))
+			}
+			if ToInt64(unitAmount) < 0 {
+				return diffError(path, "the amount of interval %s can't be negative", interval)
+			}
+		}
+		return nil
+	}
+
+	var currencies []string
+	for i, amount := range ToMapSlice(d.Get("amount")) {
+		currency := ToString(amount["currency"])
+		if containsString(currencies, currency) {
+			return diffError(fmt.Sprintf("amount.%d.currency", i), "currency %s is listed more than once", currency)
+		}
+		currencies = append(currencies, currency)
+		if err := checkUnitAmounts(fmt.Sprintf("amount.%d.unit_amounts", i), ToMap(amount["unit_amounts"])); err != nil {
+			return err
+		}
+
+		optionCurrencies := []string{currency}
+		for j, option := range ToMapSlice(amount["currency_options"]) {
+			optionCurrency := ToString(option["currency"])
+			if containsString(optionCurrencies, optionCurrency) {
+				return diffError(fmt.Sprintf("amount.%d.currency_options.%d.currency", i, j),
+					"currency %s is listed more than once", optionCurrency)
+			}
+			optionCurrencies = append(optionCurrencies, optionCurrency)
+			err := checkUnitAmounts(fmt.Sprintf("amount.%d.currency_options.%d.unit_amounts", i, j),
+				ToMap(option["unit_amounts"]))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandPricingMatrix builds the parameters creating every price of the matrix, keyed by <interval>/<currency>.
+// Metadata isn't part of them, it's the only component of the prices updated in place.
+func expandPricingMatrix(product string, intervals, amounts interface{},
+	taxBehavior string) map[string]*stripe.PriceParams {
+	prices := map[string]*stripe.PriceParams{}
+	for _, interval := range ToStringSlice(intervals) {
+		for _, amount := range ToMapSlice(amounts) {
+			unitAmount, ok := ToMap(amount["unit_amounts"])[interval]
+			if !ok {
+				continue
+			}
+			params := &stripe.PriceParams{
+				Product:     stripe.String(product),
+				Currency:    stripe.String(ToString(amount["currency"])),
+				UnitAmount:  stripe.Int64(ToInt64(unitAmount)),
+				TaxBehavior: NonZeroString(taxBehavior),
+				Recurring: &stripe.PriceRecurringParams{
+					Interval: stripe.String(interval),
+				},
+			}
+
+			var currencyOptions []interface{}
+			for _, option := range ToMapSlice(amount["currency_options"]) {
+				optionAmount, ok := ToMap(option["unit_amounts"])[interval]
+				if !ok {
+					continue
+				}
+				// the currency options take -1 for a free price
+				if ToInt64(optionAmount) == 0 {
+					optionAmount = -1
+				}
+				currencyOptions = append(currencyOptions, map[string]interface{}{
+					"currency":     option["currency"],
+					"unit_amount":  optionAmount,
+					"tax_behavior": option["tax_behavior"],
+				})
+			}
+			if len(currencyOptions) > 0 {
+				params.CurrencyOptions = expandPriceCurrencyOptions(currencyOptions)
+			}
+
+			prices[pricingMatrixKey(interval, ToString(amount["currency"]))] = params
+		}
+	}
+	return prices
+}
+
+func resourceStripePricingMatrixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var price *stripe.Price
+	var err error
+
+	params := &stripe.PriceParams{}
+	params.AddExpand("currency_options")
+	setStripeAccount(d, params)
+
+	// archived prices are missing from the matrix, the next apply creates them again
+	found := map[string]*stripe.Price{}
+	for key, id := range ToMap(d.Get("prices")) {
+		err = c.retryWithBackOff(ctx, params, func() error {
+			price, err = c.Prices.Get(ToString(id), params)
+			return err
+		})
+		switch {
+		case isNotFoundErr(err):
+			continue
+		case err != nil:
+			return diag.FromErr(err)
+		}
+		if price.Active {
+			found[key] = price
+		}
+	}
+	if len(found) == 0 {
+		d.SetId("") // remove when none of the prices exists
+		return nil
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// the order of the configuration is kept, imported matrices are sorted
+	intervals := ToStringSlice(d.Get("intervals"))
+	if len(intervals) == 0 {
+		for _, interval := range pricingMatrixIntervals {
+			for _, key := range keys {
+				if strings.HasPrefix(key, interval+"/") {
+					intervals = append(intervals, interval)
+					break
+				}
+			}
+		}
+	}
+	var currencies []string
+	optionCurrencies := map[string][]string{}
+	for _, amount := range ToMapSlice(d.Get("amount")) {
+		currency := ToString(amount["currency"])
+		currencies = append(currencies, currency)
+		for _, option := range ToMapSlice(amount["currency_options"]) {
+			optionCurrencies[currency] = append(optionCurrencies[currency], ToString(option["currency"]))
+		}
+	}
+	if len(currencies) == 0 {
+		for _, key := range keys {
+			currency := string(found[key].Currency)
+			if !containsString(currencies, currency) {
+				currencies = append(currencies, currency)
+			}
+			// the currency options of the prices include their own currency
+			for optionCurrency := range found[key].CurrencyOptions {
+				if optionCurrency != currency && !containsString(optionCurrencies[currency], optionCurrency) {
+					optionCurrencies[currency] = append(optionCurrencies[currency], optionCurrency)
+				}
+			}
+		}
+		sort.Strings(currencies)
+		for _, options := range optionCurrencies {
+			sort.Strings(options)
+		}
+	}
+
+	var amounts []map[string]interface{}
+	for _, currency := range currencies {
+		unitAmounts := map[string]interface{}{}
+		for _, interval := range intervals {
+			if price, ok := found[pricingMatrixKey(interval, currency)]; ok {
+				unitAmounts[interval] = price.UnitAmount
+			}
+		}
+
+		var currencyOptions []map[string]interface{}
+		for _, optionCurrency := range optionCurrencies[currency] {
+			optionAmounts := map[string]interface{}{}
+			var taxBehavior string
+			for _, interval := range intervals {
+				price, ok := found[pricingMatrixKey(interval, currency)]
+				if !ok {
+					continue
+				}
+				if option, ok := price.CurrencyOptions[optionCurrency]; ok {
+					optionAmounts[interval] = option.UnitAmount
+					taxBehavior = string(option.TaxBehavior)
+				}
+			}
+			currencyOptions = append(currencyOptions, map[string]interface{}{
+				"currency":     optionCurrency,
+				"unit_amounts": optionAmounts,
+				"tax_behavior": taxBehavior,
+			})
+		}
+
+		amounts = append(amounts, map[string]interface{}{
+			"currency":         currency,
+			"unit_amounts":     unitAmounts,
+			"currency_options": currencyOptions,
+		})
+	}
+
+	prices := map[string]interface{}{}
+	for _, key := range keys {
+		prices[key] = found[key].ID
+	}
+
+	return CallSet(
+		d.Set("product", found[keys[0]].Product.ID),
+		d.Set("intervals", intervals),
+		d.Set("amount", amounts),
+		d.Set("tax_behavior", found[keys[0]].TaxBehavior),
+		d.Set("metadata", found[keys[0]].Metadata),
+		d.Set("prices", prices),
+	)
+}
+
+func resourceStripePricingMatrixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+
+	cells := expandPricingMatrix(ExtractString(d, "product"), d.Get("intervals"), d.Get("amount"),
+		ExtractString(d, "tax_behavior"))
+	keys := make([]string, 0, len(cells))
+	for key := range cells {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	prices := map[string]interface{}{}
+	for _, key := range keys {
+		id, err := createPricingMatrixPrice(ctx, d, c, cells[key])
+		if err != nil {
+			// a failed apply doesn't leave prices behind, the prices created so far are archived
+			dg := diag.FromErr(err)
+			for _, created := range prices {
+				if err = archivePrice(ctx, d, c, ToString(created)); err != nil {
+					dg = append(dg, diag.FromErr(err)...)
+				}
+			}
+			return dg
+		}
+		prices[key] = id
+	}
+
+	d.SetId(ExtractString(d, "product"))
+	if dg := CallSet(d.Set("prices", prices)); len(dg) > 0 {
+		return dg
+	}
+	return resourceStripePricingMatrixRead(ctx, d, m)
+}
+
+// createPricingMatrixPrice creates a price of the matrix.
+func createPricingMatrixPrice(ctx context.Context, d *schema.ResourceData, c *stripeClient,
+	cell *stripe.PriceParams) (string, error) {
+	var price *stripe.Price
+	var err error
+
+	params := &stripe.PriceParams{}
+	*params = *cell
+	for k, v := range ToMap(d.Get("metadata")) {
+		params.AddMetadata(k, ToString(v))
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		price, err = c.Prices.New(params)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return price.ID, nil
+}
+
+func resourceStripePricingMatrixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	product := ExtractString(d, "product")
+	oldIntervals, newIntervals := d.GetChange("intervals")
+	oldAmounts, newAmounts := d.GetChange("amount")
+	oldTaxBehavior, newTaxBehavior := d.GetChange("tax_behavior")
+	oldPrices, _ := d.GetChange("prices")
+
+	oldCells := expandPricingMatrix(product, oldIntervals, oldAmounts, ToString(oldTaxBehavior))
+	newCells := expandPricingMatrix(product, newIntervals, newAmounts, ToString(newTaxBehavior))
+
+	keys := make([]string, 0, len(newCells))
+	for key := range newCells {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// prices are immutable, a changed amount creates a new price, the previous one is archived whatever on_destroy says
+	prices := ToMap(oldPrices)
+	var kept []string
+	for _, key := range keys {
+		previousID, exists := prices[key]
+		if exists && reflect.DeepEqual(oldCells[key], newCells[key]) {
+			kept = append(kept, key)
+			continue
+		}
+
+		id, err := createPricingMatrixPrice(ctx, d, c, newCells[key])
+		if err != nil {
+			return append(CallSet(d.Set("prices", prices)), diag.FromErr(err)...)
+		}
+		prices[key] = id
+		if exists {
+			if err = archivePrice(ctx, d, c, ToString(previousID)); err != nil {
+				return append(CallSet(d.Set("prices", prices)), diag.FromErr(err)...)
+			}
+		}
+	}
+
+	// prices of removed intervals and currencies
+	for key, id := range ToMap(oldPrices) {
+		if _, ok := newCells[key]; ok {
+			continue
+		}
+		if err = archivePrice(ctx, d, c, ToString(id)); err != nil {
+			return append(CallSet(d.Set("prices", prices)), diag.FromErr(err)...)
+		}
+		delete(prices, key)
+	}
+
+	if d.HasChange("metadata") {
+		for _, key := range kept {
+			params := &stripe.PriceParams{}
+			UpdateMetadata(d, params)
+			setStripeAccount(d, params)
+
+			err = c.retryWithBackOff(ctx, params, func() error {
+				_, err = c.Prices.Update(ToString(prices[key]), params)
+				return err
+			})
+			if err != nil {
+				return append(CallSet(d.Set("prices", prices)), diag.FromErr(err)...)
+			}
+		}
+	}
+
+	if dg := CallSet(d.Set("prices", prices)); len(dg) > 0 {
+		return dg
+	}
+	return resourceStripePricingMatrixRead(ctx, d, m)
+}
+
+func resourceStripePricingMatrixDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "on_destroy") == onDestroyAbandon {
+		tflog.Warn(ctx, "[WARN] Prices of the pricing matrix are abandoned, they're only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	for _, id := range ToMap(d.Get("prices")) {
+		if err := archivePrice(ctx, d, c, ToString(id)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importStripePricingMatrix imports the active recurring prices of the product identified by the import ID,
+// a product with several prices of the same interval and currency can't be imported.
+func importStripePricingMatrix(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importStripeAccountPassthrough(ctx, d, m); err != nil {
+		return nil, err
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.PriceListParams{
+		Product: stripe.String(d.Id()),
+		Active:  stripe.Bool(true),
+		Type:    stripe.String(string(stripe.PriceTypeRecurring)),
+	}
+	setStripeAccount(d, params)
+
+	prices := map[string]interface{}{}
+	err = c.retryWithBackOff(ctx, params, func() error {
+		prices = map[string]interface{}{}
+		i := c.Prices.List(params)
+		for i.Next() {
+			price := i.Price()
+			// only flat prices per unit and single interval are part of a matrix
+			if price.Product == nil || price.Product.ID != d.Id() || price.Recurring == nil ||
+				price.Recurring.IntervalCount > 1 || price.Recurring.UsageType == stripe.PriceRecurringUsageTypeMetered ||
+				price.BillingScheme == stripe.PriceBillingSchemeTiered || price.CustomUnitAmount != nil {
+				continue
+			}
+			key := pricingMatrixKey(string(price.Recurring.Interval), string(price.Currency))
+			if previous, ok := prices[key]; ok {
+				return fmt.Errorf("product %s has several active prices for %s (%s and %s)",
+					d.Id(), key, previous, price.ID)
+			}
+			prices[key] = price.ID
+		}
+		return i.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("product %s has no active recurring prices", d.Id())
+	}
+
+	if err = d.Set("prices", prices); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
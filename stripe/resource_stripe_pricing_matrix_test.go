@@ -0,0 +1,248 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripePricingMatrix(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_pricing_matrix",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"product":   "prod_standin",
+				"intervals": []interface{}{"month", "year"},
+				"amount": []interface{}{
+					map[string]interface{}{
+						"currency":     "usd",
+						"unit_amounts": map[string]interface{}{"month": 1000, "year": 10000},
+						"currency_options": []interface{}{
+							map[string]interface{}{
+								"currency":     "eur",
+								"unit_amounts": map[string]interface{}{"month": 900, "year": 9000},
+							},
+						},
+					},
+					map[string]interface{}{
+						"currency":     "gbp",
+						"unit_amounts": map[string]interface{}{"month": 800, "year": 8000},
+					},
+				},
+				"metadata": map[string]interface{}{"plan": "gold"},
+			},
+			checks: map[string]string{
+				"id":                          "prod_standin",
+				"prices.%":                    "4",
+				"amount.0.unit_amounts.month": "1000",
+				"amount.0.currency_options.0.unit_amounts.year": "9000",
+				"amount.1.unit_amounts.year":                    "8000",
+				"tax_behavior":                                  "unspecified",
+				"metadata.plan":                                 "gold",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"product":   "prod_standin",
+				"intervals": []interface{}{"month", "year"},
+				"amount": []interface{}{
+					map[string]interface{}{
+						"currency":     "usd",
+						"unit_amounts": map[string]interface{}{"month": 1200, "year": 10000},
+						"currency_options": []interface{}{
+							map[string]interface{}{
+								"currency":     "eur",
+								"unit_amounts": map[string]interface{}{"month": 1100, "year": 9000},
+							},
+						},
+					},
+				},
+				"metadata": map[string]interface{}{"plan": "platinum"},
+			},
+			checks: map[string]string{
+				"prices.%":                    "2",
+				"amount.#":                    "1",
+				"amount.0.unit_amounts.month": "1200",
+				"amount.0.currency_options.0.unit_amounts.month": "1100",
+				"metadata.plan": "platinum",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"product":   "prod_other",
+				"intervals": []interface{}{"week"},
+				"amount": []interface{}{
+					map[string]interface{}{
+						"currency":     "usd",
+						"unit_amounts": map[string]interface{}{"week": 0},
+					},
+				},
+			},
+			checks: map[string]string{
+				"id":                         "prod_other",
+				"prices.%":                   "1",
+				"amount.0.unit_amounts.week": "0",
+			},
+		},
+		importIgnore: []string{"on_destroy"},
+		// the identifier is the product, the matrix is gone with its prices
+		remove: func(standIn *stripeStandIn, state *terraform.InstanceState) {
+			for k, id := range state.Attributes {
+				if strings.HasPrefix(k, "prices.") && k != "prices.%" {
+					standIn.remove(id)
+				}
+			}
+		},
+	})
+}
+
+func TestAccStripePricingMatrixReplacement(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := func(amounts ...interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_pricing_matrix", "test", map[string]interface{}{
+			"product":   "prod_standin",
+			"intervals": []interface{}{"month", "year"},
+			"amount":    amounts,
+		}))
+	}
+	usd := map[string]interface{}{
+		"currency":     "usd",
+		"unit_amounts": map[string]interface{}{"month": 1200, "year": 10000},
+	}
+
+	first, second := &terraform.InstanceState{}, &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(
+					map[string]interface{}{
+						"currency":     "usd",
+						"unit_amounts": map[string]interface{}{"month": 1000, "year": 10000},
+					},
+					map[string]interface{}{
+						"currency":     "eur",
+						"unit_amounts": map[string]interface{}{"month": 900, "year": 9000},
+					},
+				),
+				Check: testAccKeepState("stripe_pricing_matrix.test", first),
+			},
+			{
+				// a changed amount replaces its price only, a removed currency archives its prices
+				Config: config(usd),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKeepState("stripe_pricing_matrix.test", second),
+					func(*terraform.State) error {
+						if second.Attributes["prices.month/usd"] == first.Attributes["prices.month/usd"] {
+							return fmt.Errorf("expected a new price for month/usd, got %s again",
+								first.Attributes["prices.month/usd"])
+						}
+						if second.Attributes["prices.year/usd"] != first.Attributes["prices.year/usd"] {
+							return fmt.Errorf("expected price %s to be kept for year/usd, got %s",
+								first.Attributes["prices.year/usd"], second.Attributes["prices.year/usd"])
+						}
+						for _, key := range []string{"month/usd", "month/eur", "year/eur"} {
+							price, _ := standIn.object(first.Attributes["prices."+key])
+							if fmt.Sprint(price["active"]) != "false" {
+								return fmt.Errorf("expected the previous price of %s to be archived, got %v",
+									key, price["active"])
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// a price archived outside of Terraform is dropped on refresh
+				PreConfig: func() {
+					archived := second.Attributes["prices.year/usd"]
+					price, _ := standIn.object(archived)
+					price["active"] = false
+					standIn.load(archived, price)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: testAccCheckAttributes("stripe_pricing_matrix.test", map[string]string{
+					"prices.%":                   "1",
+					"amount.0.unit_amounts.%":    "1",
+					"amount.0.unit_amounts.year": "",
+				}),
+			},
+			{
+				// and created again
+				Config: config(usd),
+				Check: testAccCheckAttributes("stripe_pricing_matrix.test", map[string]string{
+					"prices.%":                   "2",
+					"amount.0.unit_amounts.year": "10000",
+				}),
+			},
+		},
+	})
+}
+
+func TestAccStripePricingMatrixPartialCreate(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_pricing_matrix", "test", map[string]interface{}{
+		"product":   "prod_standin",
+		"intervals": []interface{}{"month", "year"},
+		"amount": []interface{}{
+			map[string]interface{}{
+				"currency":     "usd",
+				"unit_amounts": map[string]interface{}{"month": 1000, "year": 10000},
+			},
+			map[string]interface{}{
+				"currency":     "eur",
+				"unit_amounts": map[string]interface{}{"month": 900, "year": 9000},
+			},
+		},
+	}))
+	// activePrices checks how many prices of the stand-in are active, the archived ones aren't counted
+	activePrices := func(expected int) error {
+		var active []string
+		for _, id := range standIn.objectsOf("price") {
+			if price, _ := standIn.object(id); fmt.Sprint(price["active"]) != "false" {
+				active = append(active, id)
+			}
+		}
+		if len(active) != expected {
+			return fmt.Errorf("expected %d active prices, got %v", expected, active)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the third price fails, the two prices created before are archived
+				PreConfig:   func() { standIn.refuseCreates("price", 2) },
+				Config:      config,
+				ExpectError: regexp.MustCompile("the creation of price objects is refused"),
+			},
+			{
+				PreConfig: func() {
+					if err := activePrices(0); err != nil {
+						t.Fatal(err)
+					}
+					if created := len(standIn.objectsOf("price")); created != 2 {
+						t.Fatalf("expected 2 prices created by the failed apply, got %d", created)
+					}
+					standIn.refuseCreates("price", -1)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_pricing_matrix.test", map[string]string{
+						"prices.%": "4",
+					}),
+					func(*terraform.State) error {
+						return activePrices(4)
+					},
+				),
+			},
+		},
+	})
+}
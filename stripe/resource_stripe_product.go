@@ -2,12 +2,13 @@ package stripe
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeProduct() *schema.Resource {
@@ -17,7 +18,7 @@ func resourceStripeProduct() *schema.Resource {
 		UpdateContext: resourceStripeProductUpdate,
 		DeleteContext: resourceStripeProductDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"product_id": {
@@ -27,6 +28,7 @@ func resourceStripeProduct() *schema.Resource {
 				ForceNew:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -50,6 +52,7 @@ func resourceStripeProduct() *schema.Resource {
 			"marketing_features": {
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 15,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Description: "A list of up to 15 marketing features for this product. " +
 					"These are displayed in pricing tables.",
@@ -57,14 +60,22 @@ func resourceStripeProduct() *schema.Resource {
 			"images": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				MaxItems: 8,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
 				Description: "A list of up to 8 URLs of images for this product, " +
 					"meant to be displayable to the customer.",
 			},
 			"package_dimensions": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeFloat},
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeFloat},
+				ValidateDiagFunc: validation.MapKeyMatch(
+					regexp.MustCompile(`^(height|length|weight|width)$`),
+					"must be one of height, length, weight or width",
+				),
 				Description: "The dimensions of this product for shipping purposes.",
 			},
 			"shippable": {
@@ -75,6 +86,10 @@ func resourceStripeProduct() *schema.Resource {
 			"statement_descriptor": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(5, 22),
+					validation.StringDoesNotContainAny(`<>\'"*`),
+				),
 				Description: "An arbitrary string to be displayed on your customer’s credit card or bank statement. " +
 					"While most banks display this information consistently, " +
 					"some may display it incorrectly or not at all. This may be up to 22 characters. " +
@@ -95,9 +110,10 @@ func resourceStripeProduct() *schema.Resource {
 					"When set, this will be included in associated invoice line item descriptions.",
 			},
 			"url": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "A URL of a publicly-accessible webpage for this product.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "A URL of a publicly-accessible webpage for this product.",
 			},
 			"metadata": {
 				Type:     schema.TypeMap,
@@ -110,13 +126,16 @@ func resourceStripeProduct() *schema.Resource {
 	}
 }
 
-func resourceStripeProductRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeProductRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var product *stripe.Product
 	var err error
 
-	err = retryWithBackOff(func() error {
-		product, err = c.Products.Get(d.Id(), nil)
+	params := &stripe.ProductParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		product, err = c.Products.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -169,7 +188,7 @@ func resourceStripeProductRead(_ context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var product *stripe.Product
 	var err error
 
@@ -233,7 +252,10 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		product, err = c.Products.New(params)
 		return err
 	})
@@ -251,7 +273,7 @@ func resourceStripeProductCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.ProductParams{}
@@ -311,7 +333,8 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.Products.Update(d.Id(), params)
 		return err
 	})
@@ -325,11 +348,14 @@ func resourceStripeProductUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceStripeProductDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Warn(ctx, "[WARN] Deleting a product is only possible if it has no prices associated with it.")
 
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
-	err = retryWithBackOff(func() error {
-		_, err = c.Products.Del(d.Id(), nil)
+	params := &stripe.ProductParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Products.Del(d.Id(), params)
 		if err != nil {
 			stripeErr := toStripeError(err)
 			/*
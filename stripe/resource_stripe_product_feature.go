@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeProductFeature() *schema.Resource {
@@ -15,7 +14,7 @@ func resourceStripeProductFeature() *schema.Resource {
 		CreateContext: resourceStripeProductFeatureCreate,
 		DeleteContext: resourceStripeProductFeatureDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -23,6 +22,7 @@ func resourceStripeProductFeature() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"entitlements_feature": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -50,15 +50,18 @@ func resourceStripeProductFeature() *schema.Resource {
 	}
 }
 
-func resourceStripeProductFeatureRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeProductFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var productFeature *stripe.ProductFeature
 	var err error
 
-	productID := stripe.String(ExtractString(d, "product"))
+	params := &stripe.ProductFeatureParams{
+		Product: stripe.String(ExtractString(d, "product")),
+	}
+	setStripeAccount(d, params)
 
-	err = retryWithBackOff(func() error {
-		productFeature, err = c.ProductFeatures.Get(d.Id(), &stripe.ProductFeatureParams{Product: productID})
+	err = c.retryWithBackOff(ctx, params, func() error {
+		productFeature, err = c.ProductFeatures.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -71,14 +74,14 @@ func resourceStripeProductFeatureRead(_ context.Context, d *schema.ResourceData,
 
 	return CallSet(
 		d.Set("entitlements_feature", productFeature.EntitlementFeature.ID),
-		d.Set("product", productID),
+		d.Set("product", params.Product),
 		d.Set("object", productFeature.Object),
 		d.Set("livemode", productFeature.Livemode),
 	)
 }
 
 func resourceStripeProductFeatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var productFeature *stripe.ProductFeature
 	var err error
 
@@ -87,7 +90,10 @@ func resourceStripeProductFeatureCreate(ctx context.Context, d *schema.ResourceD
 		Product:            stripe.String(ExtractString(d, "product")),
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		productFeature, err = c.ProductFeatures.New(params)
 		return err
 	})
@@ -98,14 +104,17 @@ func resourceStripeProductFeatureCreate(ctx context.Context, d *schema.ResourceD
 	return resourceStripeProductFeatureRead(ctx, d, m)
 }
 
-func resourceStripeProductFeatureDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeProductFeatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var err error
 
-	productID := stripe.String(ExtractString(d, "product"))
+	params := &stripe.ProductFeatureParams{
+		Product: stripe.String(ExtractString(d, "product")),
+	}
+	setStripeAccount(d, params)
 
-	err = retryWithBackOff(func() error {
-		_, err = c.ProductFeatures.Del(d.Id(), &stripe.ProductFeatureParams{Product: productID})
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.ProductFeatures.Del(d.Id(), params)
 		return err
 	})
 	if err != nil {
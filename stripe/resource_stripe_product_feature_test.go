@@ -0,0 +1,29 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeProductFeature(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_product_feature",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"product":              "prod_standin",
+				"entitlements_feature": "feat_sso",
+			},
+			checks: map[string]string{
+				"product":              "prod_standin",
+				"entitlements_feature": "feat_sso",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"product":              "prod_standin",
+				"entitlements_feature": "feat_audit_log",
+			},
+			checks: map[string]string{
+				"entitlements_feature": "feat_audit_log",
+			},
+		},
+		skipImport: "the feature is read through its product, which isn't known from the identifier",
+	})
+}
@@ -0,0 +1,88 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeProduct(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_product",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":                 "Gold plan",
+				"description":          "Everything included",
+				"marketing_features":   []interface{}{"Unlimited seats", "Priority support"},
+				"statement_descriptor": "GOLD PLAN",
+				"unit_label":           "seat",
+				"metadata":             map[string]interface{}{"tier": "gold"},
+			},
+			checks: map[string]string{
+				"name":                 "Gold plan",
+				"active":               "true",
+				"marketing_features.#": "2",
+				"marketing_features.1": "Priority support",
+				"metadata.tier":        "gold",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":                 "Gold plan",
+				"active":               false,
+				"description":          "Everything included, forever",
+				"marketing_features":   []interface{}{"Unlimited seats"},
+				"statement_descriptor": "GOLD PLAN",
+				"unit_label":           "seat",
+				"metadata":             map[string]interface{}{"tier": "gold"},
+			},
+			checks: map[string]string{
+				"active":               "false",
+				"description":          "Everything included, forever",
+				"marketing_features.#": "1",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"product_id": "prod_gold",
+				"name":       "Gold plan",
+			},
+			checks: map[string]string{
+				"id":         "prod_gold",
+				"product_id": "prod_gold",
+			},
+		},
+	})
+}
+
+func TestAccStripeProductConnectedAccount(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_product",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"stripe_account": "acct_connected1",
+				"name":           "Gold plan",
+			},
+			checks: map[string]string{
+				"stripe_account": "acct_connected1",
+				"name":           "Gold plan",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"stripe_account": "acct_connected1",
+				"name":           "Gold plan",
+				"description":    "Everything included",
+			},
+			checks: map[string]string{
+				"description": "Everything included",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"stripe_account": "acct_connected2",
+				"name":           "Gold plan",
+				"description":    "Everything included",
+			},
+			checks: map[string]string{
+				"stripe_account": "acct_connected2",
+			},
+		},
+	})
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripePromotionCode() *schema.Resource {
@@ -17,8 +17,9 @@ func resourceStripePromotionCode() *schema.Resource {
 		CreateContext: resourceStripePromotionCodeCreate,
 		UpdateContext: resourceStripePromotionCodeUpdate,
 		DeleteContext: resourceStripePromotionCodeDelete,
+		CustomizeDiff: resourceStripePromotionCodeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -26,6 +27,7 @@ func resourceStripePromotionCode() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"coupon": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -54,17 +56,19 @@ func resourceStripePromotionCode() *schema.Resource {
 					"If not set, the promotion code can be used by all customers.",
 			},
 			"max_redemptions": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 				Description: "A positive integer specifying the number of times the promotion code can be redeemed. " +
 					"If the coupon has specified a max_redemptions, " +
 					"then this value cannot be greater than the coupon’s max_redemptions.",
 			},
 			"expires_at": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateTimestamp,
 				Description: "The timestamp at which this promotion code will expire. " +
 					"If the coupon has specified a redeems_by, " +
 					"then this value cannot be after the coupon’s redeems_by. Expected format is RFC3339",
@@ -84,15 +88,17 @@ func resourceStripePromotionCode() *schema.Resource {
 								"redeemed for Customers without any successful payments or invoices",
 						},
 						"minimum_amount": {
-							Type:     schema.TypeInt,
-							Optional: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
 							Description: "Minimum amount required to redeem this Promotion Code into a Coupon " +
 								"(e.g., a purchase must be $100 or more to work).",
 						},
 						"minimum_amount_currency": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Three-letter ISO code for minimum_amount",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCurrency,
+							Description:  "Three-letter ISO code for minimum_amount",
 						},
 					},
 				},
@@ -109,7 +115,7 @@ func resourceStripePromotionCode() *schema.Resource {
 }
 
 func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var promotionCode *stripe.PromotionCode
 	var err error
 
@@ -163,7 +169,10 @@ func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		promotionCode, err = c.PromotionCodes.New(params)
 		return err
 	})
@@ -175,13 +184,35 @@ func resourceStripePromotionCodeCreate(ctx context.Context, d *schema.ResourceDa
 	return resourceStripePromotionCodeRead(ctx, d, m)
 }
 
-func resourceStripePromotionCodeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+// resourceStripePromotionCodeCustomizeDiff checks the minimum amount restriction is given together with its currency.
+func resourceStripePromotionCodeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !diffKnown(d, "restrictions") {
+		return nil
+	}
+
+	restrictions := ToMap(d.Get("restrictions"))
+	minimumAmount := ToInt(restrictions["minimum_amount"])
+	minimumAmountCurrency := ToString(restrictions["minimum_amount_currency"])
+	switch {
+	case minimumAmount != 0 && minimumAmountCurrency == "":
+		return diffError("restrictions.0.minimum_amount_currency", "required when minimum_amount is set")
+	case minimumAmount == 0 && minimumAmountCurrency != "":
+		return diffError("restrictions.0.minimum_amount_currency", "only allowed when minimum_amount is set")
+	}
+
+	return nil
+}
+
+func resourceStripePromotionCodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var promotionCode *stripe.PromotionCode
 	var err error
 
-	err = retryWithBackOff(func() error {
-		promotionCode, err = c.PromotionCodes.Get(d.Id(), nil)
+	params := &stripe.PromotionCodeParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		promotionCode, err = c.PromotionCodes.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -242,7 +273,7 @@ func resourceStripePromotionCodeRead(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceStripePromotionCodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.PromotionCodeParams{}
@@ -254,7 +285,8 @@ func resourceStripePromotionCodeUpdate(ctx context.Context, d *schema.ResourceDa
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.PromotionCodes.Update(d.Id(), params)
 		return err
 	})
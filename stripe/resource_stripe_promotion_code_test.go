@@ -0,0 +1,86 @@
+package stripe
+
+import "testing"
+
+func TestAccStripePromotionCode(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_promotion_code",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"coupon":          "co_standin",
+				"code":            "SUMMER",
+				"max_redemptions": 100,
+				"restrictions": []interface{}{
+					map[string]interface{}{
+						"first_time_transaction":  true,
+						"minimum_amount":          1000,
+						"minimum_amount_currency": "usd",
+					},
+				},
+				"metadata": map[string]interface{}{"campaign": "summer"},
+			},
+			checks: map[string]string{
+				"code":                          "SUMMER",
+				"active":                        "true",
+				"max_redemptions":               "100",
+				"restrictions.0.minimum_amount": "1000",
+				"metadata.campaign":             "summer",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"coupon":          "co_standin",
+				"code":            "SUMMER",
+				"max_redemptions": 100,
+				"active":          false,
+				"restrictions": []interface{}{
+					map[string]interface{}{
+						"first_time_transaction":  true,
+						"minimum_amount":          1000,
+						"minimum_amount_currency": "usd",
+					},
+				},
+				"metadata": map[string]interface{}{"campaign": "summer", "expired": "true"},
+			},
+			checks: map[string]string{
+				"active":           "false",
+				"metadata.expired": "true",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"coupon": "co_standin",
+				"code":   "WINTER",
+			},
+			checks: map[string]string{
+				"code":   "WINTER",
+				"active": "true",
+			},
+		},
+	})
+}
+
+func TestAccStripePromotionCodePlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_promotion_code", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"coupon": "co_standin",
+				"restrictions": []interface{}{map[string]interface{}{
+					"first_time_transaction": false,
+					"minimum_amount":         1000,
+				}},
+			},
+			err: "restrictions.0.minimum_amount_currency: required when minimum_amount is set",
+		},
+		{
+			config: map[string]interface{}{
+				"coupon": "co_standin",
+				"restrictions": []interface{}{map[string]interface{}{
+					"first_time_transaction":  false,
+					"minimum_amount_currency": "usd",
+				}},
+			},
+			err: "restrictions.0.minimum_amount_currency: only allowed when minimum_amount is set",
+		},
+	})
+}
@@ -0,0 +1,589 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	onDestroyCancel            = "cancel"
+	onDestroyCancelAtPeriodEnd = "cancel_at_period_end"
+)
+
+func resourceStripeSubscription() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSubscriptionRead,
+		CreateContext: resourceStripeSubscriptionCreate,
+		UpdateContext: resourceStripeSubscriptionUpdate,
+		DeleteContext: resourceStripeSubscriptionDelete,
+		CustomizeDiff: resourceStripeSubscriptionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the customer to subscribe.",
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 20,
+				Description: "List of subscription items, each with an attached price. " +
+					"Items are matched by price, so changing the price of an item removes the old item " +
+					"and adds a new one. A price can be used by one item only.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier of the subscription item.",
+						},
+						"price": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the price object.",
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+							Description: "Quantity for this item. " +
+								"Must not be set for prices with usage_type=metered.",
+						},
+						"tax_rates": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "A list of tax rate ids. These tax rates will override " +
+								"the default_tax_rates on the subscription.",
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs that you can attach to the subscription item. " +
+								"This can be useful for storing additional information about the object in a structured format.",
+						},
+					},
+				},
+			},
+			"trial_end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateTimestampOrNow,
+				Description: "Timestamp representing the end of the trial period the customer will get " +
+					"before being charged for the first time. Expected format is RFC3339. " +
+					"The special value now can be provided to end the customer's trial immediately.",
+			},
+			"cancel_at_period_end": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Boolean indicating whether this subscription should cancel " +
+					"at the end of the current period.",
+			},
+			"proration_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"always_invoice",
+					"create_prorations",
+					"none",
+				}, false),
+				Description: "Determines how to handle prorations resulting from changes of the items " +
+					"or the trial end. One of always_invoice, create_prorations or none. " +
+					"Stripe defaults to create_prorations when not set.",
+			},
+			"payment_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"allow_incomplete",
+					"default_incomplete",
+					"error_if_incomplete",
+					"pending_if_incomplete",
+				}, false),
+				Description: "Determines how to handle payments that require customer action " +
+					"when creating or updating the subscription. One of allow_incomplete, default_incomplete, " +
+					"error_if_incomplete or pending_if_incomplete.",
+			},
+			"default_payment_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "ID of the default payment method for the subscription. " +
+					"It must belong to the customer associated with the subscription.",
+			},
+			"collection_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.SubscriptionCollectionMethodChargeAutomatically),
+					string(stripe.SubscriptionCollectionMethodSendInvoice),
+				}, false),
+				Description: "Either charge_automatically, or send_invoice. " +
+					"Defaults to charge_automatically.",
+			},
+			"days_until_due": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of days a customer has to pay invoices generated by this subscription. " +
+					"Valid only for subscriptions where collection_method is set to send_invoice.",
+			},
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  onDestroyCancel,
+				ValidateFunc: validation.StringInSlice([]string{
+					onDestroyCancel,
+					onDestroyCancelAtPeriodEnd,
+					onDestroyAbandon,
+				}, false),
+				Description: "What happens with the subscription when the resource is destroyed. " +
+					"Either cancel (the subscription is cancelled immediately), " +
+					"cancel_at_period_end (the subscription is cancelled at the end of the current period) " +
+					"or abandon (the subscription is only removed from the Terraform state). Defaults to cancel.",
+			},
+			"cancel_invoice_now": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Will generate a final invoice that invoices for any un-invoiced metered usage " +
+					"and new/pending proration invoice items when the subscription is cancelled on destroy.",
+			},
+			"cancel_prorate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Will generate a proration invoice item that credits remaining unused time " +
+					"until the subscription period end when the subscription is cancelled on destroy.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Possible values are incomplete, incomplete_expired, trialing, active, " +
+					"past_due, canceled, unpaid or paused.",
+			},
+			"current_period_start": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the current period that the subscription has been invoiced for.",
+			},
+			"current_period_end": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "End of the current period that the subscription has been invoiced for. " +
+					"At the end of this period, a new invoice will be created.",
+			},
+			"latest_invoice": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The most recent invoice this subscription has generated.",
+			},
+			"test_clock": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "ID of the test clock this subscription belongs to, " +
+					"subscriptions belong to the test clock of their customer.",
+			},
+		},
+	}
+}
+
+// resourceStripeSubscriptionCustomizeDiff checks every price is used by one item only, the items are matched by price,
+// and the invoice due days are only set for subscriptions sending invoices.
+func resourceStripeSubscriptionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	items := map[string]int{}
+	for i := range ToMapSlice(d.Get("items")) {
+		key := fmt.Sprintf("items.%d.price", i)
+		if !d.NewValueKnown(key) {
+			continue
+		}
+		price := ToString(d.Get(key))
+		if first, used := items[price]; used {
+			return diffError(key, "price %s is already used by items.%d, set the quantity of one item instead",
+				price, first)
+		}
+		items[price] = i
+	}
+
+	if !diffKnown(d, "collection_method", "days_until_due") || !d.HasChange("days_until_due") {
+		return nil
+	}
+
+	collectionMethod := ToString(d.Get("collection_method"))
+	if ToInt(d.Get("days_until_due")) != 0 && collectionMethod != string(stripe.SubscriptionCollectionMethodSendInvoice) {
+		return diffError("days_until_due", "only allowed when collection_method is send_invoice, got %q", collectionMethod)
+	}
+
+	return nil
+}
+
+func resourceStripeSubscriptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var subscription *stripe.Subscription
+	var items []*stripe.SubscriptionItem
+	var err error
+
+	params := &stripe.SubscriptionParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		subscription, err = c.Subscriptions.Get(d.Id(), params)
+		if err != nil {
+			return err
+		}
+
+		items = subscription.Items.Data
+		if subscription.Items.HasMore {
+			items = nil
+			listParams := &stripe.SubscriptionItemListParams{
+				Subscription: stripe.String(subscription.ID),
+			}
+			setStripeAccount(d, listParams)
+			setRequestContext(ctx, listParams)
+			i := c.SubscriptionItems.List(listParams)
+			for i.Next() {
+				items = append(items, i.SubscriptionItem())
+			}
+			return i.Err()
+		}
+		return nil
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	// cancelled subscriptions can't be reactivated, they are handled as removed
+	if subscription.Status == stripe.SubscriptionStatusCanceled ||
+		subscription.Status == stripe.SubscriptionStatusIncompleteExpired {
+		d.SetId("")
+		return nil
+	}
+
+	return CallSet(
+		d.Set("customer", subscription.Customer.ID),
+		d.Set("items", flattenSubscriptionItems(d, items)),
+		func() error {
+			// the trial_end = "now" has already been applied, keep the Terraform input
+			if ExtractString(d, "trial_end") == timestampNow {
+				return nil
+			}
+			var trialEnd string
+			if subscription.TrialEnd != 0 {
+				trialEnd = time.Unix(subscription.TrialEnd, 0).Format(time.RFC3339)
+			}
+			return d.Set("trial_end", trialEnd)
+		}(),
+		d.Set("cancel_at_period_end", subscription.CancelAtPeriodEnd),
+		func() error {
+			if subscription.DefaultPaymentMethod != nil {
+				return d.Set("default_payment_method", subscription.DefaultPaymentMethod.ID)
+			}
+			return d.Set("default_payment_method", "")
+		}(),
+		d.Set("collection_method", subscription.CollectionMethod),
+		d.Set("days_until_due", subscription.DaysUntilDue),
+		d.Set("metadata", subscription.Metadata),
+		d.Set("status", subscription.Status),
+		d.Set("current_period_start", time.Unix(subscription.CurrentPeriodStart, 0).Format(time.RFC3339)),
+		d.Set("current_period_end", time.Unix(subscription.CurrentPeriodEnd, 0).Format(time.RFC3339)),
+		func() error {
+			if subscription.LatestInvoice != nil {
+				return d.Set("latest_invoice", subscription.LatestInvoice.ID)
+			}
+			return d.Set("latest_invoice", "")
+		}(),
+		func() error {
+			if subscription.TestClock != nil {
+				return d.Set("test_clock", subscription.TestClock.ID)
+			}
+			return d.Set("test_clock", "")
+		}(),
+	)
+}
+
+// flattenSubscriptionItems keeps the order of the items known to Terraform,
+// Stripe doesn't guarantee any ordering of the subscription items.
+func flattenSubscriptionItems(d *schema.ResourceData, items []*stripe.SubscriptionItem) []map[string]interface{} {
+	position := map[string]int{}
+	for i, item := range ExtractMapSlice(d, "items") {
+		position[ToString(item["price"])] = i
+	}
+
+	ordered := make([]*stripe.SubscriptionItem, len(position))
+	var unknown []*stripe.SubscriptionItem
+	for _, item := range items {
+		if i, known := position[item.Price.ID]; known {
+			ordered[i] = item
+			continue
+		}
+		unknown = append(unknown, item)
+	}
+	ordered = append(ordered, unknown...)
+
+	var flattened []map[string]interface{}
+	for _, item := range ordered {
+		if item == nil {
+			continue // removed outside of Terraform
+		}
+		var taxRates []string
+		for _, taxRate := range item.TaxRates {
+			taxRates = append(taxRates, taxRate.ID)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":        item.ID,
+			"price":     item.Price.ID,
+			"quantity":  item.Quantity,
+			"tax_rates": taxRates,
+			"metadata":  item.Metadata,
+		})
+	}
+	return flattened
+}
+
+func resourceStripeSubscriptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var subscription *stripe.Subscription
+	var err error
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(ExtractString(d, "customer")),
+	}
+
+	for _, item := range ExtractMapSlice(d, "items") {
+		itemParams := &stripe.SubscriptionItemsParams{
+			Price:    stripe.String(ToString(item["price"])),
+			Quantity: NonZeroInt64(item["quantity"]),
+		}
+		if taxRates := ToStringSlice(item["tax_rates"]); len(taxRates) > 0 {
+			itemParams.TaxRates = stripe.StringSlice(taxRates)
+		}
+		for k, v := range ToMap(item["metadata"]) {
+			itemParams.AddMetadata(k, ToString(v))
+		}
+		params.Items = append(params.Items, itemParams)
+	}
+
+	if trialEnd, set := d.GetOk("trial_end"); set {
+		if err := setSubscriptionTrialEnd(params, ToString(trialEnd)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if cancelAtPeriodEnd, set := d.GetOk("cancel_at_period_end"); set {
+		params.CancelAtPeriodEnd = stripe.Bool(ToBool(cancelAtPeriodEnd))
+	}
+	if prorationBehavior, set := d.GetOk("proration_behavior"); set {
+		params.ProrationBehavior = stripe.String(ToString(prorationBehavior))
+	}
+	if paymentBehavior, set := d.GetOk("payment_behavior"); set {
+		params.PaymentBehavior = stripe.String(ToString(paymentBehavior))
+	}
+	if defaultPaymentMethod, set := d.GetOk("default_payment_method"); set {
+		params.DefaultPaymentMethod = stripe.String(ToString(defaultPaymentMethod))
+	}
+	if collectionMethod, set := d.GetOk("collection_method"); set {
+		params.CollectionMethod = stripe.String(ToString(collectionMethod))
+	}
+	if daysUntilDue, set := d.GetOk("days_until_due"); set {
+		params.DaysUntilDue = stripe.Int64(ToInt64(daysUntilDue))
+	}
+	if meta, set := d.GetOk("metadata"); set {
+		for k, v := range ToMap(meta) {
+			params.AddMetadata(k, ToString(v))
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		subscription, err = c.Subscriptions.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subscription.ID)
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.SubscriptionParams{}
+
+	if d.HasChange("items") {
+		params.Items = subscriptionItemsChanges(d)
+	}
+	if d.HasChange("trial_end") {
+		if err := setSubscriptionTrialEnd(params, ExtractString(d, "trial_end")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("cancel_at_period_end") {
+		params.CancelAtPeriodEnd = stripe.Bool(ExtractBool(d, "cancel_at_period_end"))
+	}
+	if d.HasChange("default_payment_method") {
+		params.DefaultPaymentMethod = stripe.String(ExtractString(d, "default_payment_method"))
+	}
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(ExtractString(d, "collection_method"))
+	}
+	if d.HasChange("days_until_due") {
+		params.DaysUntilDue = NonZeroInt64(ExtractInt64(d, "days_until_due"))
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	// proration and payment behaviour only apply to the changes above
+	if params.Items != nil || params.TrialEnd != nil || params.TrialEndNow != nil {
+		if prorationBehavior, set := d.GetOk("proration_behavior"); set {
+			params.ProrationBehavior = stripe.String(ToString(prorationBehavior))
+		}
+		if paymentBehavior, set := d.GetOk("payment_behavior"); set {
+			params.PaymentBehavior = stripe.String(ToString(paymentBehavior))
+		}
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.Subscriptions.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionRead(ctx, d, m)
+}
+
+// subscriptionItemsChanges diffs the items by price into additions, updates and deletions,
+// all of them are applied within one subscription update.
+func subscriptionItemsChanges(d *schema.ResourceData) []*stripe.SubscriptionItemsParams {
+	oldItems, newItems := d.GetChange("items")
+
+	existing := map[string]map[string]interface{}{}
+	for _, item := range ToMapSlice(oldItems) {
+		existing[ToString(item["price"])] = item
+	}
+
+	var changes []*stripe.SubscriptionItemsParams
+	for _, item := range ToMapSlice(newItems) {
+		price := ToString(item["price"])
+		itemParams := &stripe.SubscriptionItemsParams{
+			Quantity: NonZeroInt64(item["quantity"]),
+			TaxRates: stripe.StringSlice(ToStringSlice(item["tax_rates"])),
+		}
+
+		old, exists := existing[price]
+		if exists {
+			delete(existing, price)
+			itemParams.ID = stripe.String(ToString(old["id"]))
+			oldMeta := ToMap(old["metadata"])
+			for k := range oldMeta {
+				// when meta is empty string it's going be removed
+				itemParams.AddMetadata(k, "")
+			}
+		} else {
+			itemParams.Price = stripe.String(price)
+		}
+		for k, v := range ToMap(item["metadata"]) {
+			itemParams.AddMetadata(k, ToString(v))
+		}
+		changes = append(changes, itemParams)
+	}
+
+	for _, old := range existing {
+		changes = append(changes, &stripe.SubscriptionItemsParams{
+			ID:      stripe.String(ToString(old["id"])),
+			Deleted: stripe.Bool(true),
+		})
+	}
+
+	return changes
+}
+
+func setSubscriptionTrialEnd(params *stripe.SubscriptionParams, trialEnd string) error {
+	if trialEnd == timestampNow {
+		params.TrialEndNow = stripe.Bool(true)
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, trialEnd)
+	if err != nil {
+		return err
+	}
+	params.TrialEnd = stripe.Int64(t.Unix())
+	return nil
+}
+
+func resourceStripeSubscriptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	switch ExtractString(d, "on_destroy") {
+	case onDestroyAbandon:
+		tflog.Warn(ctx, "[WARN] Subscription is abandoned, it's only removed from the Terraform state")
+	case onDestroyCancelAtPeriodEnd:
+		params := &stripe.SubscriptionParams{
+			CancelAtPeriodEnd: stripe.Bool(true),
+		}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Subscriptions.Update(d.Id(), params)
+			return err
+		})
+	default:
+		params := &stripe.SubscriptionCancelParams{
+			InvoiceNow: stripe.Bool(ExtractBool(d, "cancel_invoice_now")),
+			Prorate:    stripe.Bool(ExtractBool(d, "cancel_prorate")),
+		}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.Subscriptions.Cancel(d.Id(), params)
+			return err
+		})
+	}
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
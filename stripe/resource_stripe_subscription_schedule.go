@@ -0,0 +1,579 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const onDestroyRelease = "release"
+
+func resourceStripeSubscriptionSchedule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeSubscriptionScheduleRead,
+		CreateContext: resourceStripeSubscriptionScheduleCreate,
+		UpdateContext: resourceStripeSubscriptionScheduleUpdate,
+		DeleteContext: resourceStripeSubscriptionScheduleDelete,
+		CustomizeDiff: resourceStripeSubscriptionScheduleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"customer": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"customer", "from_subscription"},
+				Description:  "The identifier of the customer to create the subscription schedule for.",
+			},
+			"from_subscription": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "Migrate an existing subscription to be managed by a subscription schedule. " +
+					"The first phase of the schedule mirrors the current state of the subscription.",
+			},
+			"start_date": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"from_subscription"},
+				ValidateFunc:  validateTimestampOrNow,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					// now is resolved to the real start date once the schedule is created
+					return new == timestampNow && old != ""
+				},
+				Description: "When the subscription schedule starts. Expected format is RFC3339 " +
+					"or the special value now. Defaults to now.",
+			},
+			"end_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.SubscriptionScheduleEndBehaviorRelease),
+					string(stripe.SubscriptionScheduleEndBehaviorCancel),
+					string(stripe.SubscriptionScheduleEndBehaviorNone),
+					string(stripe.SubscriptionScheduleEndBehaviorRenew),
+				}, false),
+				Description: "Behavior of the subscription schedule and underlying subscription when it ends. " +
+					"Possible values are release or cancel with the default being release. " +
+					"release will end the subscription schedule and keep the underlying subscription running. " +
+					"cancel will end the subscription schedule and cancel the underlying subscription.",
+			},
+			"phases": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Description: "List representing phases of the subscription schedule. " +
+					"Each phase can be customized to have different durations, prices, and coupons.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"items": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							MaxItems:    20,
+							Description: "List of configuration items, each with an attached price, to apply during this phase.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"price": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The ID of the price object.",
+									},
+									"quantity": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+										Description: "Quantity for the given price. " +
+											"Must not be set for prices with usage_type=metered.",
+									},
+									"tax_rates": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Description: "A list of tax rate ids. These tax rates will override " +
+											"the default_tax_rates on the subscription.",
+									},
+									"metadata": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Description: "Set of key-value pairs that you can attach to the item. " +
+											"They are set on the subscription item when the phase is entered.",
+									},
+								},
+							},
+						},
+						"iterations": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description: "Integer representing the multiplier applied to the price interval. " +
+								"For example, iterations=2 applied to a price with interval=month and interval_count=3 " +
+								"results in a phase of duration 2 * 3 months = 6 months. " +
+								"If set, end_date must not be set.",
+						},
+						"end_date": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateTimestamp,
+							Description: "The date at which this phase of the subscription schedule ends. " +
+								"Expected format is RFC3339. If set, iterations must not be set.",
+						},
+						"proration_behavior": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.SubscriptionSchedulePhaseProrationBehaviorAlwaysInvoice),
+								string(stripe.SubscriptionSchedulePhaseProrationBehaviorCreateProrations),
+								string(stripe.SubscriptionSchedulePhaseProrationBehaviorNone),
+							}, false),
+							Description: "Whether the subscription schedule will create prorations when transitioning " +
+								"to this phase. One of always_invoice, create_prorations or none. " +
+								"Defaults to create_prorations.",
+						},
+						"coupon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The identifier of the coupon to apply to this phase of the subscription schedule.",
+						},
+						"trial": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Description: "If set to true the entire phase is counted as a trial " +
+								"and the customer will not be charged for any fees.",
+						},
+						"trial_end": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateTimestamp,
+							Description: "Sets the phase to trialing from the start date to this date. " +
+								"Expected format is RFC3339. Must be before the phase end date, " +
+								"can not be combined with trial.",
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Set of key-value pairs that will be set on the subscription " +
+								"when the phase is entered.",
+						},
+						"start_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The start of this phase of the subscription schedule.",
+						},
+					},
+				},
+			},
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  onDestroyRelease,
+				ValidateFunc: validation.StringInSlice([]string{
+					onDestroyRelease,
+					onDestroyCancel,
+					onDestroyAbandon,
+				}, false),
+				Description: "What happens with the subscription schedule when the resource is destroyed. " +
+					"Either release (the schedule stops, the underlying subscription keeps running), " +
+					"cancel (the schedule and its subscription are cancelled immediately) " +
+					"or abandon (the schedule is only removed from the Terraform state). Defaults to release.",
+			},
+			"cancel_invoice_now": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Will generate a final invoice that invoices for any un-invoiced metered usage " +
+					"and new/pending proration invoice items when the schedule is cancelled on destroy.",
+			},
+			"cancel_prorate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Will generate a proration invoice item that credits remaining unused time " +
+					"until the subscription period end when the schedule is cancelled on destroy.",
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Set of key-value pairs that you can attach to an object. " +
+					"This can be useful for storing additional information about the object in a structured format.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The present status of the subscription schedule. " +
+					"Possible values are not_started, active, completed, released, and canceled.",
+			},
+			"subscription": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the subscription managed by the subscription schedule.",
+			},
+		},
+	}
+}
+
+// resourceStripeSubscriptionScheduleCustomizeDiff checks the exclusive settings of every phase.
+func resourceStripeSubscriptionScheduleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !diffKnown(d, "phases") {
+		return nil
+	}
+
+	phases := ToMapSlice(d.Get("phases"))
+	for i, phase := range phases {
+		path := fmt.Sprintf("phases.%d", i)
+		if ToInt(phase["iterations"]) != 0 && ToString(phase["end_date"]) != "" {
+			return diffError(path+".end_date", "can't be combined with iterations")
+		}
+		if ToBool(phase["trial"]) && ToString(phase["trial_end"]) != "" {
+			return diffError(path+".trial_end", "can't be combined with trial")
+		}
+		if i < len(phases)-1 && ToInt(phase["iterations"]) == 0 && ToString(phase["end_date"]) == "" {
+			return diffError(path, "either iterations or end_date is required for every phase except the last one")
+		}
+	}
+
+	return nil
+}
+
+func resourceStripeSubscriptionScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var schedule *stripe.SubscriptionSchedule
+	var err error
+
+	params := &stripe.SubscriptionScheduleParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		schedule, err = c.SubscriptionSchedules.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	// released and cancelled schedules don't manage their subscription anymore
+	if schedule.Status == stripe.SubscriptionScheduleStatusCanceled ||
+		schedule.Status == stripe.SubscriptionScheduleStatusReleased {
+		d.SetId("")
+		return nil
+	}
+
+	return CallSet(
+		d.Set("customer", schedule.Customer.ID),
+		func() error {
+			if ExtractString(d, "start_date") == timestampNow || len(schedule.Phases) == 0 {
+				return nil
+			}
+			return d.Set("start_date", time.Unix(schedule.Phases[0].StartDate, 0).Format(time.RFC3339))
+		}(),
+		d.Set("end_behavior", schedule.EndBehavior),
+		d.Set("phases", flattenSubscriptionSchedulePhases(d, schedule.Phases)),
+		d.Set("metadata", schedule.Metadata),
+		d.Set("status", schedule.Status),
+		func() error {
+			if schedule.Subscription != nil {
+				return d.Set("subscription", schedule.Subscription.ID)
+			}
+			return d.Set("subscription", "")
+		}(),
+	)
+}
+
+// flattenSubscriptionSchedulePhases keeps the Terraform input for the attributes
+// Stripe doesn't return (iterations) or resolves on its own (end_date and trial_end of unset phases).
+func flattenSubscriptionSchedulePhases(d *schema.ResourceData, phases []*stripe.SubscriptionSchedulePhase) []map[string]interface{} {
+	known := ExtractMapSlice(d, "phases")
+
+	var flattened []map[string]interface{}
+	for i, phase := range phases {
+		previous := map[string]interface{}{}
+		if i < len(known) {
+			previous = known[i]
+		}
+
+		var items []map[string]interface{}
+		for _, item := range phase.Items {
+			var taxRates []string
+			for _, taxRate := range item.TaxRates {
+				taxRates = append(taxRates, taxRate.ID)
+			}
+			items = append(items, map[string]interface{}{
+				"price":     item.Price.ID,
+				"quantity":  item.Quantity,
+				"tax_rates": taxRates,
+				"metadata":  item.Metadata,
+			})
+		}
+
+		f := map[string]interface{}{
+			"items":              items,
+			"iterations":         previous["iterations"],
+			"end_date":           keepTimestamp(ToString(previous["end_date"]), phase.EndDate),
+			"proration_behavior": phase.ProrationBehavior,
+			"trial":              previous["trial"],
+			"trial_end":          keepTimestamp(ToString(previous["trial_end"]), phase.TrialEnd),
+			"metadata":           phase.Metadata,
+			"start_date":         time.Unix(phase.StartDate, 0).Format(time.RFC3339),
+		}
+		if phase.Coupon != nil {
+			f["coupon"] = phase.Coupon.ID
+		}
+		flattened = append(flattened, f)
+	}
+	return flattened
+}
+
+// keepTimestamp returns the RFC3339 formatted value of the timestamp when the input is set,
+// the input itself is kept when it represents the same time.
+func keepTimestamp(input string, timestamp int64) string {
+	if input == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339, input); err == nil && t.Unix() == timestamp {
+		return input
+	}
+	return time.Unix(timestamp, 0).Format(time.RFC3339)
+}
+
+func resourceStripeSubscriptionScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var schedule *stripe.SubscriptionSchedule
+	var err error
+
+	params := &stripe.SubscriptionScheduleParams{}
+
+	if fromSubscription, set := d.GetOk("from_subscription"); set {
+		// Stripe doesn't allow any other parameter next to from_subscription,
+		// the remaining configuration is applied with a following update
+		params.FromSubscription = stripe.String(ToString(fromSubscription))
+	} else {
+		params.Customer = stripe.String(ExtractString(d, "customer"))
+
+		startDate := ExtractString(d, "start_date")
+		switch startDate {
+		case "", timestampNow:
+			params.StartDateNow = stripe.Bool(true)
+		default:
+			t, err := time.Parse(time.RFC3339, startDate)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			params.StartDate = stripe.Int64(t.Unix())
+		}
+
+		if endBehavior, set := d.GetOk("end_behavior"); set {
+			params.EndBehavior = stripe.String(ToString(endBehavior))
+		}
+		params.Phases, err = expandSubscriptionSchedulePhases(d, 0)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if meta, set := d.GetOk("metadata"); set {
+			for k, v := range ToMap(meta) {
+				params.AddMetadata(k, ToString(v))
+			}
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		schedule, err = c.SubscriptionSchedules.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schedule.ID)
+
+	if params.FromSubscription != nil {
+		updateParams := &stripe.SubscriptionScheduleParams{}
+		if endBehavior, set := d.GetOk("end_behavior"); set {
+			updateParams.EndBehavior = stripe.String(ToString(endBehavior))
+		}
+		if len(ExtractMapSlice(d, "phases")) > 0 && len(schedule.Phases) > 0 {
+			updateParams.Phases, err = expandSubscriptionSchedulePhases(d, schedule.Phases[0].StartDate)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if meta, set := d.GetOk("metadata"); set {
+			for k, v := range ToMap(meta) {
+				updateParams.AddMetadata(k, ToString(v))
+			}
+		}
+
+		setStripeAccount(d, updateParams)
+		err = c.retryWithBackOff(ctx, updateParams, func() error {
+			_, err = c.SubscriptionSchedules.Update(d.Id(), updateParams)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+func resourceStripeSubscriptionScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.SubscriptionScheduleParams{}
+
+	if d.HasChange("end_behavior") {
+		params.EndBehavior = stripe.String(ExtractString(d, "end_behavior"))
+	}
+	if d.HasChange("phases") {
+		var startDate int64
+		oldPhases, _ := d.GetChange("phases")
+		if phases := ToMapSlice(oldPhases); len(phases) > 0 {
+			// the first phase has to keep its start date
+			if t, err := time.Parse(time.RFC3339, ToString(phases[0]["start_date"])); err == nil {
+				startDate = t.Unix()
+			}
+		}
+		params.Phases, err = expandSubscriptionSchedulePhases(d, startDate)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("metadata") {
+		params.Metadata = nil
+		UpdateMetadata(d, params)
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.SubscriptionSchedules.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeSubscriptionScheduleRead(ctx, d, m)
+}
+
+// expandSubscriptionSchedulePhases builds the phases parameters,
+// the start date is set on the first phase only when it's given.
+func expandSubscriptionSchedulePhases(d *schema.ResourceData, startDate int64) ([]*stripe.SubscriptionSchedulePhaseParams, error) {
+	var phases []*stripe.SubscriptionSchedulePhaseParams
+	for i, phase := range ExtractMapSlice(d, "phases") {
+		phaseParams := &stripe.SubscriptionSchedulePhaseParams{}
+		if i == 0 && startDate != 0 {
+			phaseParams.StartDate = stripe.Int64(startDate)
+		}
+
+		for _, item := range ToMapSlice(phase["items"]) {
+			itemParams := &stripe.SubscriptionSchedulePhaseItemParams{
+				Price:    stripe.String(ToString(item["price"])),
+				Quantity: NonZeroInt64(item["quantity"]),
+			}
+			if taxRates := ToStringSlice(item["tax_rates"]); len(taxRates) > 0 {
+				itemParams.TaxRates = stripe.StringSlice(taxRates)
+			}
+			for k, v := range ToMap(item["metadata"]) {
+				itemParams.AddMetadata(k, ToString(v))
+			}
+			phaseParams.Items = append(phaseParams.Items, itemParams)
+		}
+
+		for k, v := range phase {
+			switch {
+			case k == "iterations":
+				phaseParams.Iterations = NonZeroInt64(v)
+			case k == "end_date" && ToString(v) != "":
+				t, err := time.Parse(time.RFC3339, ToString(v))
+				if err != nil {
+					return nil, err
+				}
+				phaseParams.EndDate = stripe.Int64(t.Unix())
+			case k == "proration_behavior":
+				phaseParams.ProrationBehavior = NonZeroString(v)
+			case k == "coupon":
+				phaseParams.Coupon = NonZeroString(v)
+			case k == "trial" && ToBool(v):
+				phaseParams.Trial = stripe.Bool(true)
+			case k == "trial_end" && ToString(v) != "":
+				t, err := time.Parse(time.RFC3339, ToString(v))
+				if err != nil {
+					return nil, err
+				}
+				phaseParams.TrialEnd = stripe.Int64(t.Unix())
+			case k == "metadata":
+				for mk, mv := range ToMap(v) {
+					phaseParams.AddMetadata(mk, ToString(mv))
+				}
+			}
+		}
+		phases = append(phases, phaseParams)
+	}
+	return phases, nil
+}
+
+func resourceStripeSubscriptionScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	status := stripe.SubscriptionScheduleStatus(ExtractString(d, "status"))
+	onDestroy := ExtractString(d, "on_destroy")
+
+	switch {
+	case onDestroy == onDestroyAbandon:
+		tflog.Warn(ctx, "[WARN] Subscription Schedule is abandoned, it's only removed from the Terraform state")
+	case status == stripe.SubscriptionScheduleStatusCompleted:
+		tflog.Warn(ctx, "[WARN] Subscription Schedule is completed, it's only removed from the Terraform state")
+	case onDestroy == onDestroyCancel:
+		params := &stripe.SubscriptionScheduleCancelParams{
+			InvoiceNow: stripe.Bool(ExtractBool(d, "cancel_invoice_now")),
+			Prorate:    stripe.Bool(ExtractBool(d, "cancel_prorate")),
+		}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.SubscriptionSchedules.Cancel(d.Id(), params)
+			return err
+		})
+	default:
+		params := &stripe.SubscriptionScheduleReleaseParams{}
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.SubscriptionSchedules.Release(d.Id(), params)
+			return err
+		})
+	}
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,257 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeSubscriptionSchedule(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_subscription_schedule",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer":     "cus_standin",
+				"end_behavior": "release",
+				"phases": []interface{}{
+					map[string]interface{}{
+						"items":      []interface{}{map[string]interface{}{"price": "price_2025", "quantity": 1}},
+						"iterations": 1,
+					},
+					map[string]interface{}{
+						"items":    []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+						"coupon":   "co_migration",
+						"metadata": map[string]interface{}{"phase": "repriced"},
+					},
+				},
+			},
+			checks: map[string]string{
+				"status":                    "active",
+				"phases.#":                  "2",
+				"phases.0.items.0.price":    "price_2025",
+				"phases.0.iterations":       "1",
+				"phases.1.items.0.price":    "price_2026",
+				"phases.1.coupon":           "co_migration",
+				"phases.1.metadata.phase":   "repriced",
+				"phases.0.items.0.quantity": "1",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":     "cus_standin",
+				"end_behavior": "cancel",
+				"phases": []interface{}{
+					map[string]interface{}{
+						"items":      []interface{}{map[string]interface{}{"price": "price_2025", "quantity": 1}},
+						"iterations": 2,
+					},
+					map[string]interface{}{
+						"items":    []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 3}},
+						"metadata": map[string]interface{}{"phase": "repriced"},
+					},
+				},
+			},
+			checks: map[string]string{
+				"end_behavior":              "cancel",
+				"phases.0.iterations":       "2",
+				"phases.1.items.0.quantity": "3",
+				"phases.1.coupon":           "",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_other",
+				"phases": []interface{}{
+					map[string]interface{}{
+						"items": []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+					},
+				},
+			},
+			checks: map[string]string{
+				"customer": "cus_other",
+				"phases.#": "1",
+			},
+		},
+		// Stripe resolves the iterations to the end date of the phase
+		importIgnore: []string{"on_destroy", "cancel_invoice_now", "cancel_prorate", "phases.0.iterations"},
+	})
+}
+
+func TestAccStripeSubscriptionSchedulePhases(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+	renewal := start.AddDate(0, 1, 0)
+
+	schedule := func(phases ...interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_subscription_schedule", "test",
+			map[string]interface{}{
+				"customer":   "cus_standin",
+				"start_date": start.Format(time.RFC3339),
+				"phases":     phases,
+			}))
+	}
+	current := map[string]interface{}{
+		"items":    []interface{}{map[string]interface{}{"price": "price_2025", "quantity": 1}},
+		"end_date": renewal.Format(time.RFC3339),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// every phase starts where the previous one ends
+				Config: schedule(current, map[string]interface{}{
+					"items":              []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+					"proration_behavior": "none",
+				}),
+				Check: testAccCheckAttributes("stripe_subscription_schedule.test", map[string]string{
+					"status":                      "not_started",
+					"subscription":                "",
+					"start_date":                  start.Format(time.RFC3339),
+					"phases.0.start_date":         start.Local().Format(time.RFC3339),
+					"phases.0.end_date":           renewal.Format(time.RFC3339),
+					"phases.1.start_date":         renewal.Local().Format(time.RFC3339),
+					"phases.1.proration_behavior": "none",
+				}),
+			},
+			{
+				// a phase is added at the end, the current phase keeps its start date
+				Config: schedule(current, map[string]interface{}{
+					"items":      []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+					"iterations": 12,
+				}, map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"price": "price_2027", "quantity": 1}},
+					"trial": true,
+				}),
+				Check: testAccCheckAttributes("stripe_subscription_schedule.test", map[string]string{
+					"phases.#":               "3",
+					"phases.0.start_date":    start.Local().Format(time.RFC3339),
+					"phases.0.end_date":      renewal.Format(time.RFC3339),
+					"phases.1.iterations":    "12",
+					"phases.1.end_date":      "",
+					"phases.2.start_date":    renewal.AddDate(0, 0, 360).Local().Format(time.RFC3339),
+					"phases.2.items.0.price": "price_2027",
+					"phases.2.trial":         "true",
+				}),
+			},
+			{
+				Config: schedule(map[string]interface{}{
+					"items":      []interface{}{map[string]interface{}{"price": "price_2025", "quantity": 1}},
+					"iterations": 1,
+					"end_date":   renewal.Format(time.RFC3339),
+				}, map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+				}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`phases.0.end_date: can't be combined with iterations`),
+			},
+			{
+				Config: schedule(map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"price": "price_2025", "quantity": 1}},
+				}, map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+				}),
+				PlanOnly: true,
+				ExpectError: regexp.MustCompile(
+					`phases.0: either iterations or end_date is required for every phase except the last one`),
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscriptionSchedulePlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_subscription_schedule", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"phases": []interface{}{
+					map[string]interface{}{
+						"items":     []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+						"trial":     true,
+						"trial_end": "2030-01-01T00:00:00Z",
+					},
+				},
+			},
+			err: "phases.0.trial_end: can't be combined with trial",
+		},
+	})
+}
+
+func TestAccStripeSubscriptionScheduleOnDestroy(t *testing.T) {
+	testCases := map[string]struct {
+		onDestroy string
+		// completed completes the schedule before it's destroyed.
+		completed bool
+		status    string
+	}{
+		"release": {
+			onDestroy: onDestroyRelease,
+			status:    "released",
+		},
+		"cancel": {
+			onDestroy: onDestroyCancel,
+			status:    "canceled",
+		},
+		"abandon": {
+			onDestroy: onDestroyAbandon,
+			status:    "active",
+		},
+		// a completed schedule can neither be released nor cancelled, it's only removed from the state
+		"completed": {
+			onDestroy: onDestroyCancel,
+			completed: true,
+			status:    "completed",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_subscription_schedule", "test",
+				map[string]interface{}{
+					"customer":   "cus_standin",
+					"on_destroy": tc.onDestroy,
+					"phases": []interface{}{
+						map[string]interface{}{
+							"items": []interface{}{map[string]interface{}{"price": "price_2026", "quantity": 1}},
+						},
+					},
+				}))
+
+			state := &terraform.InstanceState{}
+			steps := []resource.TestStep{{
+				Config: config,
+				Check:  testAccKeepState("stripe_subscription_schedule.test", state),
+			}}
+			if tc.completed {
+				steps = append(steps, resource.TestStep{
+					PreConfig: func() {
+						schedule, _ := standIn.object(state.ID)
+						schedule["status"] = "completed"
+						standIn.load(state.ID, schedule)
+					},
+					RefreshState: true,
+					Check: testAccCheckAttributes("stripe_subscription_schedule.test", map[string]string{
+						"status": "completed",
+					}),
+				})
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProviderFactories,
+				Steps:                    steps,
+				CheckDestroy: func(*terraform.State) error {
+					schedule, _ := standIn.object(state.ID)
+					if status := ToString(schedule["status"]); status != tc.status {
+						return fmt.Errorf("expected the destroyed schedule to be %s, got %s", tc.status, status)
+					}
+					return nil
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeSubscription(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_subscription",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_standin",
+				"items": []interface{}{
+					map[string]interface{}{"price": "price_seat", "quantity": 5},
+					map[string]interface{}{"price": "price_support"},
+				},
+				"metadata": map[string]interface{}{"plan": "team"},
+			},
+			checks: map[string]string{
+				"status":           "active",
+				"items.#":          "2",
+				"items.0.price":    "price_seat",
+				"items.0.quantity": "5",
+				"items.1.price":    "price_support",
+				"items.1.quantity": "1",
+				"metadata.plan":    "team",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"customer":             "cus_standin",
+				"cancel_at_period_end": true,
+				"items": []interface{}{
+					map[string]interface{}{"price": "price_seat", "quantity": 8},
+				},
+				"metadata": map[string]interface{}{"plan": "business"},
+			},
+			checks: map[string]string{
+				"cancel_at_period_end": "true",
+				"items.#":              "1",
+				"items.0.quantity":     "8",
+				"metadata.plan":        "business",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"customer": "cus_other",
+				"items": []interface{}{
+					map[string]interface{}{"price": "price_seat", "quantity": 1},
+				},
+			},
+			checks: map[string]string{
+				"customer": "cus_other",
+				"items.#":  "1",
+			},
+		},
+		importIgnore: []string{"on_destroy", "cancel_invoice_now", "cancel_prorate"},
+	})
+}
+
+func TestAccStripeSubscriptionItems(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	subscription := func(items ...interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_subscription", "test", map[string]interface{}{
+			"customer":           "cus_standin",
+			"proration_behavior": "none",
+			"items":              items,
+		}))
+	}
+	seat := map[string]interface{}{"price": "price_seat", "quantity": 5}
+
+	created := &terraform.InstanceState{}
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: subscription(
+					seat,
+					map[string]interface{}{"price": "price_support", "metadata": map[string]interface{}{"sla": "24h"}},
+				),
+				Check: testAccKeepState("stripe_subscription.test", created),
+			},
+			{
+				// the seat item is kept and updated, the support item is removed and the storage item added
+				Config: subscription(
+					map[string]interface{}{"price": "price_storage", "quantity": 2},
+					map[string]interface{}{"price": "price_seat", "quantity": 7, "tax_rates": []interface{}{"txr_vat"}},
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_subscription.test", map[string]string{
+						"items.#":             "2",
+						"items.0.price":       "price_storage",
+						"items.0.quantity":    "2",
+						"items.1.price":       "price_seat",
+						"items.1.quantity":    "7",
+						"items.1.tax_rates.0": "txr_vat",
+					}),
+					testAccCheckSubscriptionItem("stripe_subscription.test", 1, created, 0, true),
+					testAccCheckSubscriptionItem("stripe_subscription.test", 0, created, 1, false),
+					func(*terraform.State) error {
+						obj, _ := standIn.object(created.ID)
+						if items := ToMapSlice(obj["items"]); len(items) != 2 {
+							return fmt.Errorf("expected Stripe to keep 2 items, got %v", items)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// changing the price of an item replaces it
+				Config: subscription(map[string]interface{}{"price": "price_seat_v2", "quantity": 7}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttributes("stripe_subscription.test", map[string]string{
+						"items.#":       "1",
+						"items.0.price": "price_seat_v2",
+					}),
+					testAccCheckSubscriptionItem("stripe_subscription.test", 0, created, 0, false),
+				),
+			},
+			{
+				Config:      subscription(seat, map[string]interface{}{"price": "price_seat", "quantity": 1}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`items.1.price: price price_seat is already used by items.0`),
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscriptionPlanErrors(t *testing.T) {
+	testAccRunPlanErrors(t, "stripe_subscription", []testAccPlanError{
+		{
+			config: map[string]interface{}{
+				"customer":          "cus_standin",
+				"collection_method": "charge_automatically",
+				"days_until_due":    30,
+				"items":             []interface{}{map[string]interface{}{"price": "price_seat"}},
+			},
+			err: `days_until_due: only allowed when collection_method is send_invoice, got "charge_automatically"`,
+		},
+	})
+}
+
+// testAccCheckSubscriptionItem checks whether the item at index is the item at the index of the given state.
+func testAccCheckSubscriptionItem(address string, index int, state *terraform.InstanceState, stateIndex int,
+	same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		primary, err := testAccPrimary(s, address)
+		if err != nil {
+			return err
+		}
+		id := primary.Attributes[fmt.Sprintf("items.%d.id", index)]
+		previous := state.Attributes[fmt.Sprintf("items.%d.id", stateIndex)]
+		if (id == previous) != same {
+			return fmt.Errorf("expected item %s to be kept %t, previous item %s", id, same, previous)
+		}
+		return nil
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeTaxRate() *schema.Resource {
@@ -17,7 +17,7 @@ func resourceStripeTaxRate() *schema.Resource {
 		UpdateContext: resourceStripeTaxRateUpdate,
 		DeleteContext: resourceStripeTaxRateDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeAccountPassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -25,6 +25,7 @@ func resourceStripeTaxRate() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"display_name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -36,9 +37,10 @@ func resourceStripeTaxRate() *schema.Resource {
 				Description: "This specifies if the tax rate is inclusive or exclusive.",
 			},
 			"percentage": {
-				Type:        schema.TypeFloat,
-				Required:    true,
-				Description: "This represents the tax rate percent out of 100.",
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validation.FloatBetween(0, 100),
+				Description:  "This represents the tax rate percent out of 100.",
 			},
 			"active": {
 				Type:     schema.TypeBool,
@@ -49,9 +51,10 @@ func resourceStripeTaxRate() *schema.Resource {
 					"but will still work for subscriptions and invoices that already have it set.",
 			},
 			"country": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Two-letter country code (ISO 3166-1 alpha-2).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCountry,
+				Description:  "Two-letter country code (ISO 3166-1 alpha-2).",
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -84,6 +87,20 @@ func resourceStripeTaxRate() *schema.Resource {
 			"tax_type": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(stripe.TaxRateTaxTypeAmusementTax),
+					string(stripe.TaxRateTaxTypeCommunicationsTax),
+					string(stripe.TaxRateTaxTypeGST),
+					string(stripe.TaxRateTaxTypeHST),
+					string(stripe.TaxRateTaxTypeIGST),
+					string(stripe.TaxRateTaxTypeJCT),
+					string(stripe.TaxRateTaxTypeLeaseTax),
+					string(stripe.TaxRateTaxTypePST),
+					string(stripe.TaxRateTaxTypeQST),
+					string(stripe.TaxRateTaxTypeRST),
+					string(stripe.TaxRateTaxTypeSalesTax),
+					string(stripe.TaxRateTaxTypeVAT),
+				}, false),
 				Description: "The high-level tax type, " +
 					"such as vat or sales_tax.",
 			},
@@ -108,13 +125,16 @@ func resourceStripeTaxRate() *schema.Resource {
 	}
 }
 
-func resourceStripeTaxRateRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeTaxRateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var taxRate *stripe.TaxRate
 	var err error
 
-	err = retryWithBackOff(func() error {
-		taxRate, err = c.TaxRates.Get(d.Id(), nil)
+	params := &stripe.TaxRateParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		taxRate, err = c.TaxRates.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -143,7 +163,7 @@ func resourceStripeTaxRateRead(_ context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var taxRate *stripe.TaxRate
 	var err error
 
@@ -183,7 +203,10 @@ func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m
 		params.TaxType = stripe.String(ToString(taxType))
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		taxRate, err = c.TaxRates.New(params)
 		return err
 	})
@@ -196,7 +219,7 @@ func resourceStripeTaxRateCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.TaxRateParams{}
@@ -229,7 +252,8 @@ func resourceStripeTaxRateUpdate(ctx context.Context, d *schema.ResourceData, m
 		params.TaxType = stripe.String(ExtractString(d, "tax_type"))
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.TaxRates.Update(d.Id(), params)
 		return err
 	})
@@ -0,0 +1,43 @@
+package stripe
+
+import "testing"
+
+func TestAccStripeTaxRate(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_tax_rate",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"display_name": "GST",
+				"inclusive":    true,
+				"percentage":   10,
+				"country":      "AU",
+				"tax_type":     "gst",
+				"metadata":     map[string]interface{}{"region": "apac"},
+			},
+			checks: map[string]string{
+				"display_name":    "GST",
+				"inclusive":       "true",
+				"percentage":      "10",
+				"active":          "true",
+				"metadata.region": "apac",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"display_name": "Goods and Services Tax",
+				"inclusive":    true,
+				"percentage":   10,
+				"active":       false,
+				"country":      "AU",
+				"tax_type":     "gst",
+				"description":  "Australian GST",
+			},
+			checks: map[string]string{
+				"display_name": "Goods and Services Tax",
+				"active":       "false",
+				"description":  "Australian GST",
+				"metadata.%":   "0",
+			},
+		},
+	})
+}
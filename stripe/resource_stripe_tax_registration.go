@@ -0,0 +1,336 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func resourceStripeTaxRegistration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTaxRegistrationRead,
+		CreateContext: resourceStripeTaxRegistrationCreate,
+		UpdateContext: resourceStripeTaxRegistrationUpdate,
+		DeleteContext: resourceStripeTaxRegistrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"country": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCountry,
+				Description:  "Two-letter country code (ISO 3166-1 alpha-2).",
+			},
+			"country_options": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Description: "Specific options for a registration in the specified country. " +
+					"The options are applied to the country of the registration.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							Description: "Type of registration to be created in the country, " +
+								"for example standard, simplified, ioss, oss_union, oss_non_union, " +
+								"province_standard or state_sales_tax.",
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "Two-letter US state code (ISO 3166-2), " +
+								"required for registrations in the US.",
+						},
+						"jurisdiction": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "A FIPS code representing the local jurisdiction, " +
+								"required for the US local_amusement_tax and local_lease_tax types.",
+						},
+						"province": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "Two-letter CA province code (ISO 3166-2), " +
+								"required for the CA province_standard type.",
+						},
+						"place_of_supply_scheme": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+							Description: "Place of supply scheme used in an EU standard registration. " +
+								"Either small_seller or standard.",
+						},
+					},
+				},
+			},
+			"active_from": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					// now is resolved to the real time once the registration is created
+					return new == timestampNow && old != ""
+				},
+				ValidateFunc: validateTimestampOrNow,
+				Description: "Time at which the Tax Registration becomes active. " +
+					"Expected format is RFC3339 or the special value now. Defaults to now.",
+			},
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateTimestampOrNow,
+				Description: "If set, the Tax Registration stops being active at this time. " +
+					"Expected format is RFC3339 or the special value now.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of the registration. " +
+					"Either active, expired or scheduled.",
+			},
+		},
+	}
+}
+
+func resourceStripeTaxRegistrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var registration *stripe.TaxRegistration
+	var err error
+
+	params := &stripe.TaxRegistrationParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		registration, err = c.TaxRegistrations.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	countryOptions, err := flattenTaxRegistrationCountryOptions(registration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("country", registration.Country),
+		d.Set("country_options", countryOptions),
+		func() error {
+			if ExtractString(d, "active_from") == timestampNow {
+				return nil
+			}
+			return d.Set("active_from", time.Unix(registration.ActiveFrom, 0).Format(time.RFC3339))
+		}(),
+		func() error {
+			var expiresAt string
+			if registration.ExpiresAt != 0 {
+				expiresAt = time.Unix(registration.ExpiresAt, 0).Format(time.RFC3339)
+			}
+			if ExtractString(d, "expires_at") == timestampNow && expiresAt != "" {
+				return nil
+			}
+			return d.Set("expires_at", expiresAt)
+		}(),
+		d.Set("status", registration.Status),
+	)
+}
+
+// flattenTaxRegistrationCountryOptions reads the options of the registration country from the raw response,
+// every country has its own typed structure in the Stripe SDK.
+func flattenTaxRegistrationCountryOptions(registration *stripe.TaxRegistration) ([]map[string]interface{}, error) {
+	if registration.LastResponse == nil {
+		return nil, nil
+	}
+
+	var raw struct {
+		CountryOptions map[string]map[string]interface{} `json:"country_options"`
+	}
+	if err := json.Unmarshal(registration.LastResponse.RawJSON, &raw); err != nil {
+		return nil, err
+	}
+
+	options, ok := raw.CountryOptions[strings.ToLower(registration.Country)]
+	if !ok {
+		return nil, nil
+	}
+
+	registrationType := ToString(options["type"])
+	countryOptions := map[string]interface{}{
+		"type":  registrationType,
+		"state": ToString(options["state"]),
+	}
+	if standard := ToMap(options["standard"]); len(standard) > 0 {
+		countryOptions["place_of_supply_scheme"] = ToString(standard["place_of_supply_scheme"])
+	}
+	if provinceStandard := ToMap(options["province_standard"]); len(provinceStandard) > 0 {
+		countryOptions["province"] = ToString(provinceStandard["province"])
+	}
+	// the local US types nest the jurisdiction in a block named after the type
+	if jurisdiction := ToString(ToMap(options[registrationType])["jurisdiction"]); jurisdiction != "" {
+		countryOptions["jurisdiction"] = jurisdiction
+	}
+
+	return []map[string]interface{}{countryOptions}, nil
+}
+
+func resourceStripeTaxRegistrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var registration *stripe.TaxRegistration
+	var err error
+
+	country := ExtractString(d, "country")
+	params := &stripe.TaxRegistrationParams{
+		Country: stripe.String(country),
+	}
+
+	// country options are sent as raw form values, the typed parameters differ for every country
+	prefix := fmt.Sprintf("country_options[%s]", strings.ToLower(country))
+	countryOptions := ExtractMap(d, "country_options")
+	for k, v := range countryOptions {
+		value := ToString(v)
+		if value == "" {
+			continue
+		}
+		switch k {
+		case "type":
+			params.AddExtra(prefix+"[type]", value)
+		case "state":
+			params.AddExtra(prefix+"[state]", value)
+		case "jurisdiction":
+			params.AddExtra(fmt.Sprintf("%s[%s][jurisdiction]", prefix, ToString(countryOptions["type"])), value)
+		case "province":
+			params.AddExtra(prefix+"[province_standard][province]", value)
+		case "place_of_supply_scheme":
+			params.AddExtra(prefix+"[standard][place_of_supply_scheme]", value)
+		}
+	}
+
+	activeFrom := ExtractString(d, "active_from")
+	if activeFrom == "" {
+		activeFrom = timestampNow
+	}
+	if err := setTaxRegistrationTimestamp(activeFrom, &params.ActiveFrom, &params.ActiveFromNow); err != nil {
+		return diag.FromErr(err)
+	}
+	if expiresAt, set := d.GetOk("expires_at"); set {
+		if err := setTaxRegistrationTimestamp(ToString(expiresAt), &params.ExpiresAt, &params.ExpiresAtNow); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		registration, err = c.TaxRegistrations.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(registration.ID)
+	return resourceStripeTaxRegistrationRead(ctx, d, m)
+}
+
+func resourceStripeTaxRegistrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.TaxRegistrationParams{}
+
+	if d.HasChange("active_from") {
+		if err := setTaxRegistrationTimestamp(ExtractString(d, "active_from"), &params.ActiveFrom, &params.ActiveFromNow); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("expires_at") {
+		expiresAt := ExtractString(d, "expires_at")
+		if expiresAt == "" {
+			// an empty value removes the scheduled expiration
+			params.AddExtra("expires_at", "")
+		} else if err := setTaxRegistrationTimestamp(expiresAt, &params.ExpiresAt, &params.ExpiresAtNow); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.TaxRegistrations.Update(d.Id(), params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStripeTaxRegistrationRead(ctx, d, m)
+}
+
+func setTaxRegistrationTimestamp(value string, timestamp **int64, now **bool) error {
+	if value == timestampNow {
+		*now = stripe.Bool(true)
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+	*timestamp = stripe.Int64(t.Unix())
+	return nil
+}
+
+func resourceStripeTaxRegistrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if ExtractString(d, "status") == string(stripe.TaxRegistrationStatusExpired) {
+		tflog.Warn(ctx, "[WARN] Tax registration has already expired, it's only removed from the Terraform state")
+		d.SetId("")
+		return nil
+	}
+
+	c := m.(*stripeClient)
+	var err error
+
+	// registrations can't be deleted, they expire immediately instead
+	params := &stripe.TaxRegistrationParams{
+		ExpiresAtNow: stripe.Bool(true),
+	}
+
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.TaxRegistrations.Update(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,180 @@
+package stripe
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func TestAccStripeTaxRegistration(t *testing.T) {
+	expiresAt := time.Now().AddDate(1, 0, 0).Truncate(time.Second).Local().Format(time.RFC3339)
+
+	testAccRun(t, testAccCase{
+		resource: "stripe_tax_registration",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"country": "DE",
+				"country_options": []interface{}{
+					map[string]interface{}{"type": "standard", "place_of_supply_scheme": "small_seller"},
+				},
+			},
+			checks: map[string]string{
+				"status":                 "active",
+				"expires_at":             "",
+				"country_options.0.type": "standard",
+				"country_options.0.place_of_supply_scheme": "small_seller",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"country": "DE",
+				"country_options": []interface{}{
+					map[string]interface{}{"type": "standard", "place_of_supply_scheme": "small_seller"},
+				},
+				"expires_at": expiresAt,
+			},
+			checks: map[string]string{
+				"status":     "active",
+				"expires_at": expiresAt,
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"country": "US",
+				"country_options": []interface{}{
+					map[string]interface{}{"type": "state_sales_tax", "state": "CA"},
+				},
+			},
+			checks: map[string]string{
+				"country":                 "US",
+				"country_options.0.type":  "state_sales_tax",
+				"country_options.0.state": "CA",
+			},
+		},
+	})
+}
+
+func TestAccStripeTaxRegistrationOnDestroy(t *testing.T) {
+	// expired is the expiration of a registration which expired before it's destroyed
+	expired := time.Now().Add(-time.Hour).Unix()
+
+	testCases := map[string]struct {
+		expired bool
+	}{
+		// registrations can't be deleted, they expire immediately instead
+		"active": {},
+		// an expired registration is only removed from the state
+		"expired": {expired: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			config := testAccConfig(t, standIn, nil, testAccResource(t, "stripe_tax_registration", "test",
+				map[string]interface{}{
+					"country": "DE",
+					"country_options": []interface{}{
+						map[string]interface{}{"type": "standard"},
+					},
+				}))
+
+			state := &terraform.InstanceState{}
+			steps := []resource.TestStep{{
+				Config: config,
+				Check:  testAccKeepState("stripe_tax_registration.test", state),
+			}}
+			if tc.expired {
+				steps = append(steps, resource.TestStep{
+					PreConfig: func() {
+						registration, _ := standIn.object(state.ID)
+						registration["expires_at"] = expired
+						registration["status"] = "expired"
+						standIn.load(state.ID, registration)
+					},
+					RefreshState: true,
+					Check: testAccCheckAttributes("stripe_tax_registration.test", map[string]string{
+						"status": "expired",
+					}),
+				})
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProviderFactories,
+				Steps:                    steps,
+				CheckDestroy: func(*terraform.State) error {
+					registration, _ := standIn.object(state.ID)
+					if status := ToString(registration["status"]); status != "expired" {
+						return fmt.Errorf("expected the destroyed registration to be expired, got %s", status)
+					}
+					expiresAt := standInInt64(registration["expires_at"])
+					if tc.expired && expiresAt != expired {
+						return fmt.Errorf("expected the expired registration to be kept, it expires at %d", expiresAt)
+					}
+					return nil
+				},
+			})
+		})
+	}
+}
+
+func TestFlattenTaxRegistrationCountryOptions(t *testing.T) {
+	testCases := map[string]struct {
+		country  string
+		raw      string
+		expected []map[string]interface{}
+	}{
+		"standard": {
+			country: "DE",
+			raw:     `{"country_options": {"de": {"type": "standard", "standard": {"place_of_supply_scheme": "small_seller"}}}}`,
+			expected: []map[string]interface{}{
+				{"type": "standard", "state": "", "place_of_supply_scheme": "small_seller"},
+			},
+		},
+		"state": {
+			country: "US",
+			raw:     `{"country_options": {"us": {"type": "state_sales_tax", "state": "CA"}}}`,
+			expected: []map[string]interface{}{
+				{"type": "state_sales_tax", "state": "CA"},
+			},
+		},
+		"local jurisdiction": {
+			country: "US",
+			raw: `{"country_options": {"us": {"type": "local_amusement_tax", "state": "IL",
+				"local_amusement_tax": {"jurisdiction": "14000"}}}}`,
+			expected: []map[string]interface{}{
+				{"type": "local_amusement_tax", "state": "IL", "jurisdiction": "14000"},
+			},
+		},
+		"province": {
+			country: "CA",
+			raw:     `{"country_options": {"ca": {"type": "province_standard", "province_standard": {"province": "BC"}}}}`,
+			expected: []map[string]interface{}{
+				{"type": "province_standard", "state": "", "province": "BC"},
+			},
+		},
+		"other country": {
+			country: "FR",
+			raw:     `{"country_options": {"de": {"type": "standard"}}}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			registration := &stripe.TaxRegistration{Country: tc.country}
+			registration.LastResponse = &stripe.APIResponse{RawJSON: []byte(tc.raw)}
+
+			actual, err := flattenTaxRegistrationCountryOptions(registration)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package stripe
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+// taxSettingsID is the identifier of the account wide tax settings singleton.
+const taxSettingsID = "tax_settings"
+
+func resourceStripeTaxSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTaxSettingsRead,
+		CreateContext: resourceStripeTaxSettingsCreate,
+		UpdateContext: resourceStripeTaxSettingsUpdate,
+		DeleteContext: resourceStripeTaxSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the tax settings, always tax_settings.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"defaults": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Default configuration to be used on Stripe Tax calculations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tax_behavior": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(stripe.TaxSettingsDefaultsTaxBehaviorExclusive),
+								string(stripe.TaxSettingsDefaultsTaxBehaviorInclusive),
+								string(stripe.TaxSettingsDefaultsTaxBehaviorInferredByCurrency),
+							}, false),
+							Description: "Specifies the default tax behavior to be used when the item's price " +
+								"has unspecified tax behavior. One of inclusive, exclusive, or inferred_by_currency.",
+						},
+						"tax_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "A tax code ID used when the product doesn't have a tax code.",
+						},
+					},
+				},
+			},
+			"head_office": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The place where your business is located.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Address map with fields related to the address: line1, line2, city, state, " +
+								"postal_code and country",
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The active status indicates you have all required settings to calculate tax. " +
+					"Either active or pending.",
+			},
+			"missing_fields": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The list of missing fields that are required to perform calculations when the status is pending.",
+			},
+		},
+	}
+}
+
+func resourceStripeTaxSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var settings *stripe.TaxSettings
+	var err error
+
+	params := &stripe.TaxSettingsParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		settings, err = c.TaxSettings.Get(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		func() error {
+			if settings.Defaults != nil {
+				return d.Set("defaults", []map[string]interface{}{
+					{
+						"tax_behavior": settings.Defaults.TaxBehavior,
+						"tax_code":     settings.Defaults.TaxCode,
+					},
+				})
+			}
+			return nil
+		}(),
+		func() error {
+			if settings.HeadOffice != nil && settings.HeadOffice.Address != nil {
+				addressMap := make(map[string]interface{})
+				if settings.HeadOffice.Address.Line1 != "" {
+					addressMap["line1"] = settings.HeadOffice.Address.Line1
+				}
+				if settings.HeadOffice.Address.Line2 != "" {
+					addressMap["line2"] = settings.HeadOffice.Address.Line2
+				}
+				if settings.HeadOffice.Address.City != "" {
+					addressMap["city"] = settings.HeadOffice.Address.City
+				}
+				if settings.HeadOffice.Address.State != "" {
+					addressMap["state"] = settings.HeadOffice.Address.State
+				}
+				if settings.HeadOffice.Address.PostalCode != "" {
+					addressMap["postal_code"] = settings.HeadOffice.Address.PostalCode
+				}
+				if settings.HeadOffice.Address.Country != "" {
+					addressMap["country"] = settings.HeadOffice.Address.Country
+				}
+				return d.Set("head_office", []map[string]interface{}{{"address": addressMap}})
+			}
+			return nil
+		}(),
+		d.Set("status", settings.Status),
+		func() error {
+			var missingFields []string
+			if settings.StatusDetails != nil && settings.StatusDetails.Pending != nil {
+				missingFields = settings.StatusDetails.Pending.MissingFields
+			}
+			return d.Set("missing_fields", missingFields)
+		}(),
+	)
+}
+
+func resourceStripeTaxSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// tax settings always exist for the account, creation adopts them
+	d.SetId(taxSettingsID)
+	return resourceStripeTaxSettingsUpdate(ctx, d, m)
+}
+
+func resourceStripeTaxSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	params := &stripe.TaxSettingsParams{}
+
+	if d.HasChange("defaults") {
+		params.Defaults = &stripe.TaxSettingsDefaultsParams{}
+		for k, v := range ExtractMap(d, "defaults") {
+			switch k {
+			case "tax_behavior":
+				params.Defaults.TaxBehavior = NonZeroString(v)
+			case "tax_code":
+				params.Defaults.TaxCode = NonZeroString(v)
+			}
+		}
+	}
+	if d.HasChange("head_office") {
+		params.HeadOffice = &stripe.TaxSettingsHeadOfficeParams{
+			Address: &stripe.AddressParams{},
+		}
+		for k, v := range ToMap(ExtractMap(d, "head_office")["address"]) {
+			value := stripe.String(ToString(v))
+			switch k {
+			case "line1":
+				params.HeadOffice.Address.Line1 = value
+			case "line2":
+				params.HeadOffice.Address.Line2 = value
+			case "city":
+				params.HeadOffice.Address.City = value
+			case "state":
+				params.HeadOffice.Address.State = value
+			case "postal_code":
+				params.HeadOffice.Address.PostalCode = value
+			case "country":
+				params.HeadOffice.Address.Country = value
+			}
+		}
+	}
+
+	if params.Defaults != nil || params.HeadOffice != nil {
+		setStripeAccount(d, params)
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.TaxSettings.Update(params)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeTaxSettingsRead(ctx, d, m)
+}
+
+func resourceStripeTaxSettingsDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "[WARN] Stripe API doesn't support deletion of tax settings")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,72 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeTaxSettings(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	settings := func(values map[string]interface{}) string {
+		return testAccConfig(t, standIn, nil, testAccResource(t, "stripe_tax_settings", "test", values))
+	}
+	headOffice := []interface{}{
+		map[string]interface{}{
+			"address": map[string]interface{}{
+				"line1":       "Unter den Linden 1",
+				"city":        "Berlin",
+				"postal_code": "10117",
+				"country":     "DE",
+			},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the existing settings are adopted, Stripe keeps the default tax code
+				Config: settings(map[string]interface{}{
+					"defaults": []interface{}{map[string]interface{}{"tax_behavior": "exclusive"}},
+				}),
+				Check: testAccCheckAttributes("stripe_tax_settings.test", map[string]string{
+					"id":                      taxSettingsID,
+					"defaults.0.tax_behavior": "exclusive",
+					"defaults.0.tax_code":     "txcd_10000000",
+					"status":                  "pending",
+					"missing_fields.#":        "1",
+					"missing_fields.0":        "head_office",
+				}),
+			},
+			{
+				Config: settings(map[string]interface{}{
+					"defaults":    []interface{}{map[string]interface{}{"tax_behavior": "exclusive"}},
+					"head_office": headOffice,
+				}),
+				Check: testAccCheckAttributes("stripe_tax_settings.test", map[string]string{
+					"head_office.0.address.city":    "Berlin",
+					"head_office.0.address.country": "DE",
+					"status":                        "active",
+					"missing_fields.#":              "0",
+				}),
+			},
+			{
+				ResourceName:      "stripe_tax_settings.test",
+				ImportState:       true,
+				ImportStateId:     taxSettingsID,
+				ImportStateVerify: true,
+			},
+		},
+		// destroying the settings only removes them from the state
+		CheckDestroy: func(*terraform.State) error {
+			obj, _ := standIn.object("taxset_")
+			if status := ToString(obj["status"]); status != "active" {
+				return fmt.Errorf("expected the tax settings to be kept active, got %s", status)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,232 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stripe/stripe-go/v78"
+)
+
+// testClockPollInterval is the wait between two reads of an advancing test clock.
+var testClockPollInterval = 2 * time.Second
+
+func resourceStripeTestClock() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceStripeTestClockRead,
+		CreateContext: resourceStripeTestClockCreate,
+		UpdateContext: resourceStripeTestClockUpdate,
+		DeleteContext: resourceStripeTestClockDelete,
+		CustomizeDiff: resourceStripeTestClockCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStripeAccountPassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier for the object.",
+			},
+			"stripe_account": stripeAccountSchema(),
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name for this test clock.",
+			},
+			"frozen_time": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The time at which all objects belonging to the test clock are frozen, " +
+					"measured in seconds since the Unix epoch. Increasing it advances the clock, " +
+					"decreasing it recreates the clock.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the test clock, one of ready, advancing or internal_failure.",
+			},
+			"deletes_after": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Time at which this clock is scheduled to auto delete.",
+			},
+			"created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Time at which the object was created. " +
+					"Measured in seconds since the Unix epoch.",
+			},
+		},
+	}
+}
+
+// resourceStripeTestClockCustomizeDiff recreates the clock when the frozen time goes backwards,
+// test clocks can only be advanced.
+func resourceStripeTestClockCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("frozen_time") {
+		return nil
+	}
+	oldFrozenTime, newFrozenTime := d.GetChange("frozen_time")
+	if ToInt64(newFrozenTime) < ToInt64(oldFrozenTime) {
+		return d.ForceNew("frozen_time")
+	}
+	return nil
+}
+
+func resourceStripeTestClockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var testClock *stripe.TestHelpersTestClock
+	var err error
+
+	params := &stripe.TestHelpersTestClockParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		testClock, err = c.TestHelpersTestClocks.Get(d.Id(), params)
+		return err
+	})
+	switch {
+	case isNotFoundErr(err):
+		d.SetId("") // remove when resource does not exist
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	return CallSet(
+		d.Set("name", testClock.Name),
+		d.Set("frozen_time", testClock.FrozenTime),
+		d.Set("status", testClock.Status),
+		d.Set("deletes_after", testClock.DeletesAfter),
+		d.Set("created", testClock.Created),
+	)
+}
+
+func resourceStripeTestClockCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var testClock *stripe.TestHelpersTestClock
+	var err error
+
+	params := &stripe.TestHelpersTestClockParams{
+		FrozenTime: stripe.Int64(ExtractInt64(d, "frozen_time")),
+	}
+	if name, set := d.GetOk("name"); set {
+		params.Name = stripe.String(ToString(name))
+	}
+
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		testClock, err = c.TestHelpersTestClocks.New(params)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(testClock.ID)
+	if err = waitForTestClockReady(ctx, d, c, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceStripeTestClockRead(ctx, d, m)
+}
+
+func resourceStripeTestClockUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	if d.HasChange("frozen_time") {
+		params := &stripe.TestHelpersTestClockAdvanceParams{
+			FrozenTime: stripe.Int64(ExtractInt64(d, "frozen_time")),
+		}
+		setStripeAccount(d, params)
+
+		err = c.retryWithBackOff(ctx, params, func() error {
+			_, err = c.TestHelpersTestClocks.Advance(d.Id(), params)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// the objects of the clock (subscriptions, invoices, ...) are only settled once the clock is ready
+		if err = waitForTestClockReady(ctx, d, c, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStripeTestClockRead(ctx, d, m)
+}
+
+// waitForTestClockReady polls the test clock until it has finished advancing.
+func waitForTestClockReady(ctx context.Context, d *schema.ResourceData, c *stripeClient, timeout time.Duration) error {
+	var testClock *stripe.TestHelpersTestClock
+	var err error
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	params := &stripe.TestHelpersTestClockParams{}
+	setStripeAccount(d, params)
+
+	// the clock is polled once it's been advanced, the timeout can expire in the middle of a read
+	status := stripe.TestHelpersTestClockStatusAdvancing
+	for {
+		err = c.retryWithBackOff(ctx, params, func() error {
+			testClock, err = c.TestHelpersTestClocks.Get(d.Id(), params)
+			return err
+		})
+		if err != nil && ctx.Err() != nil {
+			return fmt.Errorf("test clock %s is still %s: %w", d.Id(), status, ctx.Err())
+		}
+		if err != nil {
+			return err
+		}
+
+		status = testClock.Status
+		switch status {
+		case stripe.TestHelpersTestClockStatusReady:
+			return nil
+		case stripe.TestHelpersTestClockStatusInternalFailure:
+			return fmt.Errorf("test clock %s failed to advance to %d", d.Id(), testClock.FrozenTime)
+		}
+
+		timer := time.NewTimer(testClockPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("test clock %s is still %s: %w", d.Id(), status, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func resourceStripeTestClockDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
+	var err error
+
+	// deleting the clock deletes the customers attached to it and all their objects
+	params := &stripe.TestHelpersTestClockParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.TestHelpersTestClocks.Del(d.Id(), params)
+		return err
+	})
+	if err != nil && !isNotFoundErr(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
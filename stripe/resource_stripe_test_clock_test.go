@@ -0,0 +1,124 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stripe/stripe-go/v78"
+)
+
+func TestAccStripeTestClock(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_test_clock",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"name":        "renewals",
+				"frozen_time": 1767225600,
+			},
+			checks: map[string]string{
+				"name":        "renewals",
+				"frozen_time": "1767225600",
+				"status":      "ready",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"name":        "renewals",
+				"frozen_time": 1769904000,
+			},
+			checks: map[string]string{
+				"frozen_time": "1769904000",
+				"status":      "ready",
+			},
+		},
+		replace: &testAccStep{
+			// clocks can't go back in time, a new one is created
+			config: map[string]interface{}{
+				"name":        "renewals",
+				"frozen_time": 1767225600,
+			},
+			checks: map[string]string{
+				"frozen_time": "1767225600",
+			},
+		},
+	})
+}
+
+func TestWaitForTestClockReady(t *testing.T) {
+	pollInterval := testClockPollInterval
+	testClockPollInterval = time.Millisecond
+	t.Cleanup(func() { testClockPollInterval = pollInterval })
+
+	testCases := map[string]struct {
+		reads   int
+		fails   bool
+		timeout time.Duration
+		// retrievals is the number of reads of the clock, none for a timeout.
+		retrievals int
+		err        string
+	}{
+		"ready": {
+			timeout:    time.Minute,
+			retrievals: 1,
+		},
+		"advancing": {
+			reads:      3,
+			timeout:    time.Minute,
+			retrievals: 4,
+		},
+		"internal failure": {
+			reads:      1,
+			fails:      true,
+			timeout:    time.Minute,
+			retrievals: 2,
+			err:        "failed to advance to 1769904000",
+		},
+		"timeout": {
+			reads:   1 << 30,
+			timeout: 50 * time.Millisecond,
+			err:     "is still advancing: context deadline exceeded",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			standIn := newStripeStandIn(t)
+			c := testAccConfigure(t, map[string]interface{}{"api_base_url": standIn.URL}).(*stripeClient)
+
+			clock, err := c.TestHelpersTestClocks.New(&stripe.TestHelpersTestClockParams{
+				FrozenTime: stripe.Int64(1767225600),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			standIn.advanceClocks(tc.reads, tc.fails)
+			if _, err = c.TestHelpersTestClocks.Advance(clock.ID, &stripe.TestHelpersTestClockAdvanceParams{
+				FrozenTime: stripe.Int64(1769904000),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceStripeTestClock().Schema, map[string]interface{}{})
+			d.SetId(clock.ID)
+			err = waitForTestClockReady(context.Background(), d, c, tc.timeout)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("expected the clock to be ready, got %v", err)
+			case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+				t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+			}
+
+			obj, _ := standIn.object(clock.ID)
+			retrievals := ToInt(obj["retrievals"])
+			if tc.retrievals > 0 && retrievals != tc.retrievals {
+				t.Fatalf("expected %d reads of the clock, got %d", tc.retrievals, retrievals)
+			}
+			if tc.retrievals == 0 && retrievals < 2 {
+				t.Fatalf("expected the clock to be polled until the timeout, got %d reads", retrievals)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package stripe
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stripe/stripe-go/v78"
-	"github.com/stripe/stripe-go/v78/client"
 )
 
 func resourceStripeWebhookEndpoint() *schema.Resource {
@@ -16,7 +18,7 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 		UpdateContext: resourceStripeWebhookEndpointUpdate,
 		DeleteContext: resourceStripeWebhookEndpointDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStripeWebhookEndpoint,
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -24,17 +26,23 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Computed:    true,
 				Description: "Unique identifier for the object.",
 			},
+			"stripe_account": stripeAccountSchema(),
 			"enabled_events": {
 				Type:     schema.TypeList,
 				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(webhookEventTypes, false),
+				},
 				Description: "The list of events to enable for this endpoint. " +
 					"[’*’] indicates that all events are enabled, except those that require explicit selection.",
 			},
 			"url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The URL of the webhook endpoint.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL of the webhook endpoint.",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -42,10 +50,19 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Description: "An optional description of what the webhook is used for.",
 			},
 			"secret": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Sensitive:   true,
-				Description: "The endpoint’s secret, used to generate webhook signatures. Only returned at creation.",
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+				Description: "The endpoint’s secret, used to generate webhook signatures. Only returned at creation, " +
+					"imports seed it with the <id>:<secret> identifier.",
+			},
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary key-value pairs, changing them replaces the endpoint to rotate its secret. " +
+					"The Stripe API doesn't roll the secret of an existing endpoint.",
 			},
 			"disabled": {
 				Type:        schema.TypeBool,
@@ -83,13 +100,16 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 	}
 }
 
-func resourceStripeWebhookEndpointRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeWebhookEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var webhookEndpoint *stripe.WebhookEndpoint
 	var err error
 
-	err = retryWithBackOff(func() error {
-		webhookEndpoint, err = c.WebhookEndpoints.Get(d.Id(), nil)
+	params := &stripe.WebhookEndpointParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		webhookEndpoint, err = c.WebhookEndpoints.Get(d.Id(), params)
 		return err
 	})
 	switch {
@@ -100,7 +120,7 @@ func resourceStripeWebhookEndpointRead(_ context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	return CallSet(
+	dg := CallSet(
 		d.Set("enabled_events", webhookEndpoint.EnabledEvents),
 		d.Set("url", webhookEndpoint.URL),
 		d.Set("description", webhookEndpoint.Description),
@@ -110,10 +130,36 @@ func resourceStripeWebhookEndpointRead(_ context.Context, d *schema.ResourceData
 		d.Set("application", webhookEndpoint.Application),
 		d.Set("metadata", webhookEndpoint.Metadata),
 	)
+	if ExtractString(d, "secret") == "" {
+		dg = append(dg, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The secret of webhook endpoint %s is unknown", d.Id()),
+			Detail: "Stripe returns the secret only when the endpoint is created, the secret attribute is empty. " +
+				"Import the endpoint as <id>:<secret> to keep its secret, or change keepers to replace " +
+				"the endpoint with one having a new secret.",
+		})
+	}
+	return dg
+}
+
+// importStripeWebhookEndpoint imports the endpoint identified by <id>:<secret> along with its secret,
+// which Stripe doesn't return after creation. A plain identifier leaves the secret empty.
+func importStripeWebhookEndpoint(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if id, secret, found := strings.Cut(d.Id(), ":"); found {
+		if !strings.HasPrefix(secret, "whsec_") {
+			return nil, fmt.Errorf("unexpected import identifier %q, expected <id>:<secret> like we_123:whsec_456",
+				d.Id())
+		}
+		if err := d.Set("secret", secret); err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+	}
+	return importStripeAccountPassthrough(ctx, d, m)
 }
 
 func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var webhookEndpoint *stripe.WebhookEndpoint
 	var err error
 
@@ -136,7 +182,10 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 		}
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	params.IdempotencyKey = newIdempotencyKey(ctx)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
 		webhookEndpoint, err = c.WebhookEndpoints.New(params)
 		return err
 	})
@@ -156,7 +205,7 @@ func resourceStripeWebhookEndpointCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+	c := m.(*stripeClient)
 	var err error
 
 	params := &stripe.WebhookEndpointParams{}
@@ -178,7 +227,8 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 		UpdateMetadata(d, params)
 	}
 
-	err = retryWithBackOff(func() error {
+	setStripeAccount(d, params)
+	err = c.retryWithBackOff(ctx, params, func() error {
 		_, err = c.WebhookEndpoints.Update(d.Id(), params)
 		return err
 	})
@@ -189,12 +239,15 @@ func resourceStripeWebhookEndpointUpdate(ctx context.Context, d *schema.Resource
 	return resourceStripeWebhookEndpointRead(ctx, d, m)
 }
 
-func resourceStripeWebhookEndpointDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.API)
+func resourceStripeWebhookEndpointDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*stripeClient)
 	var err error
 
-	err = retryWithBackOff(func() error {
-		_, err = c.WebhookEndpoints.Del(d.Id(), nil)
+	params := &stripe.WebhookEndpointParams{}
+	setStripeAccount(d, params)
+
+	err = c.retryWithBackOff(ctx, params, func() error {
+		_, err = c.WebhookEndpoints.Del(d.Id(), params)
 		return err
 	})
 	if err != nil {
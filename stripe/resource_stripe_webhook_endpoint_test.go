@@ -0,0 +1,165 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStripeWebhookEndpoint(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_webhook_endpoint",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"url":            "https://example.com/webhooks",
+				"enabled_events": []interface{}{"customer.created", "customer.updated"},
+				"description":    "customer events",
+			},
+			checks: map[string]string{
+				"url":              "https://example.com/webhooks",
+				"enabled_events.#": "2",
+				"disabled":         "false",
+			},
+		},
+		update: &testAccStep{
+			config: map[string]interface{}{
+				"url":            "https://example.com/stripe/webhooks",
+				"enabled_events": []interface{}{"customer.created"},
+				"description":    "customer events",
+				"disabled":       true,
+				"metadata":       map[string]interface{}{"owner": "billing"},
+			},
+			checks: map[string]string{
+				"url":              "https://example.com/stripe/webhooks",
+				"enabled_events.#": "1",
+				"disabled":         "true",
+				"metadata.owner":   "billing",
+			},
+		},
+		replace: &testAccStep{
+			config: map[string]interface{}{
+				"url":            "https://example.com/stripe/webhooks",
+				"enabled_events": []interface{}{"account.updated"},
+				"connect":        true,
+			},
+			checks: map[string]string{
+				"connect":          "true",
+				"enabled_events.0": "account.updated",
+			},
+		},
+		// the secret is only returned when the endpoint is created
+		importIgnore: []string{"secret"},
+	})
+}
+
+func TestAccStripeWebhookEndpointKeepers(t *testing.T) {
+	testAccRun(t, testAccCase{
+		resource: "stripe_webhook_endpoint",
+		create: testAccStep{
+			config: map[string]interface{}{
+				"url":            "https://example.com/webhooks",
+				"enabled_events": []interface{}{"invoice.paid"},
+				"keepers":        map[string]interface{}{"rotation": "2026-01"},
+			},
+			checks: map[string]string{
+				"keepers.rotation": "2026-01",
+			},
+		},
+		replace: &testAccStep{
+			// the new endpoint comes with a new secret
+			config: map[string]interface{}{
+				"url":            "https://example.com/webhooks",
+				"enabled_events": []interface{}{"invoice.paid"},
+				"keepers":        map[string]interface{}{"rotation": "2026-07"},
+			},
+			checks: map[string]string{
+				"keepers.rotation": "2026-07",
+			},
+		},
+		importIgnore: []string{"secret", "keepers"},
+	})
+}
+
+func TestResourceStripeWebhookEndpointImport(t *testing.T) {
+	testCases := map[string]struct {
+		id      string
+		account string
+		secret  string
+		err     string
+	}{
+		"identifier": {
+			id: "we_123",
+		},
+		"identifier with secret": {
+			id:     "we_123:whsec_456",
+			secret: "whsec_456",
+		},
+		"connected account with secret": {
+			id:      "acct_789/we_123:whsec_456",
+			account: "acct_789",
+			secret:  "whsec_456",
+		},
+		"not a secret": {
+			id:  "we_123:456",
+			err: "expected <id>:<secret>",
+		},
+	}
+
+	r := resourceStripeWebhookEndpoint()
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := r.Data(&terraform.InstanceState{ID: tc.id})
+			imported, err := r.Importer.StateContext(context.Background(), d, nil)
+			switch {
+			case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+				t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+			case tc.err != "":
+				return
+			case err != nil:
+				t.Fatal(err)
+			}
+
+			d = imported[0]
+			if d.Id() != "we_123" || ExtractString(d, "stripe_account") != tc.account ||
+				ExtractString(d, "secret") != tc.secret {
+				t.Fatalf("unexpected import of %s: id %s, account %q, secret %q", tc.id, d.Id(),
+					ExtractString(d, "stripe_account"), ExtractString(d, "secret"))
+			}
+		})
+	}
+}
+
+func TestAccStripeWebhookEndpointUnknownSecret(t *testing.T) {
+	standIn := newStripeStandIn(t)
+	standIn.load("we_standin", map[string]interface{}{
+		"object":         "webhook_endpoint",
+		"url":            "https://example.com/webhooks",
+		"enabled_events": []interface{}{"invoice.paid"},
+		"status":         "enabled",
+	})
+	meta := testAccMeta(t, standIn)
+	r := resourceStripeWebhookEndpoint()
+
+	for secret, warned := range map[string]bool{"": true, "whsec_standin": false} {
+		state := &terraform.InstanceState{ID: "we_standin", Attributes: map[string]string{"secret": secret}}
+		_, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+		if diags.HasError() {
+			t.Fatalf("refresh: %v", diags)
+		}
+		if hasWarning := testAccHasWarning(diags); hasWarning != warned {
+			t.Fatalf("secret %q: expected a warning %t, got %v", secret, warned, diags)
+		}
+	}
+}
+
+func testAccHasWarning(diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1665 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v78"
+)
+
+// standInCollection describes a collection of objects served by the stand-in.
+type standInCollection struct {
+	// pattern matches the collection path below /v1, groups capture the parent identifiers.
+	pattern string
+	object  string
+	prefix  string
+	// model is the stripe-go type used to convert form values to typed JSON values.
+	model reflect.Type
+	// defaults are set on created objects when the request doesn't provide them.
+	defaults map[string]interface{}
+	// create adjusts a created object, parents are the identifiers captured by the pattern.
+	create func(obj map[string]interface{}, parents []string)
+	// merge applies the update values the generic merge can't, e.g. the item changes of a subscription,
+	// and removes them from the values.
+	merge func(s *stripeStandIn, obj, values map[string]interface{})
+	// update adjusts an updated object.
+	update func(obj map[string]interface{})
+	// retrieve adjusts an object before it's returned by a retrieval, e.g. to complete an asynchronous
+	// transition the provider waits for.
+	retrieve func(obj map[string]interface{})
+	// children moves the values of nested objects created along with the object, e.g. the tax IDs
+	// of a customer, to the nested collection given by its pattern.
+	children func(obj map[string]interface{}) (string, []map[string]interface{})
+	// parent names the field holding the identifier captured by the pattern, nested collections with
+	// a parent can be listed.
+	parent string
+	// filters are the query parameters listing a collection without parent filters by, e.g. the product
+	// of prices. A list parameter, e.g. lookup_keys, matches the objects whose singular field is one of its values.
+	filters []string
+	// unique names a field held by a single object of the account, e.g. the lookup key of prices. A creation
+	// with a held value is rejected unless transfer_<field> is set, the value is then taken from its holder.
+	unique string
+	// hidden are the stored fields Stripe doesn't return: request parameters, e.g. the transfer_lookup_key
+	// of prices the tests check, and the stand-in's own bookkeeping, e.g. the reads of a test clock.
+	hidden []string
+	// searchable collections are served by the Search API as well.
+	searchable bool
+	// summarize computes the listed objects of a parent from the stored objects, e.g. the event
+	// summaries of a meter.
+	summarize func(s *stripeStandIn, parent string, values map[string]interface{}) []map[string]interface{}
+	// singleton collections hold a single object per account, e.g. the tax settings, it's read and updated
+	// on the collection path and created on first use.
+	singleton bool
+
+	re *regexp.Regexp
+}
+
+// standInCollections lists the served collections, nested collections go before their parents.
+var standInCollections = []*standInCollection{
+	{
+		pattern: `accounts/([^/]+)/login_links`,
+		object:  "login_link",
+		prefix:  "lael",
+		model:   reflect.TypeOf(stripe.LoginLink{}),
+		create: func(obj map[string]interface{}, parents []string) {
+			obj["url"] = "https://connect.stripe.com/express/" + parents[0] + "/" + ToString(obj["id"])
+		},
+	},
+	{
+		pattern: `customers/([^/]+)/sources`,
+		object:  "card",
+		prefix:  "card",
+		model:   reflect.TypeOf(stripe.Card{}),
+		create: func(obj map[string]interface{}, parents []string) {
+			token := ToString(obj["source"])
+			for k, v := range ToMap(obj["source"]) {
+				obj[k] = v
+			}
+			delete(obj, "source")
+			obj["object"] = "card"
+			obj["customer"] = parents[0]
+			obj["brand"] = "Visa"
+			for k, v := range standInTokenCard(token) {
+				obj[k] = v
+			}
+			obj["funding"] = "credit"
+			obj["country"] = "US"
+			if number := ToString(obj["number"]); len(number) > 4 {
+				obj["last4"] = number[len(number)-4:]
+				obj["fingerprint"] = fmt.Sprintf("fp_%x", number)
+			}
+			delete(obj, "number")
+			delete(obj, "cvc")
+		},
+	},
+	{
+		pattern: `customers/([^/]+)/tax_ids`,
+		object:  "tax_id",
+		prefix:  "txi",
+		model:   reflect.TypeOf(stripe.TaxID{}),
+		parent:  "customer",
+		create: func(obj map[string]interface{}, parents []string) {
+			obj["customer"] = parents[0]
+			obj["country"] = strings.ToUpper(strings.SplitN(ToString(obj["type"]), "_", 2)[0])
+			obj["verification"] = map[string]interface{}{"status": "pending"}
+		},
+	},
+	{
+		pattern: `payment_links/([^/]+)/line_items`,
+		object:  "item",
+		prefix:  "li",
+		model:   reflect.TypeOf(stripe.LineItem{}),
+		parent:  "payment_link",
+		hidden:  []string{"payment_link"},
+		create: func(obj map[string]interface{}, parents []string) {
+			obj["payment_link"] = parents[0]
+		},
+	},
+	{
+		pattern: `products/([^/]+)/features`,
+		object:  "product_feature",
+		prefix:  "prodft",
+		model:   reflect.TypeOf(stripe.ProductFeature{}),
+	},
+	{
+		pattern: `account_links`,
+		object:  "account_link",
+		prefix:  "acctlink",
+		model:   reflect.TypeOf(stripe.AccountLink{}),
+		hidden:  []string{"account", "collection_options", "refresh_url", "return_url", "type"},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["url"] = "https://connect.stripe.com/setup/s/" + ToString(obj["account"]) + "/" + ToString(obj["id"])
+			obj["expires_at"] = ToInt64(obj["created"]) + 300
+		},
+	},
+	{
+		pattern: `accounts`,
+		object:  "account",
+		prefix:  "acct",
+		model:   reflect.TypeOf(stripe.Account{}),
+		defaults: map[string]interface{}{
+			"country":           "US",
+			"default_currency":  "usd",
+			"charges_enabled":   false,
+			"payouts_enabled":   false,
+			"details_submitted": false,
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			standInCapabilities(obj)
+		},
+		update: standInCapabilities,
+	},
+	{
+		pattern: `checkout/sessions`,
+		object:  "checkout.session",
+		prefix:  "cs",
+		model:   reflect.TypeOf(stripe.CheckoutSession{}),
+		defaults: map[string]interface{}{
+			"status":         "open",
+			"payment_status": "unpaid",
+			"currency":       "usd",
+			"amount_total":   0,
+			"automatic_tax":  map[string]interface{}{"enabled": false},
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["url"] = "https://checkout.stripe.com/c/pay/" + ToString(obj["id"])
+			obj["expires_at"] = ToInt64(obj["created"]) + 24*60*60
+			// line items are only returned when expanded
+			delete(obj, "line_items")
+			delete(obj, "discounts")
+		},
+	},
+	{
+		pattern:  `coupons`,
+		object:   "coupon",
+		prefix:   "co",
+		model:    reflect.TypeOf(stripe.Coupon{}),
+		defaults: map[string]interface{}{"valid": true, "times_redeemed": 0, "duration": "once"},
+	},
+	{
+		pattern:    `customers`,
+		object:     "customer",
+		prefix:     "cus",
+		model:      reflect.TypeOf(stripe.Customer{}),
+		hidden:     []string{"source"},
+		searchable: true,
+		children: func(obj map[string]interface{}) (string, []map[string]interface{}) {
+			taxIDData := ToMapSlice(obj["tax_id_data"])
+			delete(obj, "tax_id_data")
+			return `customers/([^/]+)/tax_ids`, taxIDData
+		},
+	},
+	{
+		pattern:  `entitlements/features`,
+		object:   "entitlements.feature",
+		prefix:   "feat",
+		model:    reflect.TypeOf(stripe.EntitlementsFeature{}),
+		defaults: map[string]interface{}{"active": true},
+	},
+	{
+		pattern: `files`,
+		object:  "file",
+		prefix:  "file",
+		model:   reflect.TypeOf(stripe.File{}),
+		hidden:  []string{"file_link_data"},
+	},
+	{
+		pattern: `invoiceitems`,
+		object:  "invoiceitem",
+		prefix:  "ii",
+		model:   reflect.TypeOf(stripe.InvoiceItem{}),
+		defaults: map[string]interface{}{
+			"amount":       0,
+			"currency":     "usd",
+			"discountable": true,
+			"quantity":     1,
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["date"] = obj["created"]
+			if _, ok := obj["period"]; !ok {
+				obj["period"] = map[string]interface{}{"start": obj["created"], "end": obj["created"]}
+			}
+		},
+	},
+	{
+		pattern: `invoices`,
+		object:  "invoice",
+		prefix:  "in",
+		model:   reflect.TypeOf(stripe.Invoice{}),
+		hidden:  []string{"sends"},
+		defaults: map[string]interface{}{
+			"status":            "draft",
+			"collection_method": "charge_automatically",
+			"currency":          "usd",
+			"auto_advance":      false,
+			"amount_due":        0,
+			"total":             0,
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			delete(obj, "pending_invoice_items_behavior")
+			standInInvoiceDueDate(obj)
+		},
+		update: standInInvoiceDueDate,
+	},
+	{
+		pattern: `billing/meter_events`,
+		object:  "billing.meter_event",
+		prefix:  "mtrevt",
+		model:   reflect.TypeOf(stripe.BillingMeterEvent{}),
+		create: func(obj map[string]interface{}, _ []string) {
+			if _, ok := obj["identifier"]; !ok {
+				obj["identifier"] = obj["id"]
+			}
+			if _, ok := obj["timestamp"]; !ok {
+				obj["timestamp"] = obj["created"]
+			}
+		},
+	},
+	{
+		pattern: `billing/meter_event_adjustments`,
+		object:  "billing.meter_event_adjustment",
+		prefix:  "mtradj",
+		model:   reflect.TypeOf(stripe.BillingMeterEventAdjustment{}),
+		defaults: map[string]interface{}{
+			"status": "complete",
+		},
+	},
+	{
+		pattern:   `billing/meters/([^/]+)/event_summaries`,
+		object:    "billing.meter_event_summary",
+		prefix:    "mtrusg",
+		model:     reflect.TypeOf(stripe.BillingMeterEventSummary{}),
+		summarize: standInMeterEventSummaries,
+	},
+	{
+		pattern:  `billing/meters`,
+		object:   "billing.meter",
+		prefix:   "mtr",
+		model:    reflect.TypeOf(stripe.BillingMeter{}),
+		defaults: map[string]interface{}{"status": "active"},
+	},
+	{
+		pattern:  `billing_portal/configurations`,
+		object:   "billing_portal.configuration",
+		prefix:   "bpc",
+		model:    reflect.TypeOf(stripe.BillingPortalConfiguration{}),
+		defaults: map[string]interface{}{"active": true, "is_default": false},
+	},
+	{
+		pattern:  `payment_links`,
+		object:   "payment_link",
+		prefix:   "plink",
+		model:    reflect.TypeOf(stripe.PaymentLink{}),
+		defaults: map[string]interface{}{"active": true, "billing_address_collection": "auto"},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["url"] = "https://buy.stripe.com/test_" + ToString(obj["id"])
+		},
+		children: func(obj map[string]interface{}) (string, []map[string]interface{}) {
+			lineItems := ToMapSlice(obj["line_items"])
+			delete(obj, "line_items")
+			return `payment_links/([^/]+)/line_items`, lineItems
+		},
+		merge: func(s *stripeStandIn, _, values map[string]interface{}) {
+			// line items are updated by their identifier
+			for _, lineItem := range ToMapSlice(values["line_items"]) {
+				if item, ok := s.objects[ToString(lineItem["id"])]; ok {
+					delete(lineItem, "id")
+					mergeStandInValues(item, lineItem)
+				}
+			}
+			delete(values, "line_items")
+		},
+	},
+	{
+		pattern: `payment_methods`,
+		object:  "payment_method",
+		prefix:  "pm",
+		model:   reflect.TypeOf(stripe.PaymentMethod{}),
+		hidden:  []string{"detached"},
+		create: func(obj map[string]interface{}, _ []string) {
+			if token := ToString(ToMap(obj["card"])["token"]); token != "" {
+				obj["card"] = standInTokenCard(token)
+			}
+		},
+	},
+	{
+		pattern:    `prices`,
+		object:     "price",
+		prefix:     "price",
+		model:      reflect.TypeOf(stripe.Price{}),
+		filters:    []string{"product", "active", "type", "lookup_keys"},
+		unique:     "lookup_key",
+		hidden:     []string{"transfer_lookup_key"},
+		searchable: true,
+		defaults: map[string]interface{}{
+			"active":         true,
+			"billing_scheme": "per_unit",
+			"tax_behavior":   "unspecified",
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["type"] = "one_time"
+			if _, ok := obj["recurring"]; ok {
+				obj["type"] = "recurring"
+			}
+			// Stripe returns the decimal representation of every unit amount
+			if unitAmount, ok := obj["unit_amount"]; ok && obj["unit_amount_decimal"] == nil {
+				obj["unit_amount_decimal"] = unitAmount
+			}
+		},
+	},
+	{
+		pattern:    `products`,
+		object:     "product",
+		prefix:     "prod",
+		model:      reflect.TypeOf(stripe.Product{}),
+		filters:    []string{"active"},
+		searchable: true,
+		defaults:   map[string]interface{}{"active": true},
+	},
+	{
+		pattern:  `promotion_codes`,
+		object:   "promotion_code",
+		prefix:   "promo",
+		model:    reflect.TypeOf(stripe.PromotionCode{}),
+		defaults: map[string]interface{}{"active": true, "times_redeemed": 0},
+		create: func(obj map[string]interface{}, _ []string) {
+			if _, ok := obj["code"]; !ok {
+				obj["code"] = strings.ToUpper(ToString(obj["id"]))
+			}
+		},
+	},
+	{
+		pattern:  `shipping_rates`,
+		object:   "shipping_rate",
+		prefix:   "shr",
+		model:    reflect.TypeOf(stripe.ShippingRate{}),
+		defaults: map[string]interface{}{"active": true, "type": "fixed_amount", "tax_behavior": "unspecified"},
+	},
+	{
+		pattern: `tax_ids`,
+		object:  "tax_id",
+		prefix:  "txi",
+		model:   reflect.TypeOf(stripe.TaxID{}),
+	},
+	{
+		pattern:  `subscription_schedules`,
+		object:   "subscription_schedule",
+		prefix:   "sub_sched",
+		model:    reflect.TypeOf(stripe.SubscriptionSchedule{}),
+		defaults: map[string]interface{}{"end_behavior": "release"},
+		create: func(obj map[string]interface{}, _ []string) {
+			start := obj["created"]
+			if startDate, ok := obj["start_date"]; ok && startDate != "now" {
+				start = startDate
+			}
+			delete(obj, "start_date")
+			if phases := ToMapSlice(obj["phases"]); len(phases) > 0 {
+				phases[0]["start_date"] = start
+			}
+			standInSchedulePhases(obj)
+		},
+		update: standInSchedulePhases,
+	},
+	{
+		pattern: `subscriptions`,
+		object:  "subscription",
+		prefix:  "sub",
+		model:   reflect.TypeOf(stripe.Subscription{}),
+		hidden:  []string{"proration_behavior", "seq"},
+		defaults: map[string]interface{}{
+			"status":               "active",
+			"collection_method":    "charge_automatically",
+			"cancel_at_period_end": false,
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["current_period_start"] = obj["created"]
+			obj["current_period_end"] = ToInt64(obj["created"]) + 30*24*60*60
+			items := ToMapSlice(obj["items"])
+			obj["items"] = []interface{}{}
+			standInSubscriptionItems(obj, items)
+			standInTrialEnd(obj)
+		},
+		merge: func(_ *stripeStandIn, obj, values map[string]interface{}) {
+			standInSubscriptionItems(obj, ToMapSlice(values["items"]))
+			delete(values, "items")
+		},
+		update: standInTrialEnd,
+	},
+	{
+		pattern: `tax/registrations`,
+		object:  "tax.registration",
+		prefix:  "taxreg",
+		model:   reflect.TypeOf(stripe.TaxRegistration{}),
+		create: func(obj map[string]interface{}, _ []string) {
+			standInTaxRegistrationStatus(obj)
+		},
+		update: standInTaxRegistrationStatus,
+	},
+	{
+		pattern:   `tax/settings`,
+		object:    "tax.settings",
+		prefix:    "taxset",
+		model:     reflect.TypeOf(stripe.TaxSettings{}),
+		singleton: true,
+		defaults: map[string]interface{}{
+			"defaults": map[string]interface{}{"tax_behavior": nil, "tax_code": "txcd_10000000"},
+		},
+		create: func(obj map[string]interface{}, _ []string) {
+			standInTaxSettingsStatus(obj)
+		},
+		update: standInTaxSettingsStatus,
+	},
+	{
+		pattern:  `tax_rates`,
+		object:   "tax_rate",
+		prefix:   "txr",
+		model:    reflect.TypeOf(stripe.TaxRate{}),
+		filters:  []string{"active"},
+		defaults: map[string]interface{}{"active": true},
+	},
+	{
+		pattern:  `test_helpers/test_clocks`,
+		object:   "test_helpers.test_clock",
+		prefix:   "clock",
+		model:    reflect.TypeOf(stripe.TestHelpersTestClock{}),
+		defaults: map[string]interface{}{"status": "ready"},
+		hidden:   []string{"retrievals", "advancing_reads", "advance_fails"},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["deletes_after"] = ToInt64(obj["created"]) + 30*24*60*60
+		},
+		// an advancing clock ends its advance once it's been read the number of times set by advanceClocks,
+		// retrievals counts the reads, neither is part of the Stripe API
+		retrieve: func(obj map[string]interface{}) {
+			obj["retrievals"] = ToInt(obj["retrievals"]) + 1
+			if obj["status"] != "advancing" {
+				return
+			}
+			if reads := ToInt(obj["advancing_reads"]); reads > 0 {
+				obj["advancing_reads"] = reads - 1
+				return
+			}
+			obj["status"] = "ready"
+			if obj["advance_fails"] == true {
+				obj["status"] = "internal_failure"
+			}
+		},
+	},
+	{
+		pattern:  `webhook_endpoints`,
+		object:   "webhook_endpoint",
+		prefix:   "we",
+		model:    reflect.TypeOf(stripe.WebhookEndpoint{}),
+		defaults: map[string]interface{}{"status": "enabled"},
+		create: func(obj map[string]interface{}, _ []string) {
+			obj["secret"] = "whsec_" + ToString(obj["id"])
+			// connect endpoints belong to the platform application
+			if ToString(obj["connect"]) == "true" {
+				obj["application"] = "ca_standin"
+			}
+			delete(obj, "connect")
+		},
+		update: func(obj map[string]interface{}) {
+			if disabled, ok := obj["disabled"]; ok {
+				obj["status"] = "enabled"
+				if ToString(disabled) == "true" {
+					obj["status"] = "disabled"
+				}
+				delete(obj, "disabled")
+			}
+		},
+	},
+}
+
+// standInTokenCard returns the card behind a Stripe test token, e.g. tok_visa.
+func standInTokenCard(token string) map[string]interface{} {
+	cards := map[string]map[string]interface{}{
+		"tok_visa":       {"brand": "Visa", "last4": "4242"},
+		"tok_mastercard": {"brand": "MasterCard", "last4": "4444"},
+	}
+	card, ok := cards[token]
+	if !ok {
+		return nil
+	}
+	card["fingerprint"] = "fp_" + token
+	card["exp_month"] = "12"
+	card["exp_year"] = strconv.Itoa(time.Now().Year() + 1)
+	return card
+}
+
+// standInCapabilities activates the requested capabilities of an account and drops the unrequested ones.
+// standInFinalizeInvoice assigns the number and the hosted pages of the finalized invoice.
+func standInFinalizeInvoice(obj map[string]interface{}) {
+	obj["status"] = "open"
+	obj["number"] = strings.ToUpper(ToString(obj["id"]))
+	obj["hosted_invoice_url"] = "https://invoice.stripe.com/i/" + ToString(obj["id"])
+	obj["invoice_pdf"] = "https://pay.stripe.com/invoice/" + ToString(obj["id"]) + "/pdf"
+}
+
+// standInInvoiceDueDate turns the days until due into the due date, Stripe doesn't return the days.
+func standInInvoiceDueDate(obj map[string]interface{}) {
+	if days, ok := obj["days_until_due"]; ok {
+		obj["due_date"] = ToInt64(obj["created"]) + standInInt64(days)*24*60*60
+		delete(obj, "days_until_due")
+	}
+}
+
+// standInMeterEventSummaries aggregates the events of the meter's customer recorded between the start
+// and end time, the events cancelled by an adjustment are left out. The window is split by the value
+// grouping window, if any.
+func standInMeterEventSummaries(s *stripeStandIn, meterID string, values map[string]interface{}) []map[string]interface{} {
+	meter, ok := s.lookup(meterID)
+	if !ok {
+		return nil
+	}
+	customerKey, valueKey := meterEventCustomerKey, meterEventValueKey
+	if key := ToString(ToMap(meter["customer_mapping"])["event_payload_key"]); key != "" {
+		customerKey = key
+	}
+	if key := ToString(ToMap(meter["value_settings"])["event_payload_key"]); key != "" {
+		valueKey = key
+	}
+	formula := ToString(ToMap(meter["default_aggregation"])["formula"])
+
+	cancelled := map[string]bool{}
+	for _, obj := range s.objects {
+		if obj["object"] == "billing.meter_event_adjustment" && obj["event_name"] == meter["event_name"] {
+			cancelled[ToString(ToMap(obj["cancel"])["identifier"])] = true
+		}
+	}
+
+	start, end := standInInt64(values["start_time"]), standInInt64(values["end_time"])
+	window := end - start
+	switch values["value_grouping_window"] {
+	case "day":
+		window = 24 * 60 * 60
+	case "hour":
+		window = 60 * 60
+	}
+
+	var summaries []map[string]interface{}
+	for from := start; from < end; from += window {
+		to := from + window
+		if to > end {
+			to = end
+		}
+		var aggregated float64
+		for id, obj := range s.objects {
+			timestamp := standInInt64(obj["timestamp"])
+			payload := ToMap(obj["payload"])
+			if obj["object"] != "billing.meter_event" || obj["event_name"] != meter["event_name"] ||
+				s.accounts[id] != s.accounts[meterID] || cancelled[ToString(obj["identifier"])] ||
+				ToString(payload[customerKey]) != ToString(values["customer"]) || timestamp < from || timestamp >= to {
+				continue
+			}
+			if formula == "sum" {
+				value, _ := strconv.ParseFloat(ToString(payload[valueKey]), 64)
+				aggregated += value
+			} else {
+				aggregated++
+			}
+		}
+		summaries = append(summaries, map[string]interface{}{
+			"id":               fmt.Sprintf("mtrusg_%s_%d", meterID, from),
+			"object":           "billing.meter_event_summary",
+			"meter":            meterID,
+			"aggregated_value": aggregated,
+			"start_time":       from,
+			"end_time":         to,
+			"livemode":         false,
+		})
+	}
+	return summaries
+}
+
+// standInInt64 reads a number stored by the stand-in, either from a form value or set by the stand-in itself.
+// standInSubscriptionItems applies the item changes of a subscription create or update the way Stripe does,
+// items with an id are updated or deleted, the others are added.
+func standInSubscriptionItems(obj map[string]interface{}, changes []map[string]interface{}) {
+	items := obj["items"].([]interface{})
+	for _, change := range changes {
+		id := ToString(change["id"])
+		if id == "" {
+			// seq numbers the items of the subscription, it isn't part of the Stripe API
+			obj["seq"] = ToInt(obj["seq"]) + 1
+			item := map[string]interface{}{
+				"id":           fmt.Sprintf("si_%s_%d", obj["id"], obj["seq"]),
+				"object":       "subscription_item",
+				"subscription": obj["id"],
+				"quantity":     "1",
+			}
+			mergeStandInValues(item, change)
+			items = append(items, item)
+			continue
+		}
+		for i, item := range items {
+			item := item.(map[string]interface{})
+			if item["id"] != id {
+				continue
+			}
+			if change["deleted"] == "true" {
+				items = append(items[:i], items[i+1:]...)
+				break
+			}
+			delete(change, "id")
+			mergeStandInValues(item, change)
+			break
+		}
+	}
+	obj["items"] = items
+}
+
+// standInSchedulePhases dates the phases of a subscription schedule one after another, a phase ends at its end_date
+// or after its iterations of 30 days. The schedule starts its subscription once the first phase began.
+func standInSchedulePhases(obj map[string]interface{}) {
+	var start int64
+	for i, phase := range ToMapSlice(obj["phases"]) {
+		if i == 0 {
+			start = standInInt64(phase["start_date"])
+		}
+		phase["start_date"] = start
+		end := start + max(standInInt64(phase["iterations"]), 1)*30*24*60*60
+		if endDate, ok := phase["end_date"]; ok {
+			end = standInInt64(endDate)
+		}
+		phase["end_date"] = end
+		// Stripe resolves the iterations to the end date and a trial to the end of the trial
+		delete(phase, "iterations")
+		if phase["trial"] == "true" {
+			phase["trial_end"] = end
+		}
+		delete(phase, "trial")
+		start = end
+	}
+
+	if obj["status"] == nil || obj["status"] == "not_started" {
+		obj["status"] = "not_started"
+		if phases := ToMapSlice(obj["phases"]); len(phases) > 0 &&
+			standInInt64(phases[0]["start_date"]) <= time.Now().Unix() {
+			obj["status"] = "active"
+			obj["subscription"] = "sub_" + strings.TrimPrefix(ToString(obj["id"]), "sub_sched_")
+		}
+	}
+}
+
+// standInTrialEnd ends the trial of a subscription updated with trial_end = now.
+func standInTrialEnd(obj map[string]interface{}) {
+	status := "active"
+	switch {
+	case obj["trial_end"] == "now":
+		delete(obj, "trial_end")
+	case standInInt64(obj["trial_end"]) > time.Now().Unix():
+		status = "trialing"
+	}
+	if obj["status"] == "active" || obj["status"] == "trialing" {
+		obj["status"] = status
+	}
+}
+
+// standInTaxRegistrationStatus resolves the now timestamps of a tax registration and derives its status from them.
+func standInTaxRegistrationStatus(obj map[string]interface{}) {
+	now := time.Now().Unix()
+	for _, k := range []string{"active_from", "expires_at"} {
+		if obj[k] == "now" {
+			obj[k] = now
+		}
+	}
+	switch {
+	case obj["expires_at"] != nil && standInInt64(obj["expires_at"]) <= now:
+		obj["status"] = "expired"
+	case standInInt64(obj["active_from"]) > now:
+		obj["status"] = "scheduled"
+	default:
+		obj["status"] = "active"
+	}
+}
+
+// standInTaxSettingsStatus activates the tax settings once the head office is known.
+func standInTaxSettingsStatus(obj map[string]interface{}) {
+	if len(ToMap(obj["head_office"])) == 0 {
+		obj["status"] = "pending"
+		obj["status_details"] = map[string]interface{}{
+			"pending": map[string]interface{}{"missing_fields": []interface{}{"head_office"}},
+		}
+		return
+	}
+	obj["status"] = "active"
+	obj["status_details"] = map[string]interface{}{"active": map[string]interface{}{}}
+}
+
+func standInInt64(value interface{}) int64 {
+	i, _ := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+	return i
+}
+
+func standInCapabilities(obj map[string]interface{}) {
+	capabilities := ToMap(obj["capabilities"])
+	for capability, v := range capabilities {
+		request, ok := v.(map[string]interface{})
+		switch {
+		case !ok:
+			continue
+		case ToString(request["requested"]) == "true":
+			capabilities[capability] = "active"
+		default:
+			delete(capabilities, capability)
+		}
+	}
+}
+
+func init() {
+	for _, collection := range standInCollections {
+		collection.re = regexp.MustCompile(`^/v1/` + collection.pattern + `(?:/([^/]+)(?:/([a-z_]+))?)?$`)
+	}
+}
+
+// stripeStandIn is an in-memory stand-in of the Stripe API, it's good enough to round-trip
+// the objects managed by the provider without network access.
+type stripeStandIn struct {
+	*httptest.Server
+	t *testing.T
+
+	mu      sync.Mutex
+	seq     int
+	objects map[string]map[string]interface{}
+	// accounts maps the objects to the connected account they were created for, empty for the platform.
+	accounts map[string]string
+	// header holds the headers of the last request.
+	header http.Header
+	// age is how long ago created objects are dated, e.g. to create objects which are already expired.
+	age time.Duration
+	// replays holds the responses by idempotency key, Stripe replays them for requests sent with the same key.
+	replays map[string]*httptest.ResponseRecorder
+	// requests holds the form of the requests by idempotency key, a key sent with other parameters is refused.
+	requests map[string]url.Values
+	// lost is the number of upcoming POST responses lost on the way back after the request was handled.
+	lost int
+	// dropped is the number of upcoming POST requests whose connection is closed after the request was handled.
+	dropped int
+	// apiVersion is the default API version of the account, requests without the Stripe-Version header use it.
+	apiVersion string
+	// refused maps an object type to the number of creations accepted before the next ones are refused.
+	refused map[string]int
+	// advancingReads is the number of reads a test clock advanced from now on stays advancing,
+	// advanceFails makes the advance end in internal_failure instead of ready.
+	advancingReads int
+	advanceFails   bool
+}
+
+func newStripeStandIn(t *testing.T) *stripeStandIn {
+	s := &stripeStandIn{
+		t:        t,
+		objects:  map[string]map[string]interface{}{},
+		accounts: map[string]string{},
+		replays:  map[string]*httptest.ResponseRecorder{},
+		requests: map[string]url.Values{},
+		refused:  map[string]int{},
+		// accounts follow the version the provider is built against unless a test upgrades them
+		apiVersion: stripe.APIVersion,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// remove deletes the object behind the stand-in's back, it simulates a change made outside of Terraform.
+func (s *stripeStandIn) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, id)
+}
+
+// load adds an object of the platform to the stand-in, e.g. a customer referenced by the tested resource.
+func (s *stripeStandIn) load(id string, obj map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[id] = copyStandInValue(obj).(map[string]interface{})
+	s.objects[id]["id"] = id
+	s.accounts[id] = ""
+}
+
+// backdate dates the objects created from now on the given duration back.
+func (s *stripeStandIn) backdate(age time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.age = age
+}
+
+// upgradeAccount changes the default API version of the account.
+func (s *stripeStandIn) upgradeAccount(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiVersion = version
+}
+
+// loseResponses makes the stand-in handle the next n POST requests but answer them with a server error,
+// it simulates a connection dropped after Stripe accepted the request.
+func (s *stripeStandIn) loseResponses(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lost = n
+}
+
+// dropConnections makes the stand-in handle the next n POST requests but close their connection in the middle of
+// the response, the client never learns the outcome of the request.
+func (s *stripeStandIn) dropConnections(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped = n
+}
+
+// advanceClocks makes the test clocks advanced from now on stay advancing for the next reads, the advance then
+// ends ready or, when it fails, in internal_failure.
+func (s *stripeStandIn) advanceClocks(reads int, fails bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advancingReads = reads
+	s.advanceFails = fails
+}
+
+// refuseCreates makes the stand-in refuse the creation of objects of the given type, e.g. price, once the next
+// accepted ones are created. It simulates an apply failing halfway, a negative number accepts them again.
+func (s *stripeStandIn) refuseCreates(object string, accepted int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if accepted < 0 {
+		delete(s.refused, object)
+		return
+	}
+	s.refused[object] = accepted
+}
+
+// objectsOf returns the identifiers of the stored objects of the given type, e.g. product.
+func (s *stripeStandIn) objectsOf(object string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id, obj := range s.objects {
+		if obj["object"] == object {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// account returns the connected account the object was created for, empty for the platform.
+func (s *stripeStandIn) account(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts[id]
+}
+
+// lastHeader returns the value of the header sent with the last request.
+func (s *stripeStandIn) lastHeader(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header.Get(key)
+}
+
+// object returns a copy of the stored object.
+func (s *stripeStandIn) object(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[id]
+	if !ok {
+		return nil, false
+	}
+	return copyStandInValue(obj).(map[string]interface{}), true
+}
+
+// standInIdempotentForm returns the parameters of a form encoded request, the body is left to be read again.
+// Multipart requests, e.g. file uploads, aren't compared.
+func standInIdempotentForm(r *http.Request) (url.Values, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return url.ParseQuery(string(body))
+}
+
+func (s *stripeStandIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = r.Header.Clone()
+
+	// Stripe names the API version a request is served in
+	version := r.Header.Get("Stripe-Version")
+	if version == "" {
+		version = s.apiVersion
+	}
+	w.Header().Set("Stripe-Version", version)
+
+	if r.Method != http.MethodPost {
+		s.handle(w, r)
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	form, err := standInIdempotentForm(r)
+	if err != nil {
+		standInError(w, http.StatusBadRequest, "", err.Error())
+		return
+	}
+	if previous, ok := s.requests[key]; ok && key != "" && form != nil && !reflect.DeepEqual(previous, form) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{
+			"type":    "idempotency_error",
+			"message": "Keys for idempotent requests can only be used with the same parameters they were first used with.",
+		}})
+		return
+	}
+	if replay, ok := s.replays[key]; ok && key != "" {
+		w.Header().Set("Idempotent-Replayed", "true")
+		standInCopyResponse(w, replay)
+		return
+	}
+	recorder := httptest.NewRecorder()
+	s.handle(recorder, r)
+	if key != "" {
+		s.replays[key] = recorder
+		s.requests[key] = form
+	}
+	if s.lost > 0 {
+		s.lost--
+		standInError(w, http.StatusInternalServerError, "", "the connection was lost")
+		return
+	}
+	if s.dropped > 0 {
+		s.dropped--
+		// the response is cut short, an unanswered request on a reused connection would be sent again by net/http
+		if conn, buf, err := w.(http.Hijacker).Hijack(); err == nil {
+			_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 64\r\n\r\n{")
+			_ = buf.Flush()
+			conn.Close()
+			return
+		}
+	}
+	standInCopyResponse(w, recorder)
+}
+
+func standInCopyResponse(w http.ResponseWriter, recorder *httptest.ResponseRecorder) {
+	for k, v := range recorder.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(recorder.Code)
+	_, _ = w.Write(recorder.Body.Bytes())
+}
+
+func (s *stripeStandIn) handle(w http.ResponseWriter, r *http.Request) {
+	// the account of the API key
+	if r.URL.Path == "/v1/account" && r.Method == http.MethodGet {
+		s.respond(w, standInCollectionOf(`accounts`), map[string]interface{}{
+			"id":     "acct_standin",
+			"object": "account",
+		})
+		return
+	}
+
+	for _, collection := range standInCollections {
+		match := collection.re.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			continue
+		}
+		parents := match[1 : len(match)-2]
+		id, action := match[len(match)-2], match[len(match)-1]
+
+		values, err := standInForm(r)
+		if err != nil {
+			standInError(w, http.StatusBadRequest, "parameter_invalid", err.Error())
+			return
+		}
+
+		switch {
+		case id == "" && collection.singleton && r.Method == http.MethodGet:
+			s.get(w, collection, s.singleton(collection))
+		case id == "" && collection.singleton && r.Method == http.MethodPost:
+			s.update(w, collection, s.singleton(collection), "", values)
+		case id == "" && r.Method == http.MethodPost:
+			s.create(w, collection, parents, values)
+		case id == "" && collection.summarize != nil && r.Method == http.MethodGet:
+			s.respondList(w, collection, collection.summarize(s, parents[0], values))
+		case id == "" && collection.parent != "" && r.Method == http.MethodGet:
+			s.list(w, collection, parents[0], nil)
+		case id == "" && collection.filters != nil && r.Method == http.MethodGet:
+			s.list(w, collection, "", values)
+		case id == "":
+			standInError(w, http.StatusNotImplemented, "", "listing is not supported by the stand-in")
+		case id == "search" && collection.searchable && r.Method == http.MethodGet:
+			s.search(w, collection, ToString(values["query"]))
+		case r.Method == http.MethodGet:
+			s.get(w, collection, id)
+		case r.Method == http.MethodPost:
+			s.update(w, collection, id, action, values)
+		case r.Method == http.MethodDelete:
+			s.delete(w, collection, id)
+		default:
+			standInError(w, http.StatusMethodNotAllowed, "", "unsupported method "+r.Method)
+		}
+		return
+	}
+
+	standInError(w, http.StatusNotFound, "", "unrecognized request URL "+r.URL.Path)
+}
+
+func (s *stripeStandIn) create(w http.ResponseWriter, collection *standInCollection, parents []string,
+	values map[string]interface{}) {
+	if accepted, ok := s.refused[collection.object]; ok {
+		if accepted == 0 {
+			standInError(w, http.StatusBadRequest, "", "the creation of "+collection.object+" objects is refused")
+			return
+		}
+		s.refused[collection.object] = accepted - 1
+	}
+	obj := s.newObject(collection, parents, values)
+
+	id := ToString(obj["id"])
+	if _, exists := s.objects[id]; exists {
+		standInError(w, http.StatusBadRequest, "resource_already_exists", "object "+id+" already exists")
+		return
+	}
+	if key := collection.unique; key != "" && ToString(obj[key]) != "" {
+		for holderID, holder := range s.objects {
+			if ToString(holder["object"]) != collection.object || ToString(holder[key]) != ToString(obj[key]) ||
+				s.accounts[holderID] != s.header.Get("Stripe-Account") {
+				continue
+			}
+			if ToString(obj["transfer_"+key]) != "true" {
+				standInError(w, http.StatusBadRequest, "", fmt.Sprintf("A %s (%s) already uses that %s.",
+					collection.object, holderID, strings.ReplaceAll(key, "_", " ")))
+				return
+			}
+			delete(holder, key)
+		}
+	}
+	s.objects[id] = obj
+	s.accounts[id] = s.header.Get("Stripe-Account")
+
+	if collection.children != nil {
+		pattern, children := collection.children(obj)
+		for _, values := range children {
+			child := s.newObject(standInCollectionOf(pattern), []string{id}, values)
+			s.objects[ToString(child["id"])] = child
+			s.accounts[ToString(child["id"])] = s.header.Get("Stripe-Account")
+		}
+	}
+	s.respond(w, collection, obj)
+}
+
+func (s *stripeStandIn) newObject(collection *standInCollection, parents []string,
+	values map[string]interface{}) map[string]interface{} {
+	s.seq++
+	obj := map[string]interface{}{
+		"id":       fmt.Sprintf("%s_standin%d", collection.prefix, s.seq),
+		"object":   collection.object,
+		"created":  time.Now().Add(-s.age).Unix(),
+		"livemode": false,
+	}
+	for k, v := range collection.defaults {
+		obj[k] = v
+	}
+	mergeStandInValues(obj, values)
+	if collection.create != nil {
+		collection.create(obj, parents)
+	}
+	return obj
+}
+
+// singleton returns the identifier of the object of a singleton collection for the account of the request,
+// the object is created with the collection defaults on first use.
+func (s *stripeStandIn) singleton(collection *standInCollection) string {
+	account := s.header.Get("Stripe-Account")
+	id := collection.prefix + "_" + account
+	if _, ok := s.objects[id]; !ok {
+		obj := s.newObject(collection, nil, nil)
+		obj["id"] = id
+		s.objects[id] = obj
+		s.accounts[id] = account
+	}
+	return id
+}
+
+func standInCollectionOf(pattern string) *standInCollection {
+	for _, collection := range standInCollections {
+		if collection.pattern == pattern {
+			return collection
+		}
+	}
+	panic("no stand-in collection " + pattern)
+}
+
+// lookup finds the object among the objects of the account the request is made on behalf of.
+func (s *stripeStandIn) lookup(id string) (map[string]interface{}, bool) {
+	obj, ok := s.objects[id]
+	if !ok || s.accounts[id] != s.header.Get("Stripe-Account") {
+		return nil, false
+	}
+	return obj, true
+}
+
+func (s *stripeStandIn) get(w http.ResponseWriter, collection *standInCollection, id string) {
+	obj, ok := s.lookup(id)
+	if !ok {
+		standInError(w, http.StatusNotFound, "resource_missing", "No such object: '"+id+"'")
+		return
+	}
+	if collection.retrieve != nil {
+		collection.retrieve(obj)
+	}
+	s.respond(w, collection, obj)
+}
+
+func (s *stripeStandIn) update(w http.ResponseWriter, collection *standInCollection, id, action string,
+	values map[string]interface{}) {
+	obj, ok := s.lookup(id)
+	if !ok {
+		standInError(w, http.StatusNotFound, "resource_missing", "No such object: '"+id+"'")
+		return
+	}
+
+	switch action {
+	case "":
+		if collection.merge != nil {
+			collection.merge(s, obj, values)
+		}
+		mergeStandInValues(obj, values)
+		if collection.update != nil {
+			collection.update(obj)
+		}
+	case "deactivate":
+		obj["status"] = "inactive"
+	case "reactivate":
+		obj["status"] = "active"
+	case "attach":
+		// a payment method belongs to a single customer, once detached it can't be used again
+		if customer := ToString(obj["customer"]); obj["detached"] == true || customer != "" && customer != values["customer"] {
+			standInError(w, http.StatusBadRequest, "payment_method_unexpected_state",
+				"This PaymentMethod was previously used without being attached to a Customer or was detached "+
+					"from a Customer, and may not be used again.")
+			return
+		}
+		obj["customer"] = values["customer"]
+	case "detach":
+		delete(obj, "customer")
+		// detached marks the payment methods which can't be attached anymore, it isn't part of the Stripe API
+		obj["detached"] = true
+	case "advance":
+		obj["frozen_time"] = values["frozen_time"]
+		obj["status"] = "advancing"
+		obj["advancing_reads"] = s.advancingReads
+		obj["advance_fails"] = s.advanceFails
+	case "finalize":
+		standInFinalizeInvoice(obj)
+	case "send":
+		if obj["status"] == "draft" {
+			standInFinalizeInvoice(obj)
+		}
+		// sends counts the emails sent to the customer, it isn't part of the Stripe API
+		obj["sends"] = ToInt(obj["sends"]) + 1
+	case "void":
+		if obj["status"] != "open" && obj["status"] != "uncollectible" {
+			standInError(w, http.StatusBadRequest, "invoice_not_editable",
+				"You can only void open or uncollectible invoices, this invoice is "+ToString(obj["status"]))
+			return
+		}
+		obj["status"] = "void"
+	case "expire":
+		if obj["status"] != "open" {
+			standInError(w, http.StatusBadRequest, "checkout_session_not_open",
+				"Only Checkout Sessions with a status of open can be expired, this session is "+ToString(obj["status"]))
+			return
+		}
+		obj["status"] = "expired"
+	case "cancel", "release":
+		if obj["status"] != "not_started" && obj["status"] != "active" {
+			standInError(w, http.StatusBadRequest, "", "You cannot "+action+
+				" a subscription schedule that is currently in the `"+ToString(obj["status"])+"` status.")
+			return
+		}
+		obj["status"] = map[string]string{"cancel": "canceled", "release": "released"}[action]
+	case "reject":
+		obj["charges_enabled"] = false
+		obj["payouts_enabled"] = false
+		obj["requirements"] = map[string]interface{}{"disabled_reason": "rejected." + ToString(values["reason"])}
+	default:
+		standInError(w, http.StatusNotImplemented, "", "action "+action+" is not supported by the stand-in")
+		return
+	}
+	s.respond(w, collection, obj)
+}
+
+// list responds with the objects of the collection belonging to the parent and matching the filters,
+// in the order of creation.
+func (s *stripeStandIn) list(w http.ResponseWriter, collection *standInCollection, parent string,
+	values map[string]interface{}) {
+	var ids []string
+	for id, obj := range s.objects {
+		if ToString(obj["object"]) == collection.object && ToString(obj[collection.parent]) == parent &&
+			s.accounts[id] == s.header.Get("Stripe-Account") && standInMatches(obj, collection.filters, values) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ToInt64(s.objects[ids[i]]["created"]) < ToInt64(s.objects[ids[j]]["created"]) ||
+			ToInt64(s.objects[ids[i]]["created"]) == ToInt64(s.objects[ids[j]]["created"]) && ids[i] < ids[j]
+	})
+
+	objs := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		objs = append(objs, s.objects[id])
+	}
+	s.respondList(w, collection, objs)
+}
+
+// standInSearchClause matches a clause of the Search query language, e.g. active:'true' or metadata['tier']:'gold'.
+var standInSearchClause = regexp.MustCompile(`^(?:metadata\['([^']+)'\]|([a-z_]+)):'([^']*)'$`)
+
+// search responds with the objects of the collection matching a query of exact match clauses joined by AND,
+// the only part of the Search query language the stand-in understands.
+func (s *stripeStandIn) search(w http.ResponseWriter, collection *standInCollection, query string) {
+	var clauses [][]string
+	for _, clause := range strings.Split(query, " AND ") {
+		match := standInSearchClause.FindStringSubmatch(strings.TrimSpace(clause))
+		if match == nil {
+			standInError(w, http.StatusBadRequest, "parameter_invalid",
+				"the stand-in doesn't support the search clause "+clause)
+			return
+		}
+		clauses = append(clauses, match[1:])
+	}
+
+	var ids []string
+	for id, obj := range s.objects {
+		if ToString(obj["object"]) != collection.object || s.accounts[id] != s.header.Get("Stripe-Account") {
+			continue
+		}
+		matches := true
+		for _, clause := range clauses {
+			metadataKey, field, value := clause[0], clause[1], clause[2]
+			if metadataKey != "" {
+				matches = matches && ToString(ToMap(obj["metadata"])[metadataKey]) == value
+			} else {
+				matches = matches && fmt.Sprint(obj[field]) == value
+			}
+		}
+		if matches {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	data := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		data = append(data, typedStandInValue(s.objects[id], collection.model))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"object":   "search_result",
+		"data":     data,
+		"has_more": false,
+		"url":      "/v1/" + collection.pattern + "/search",
+	})
+}
+
+func (s *stripeStandIn) respondList(w http.ResponseWriter, collection *standInCollection,
+	objs []map[string]interface{}) {
+	data := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		data = append(data, s.response(collection, obj))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"object":   "list",
+		"data":     data,
+		"has_more": false,
+		"url":      "/v1/" + collection.object,
+	})
+}
+
+// standInMatches reports whether the object has the values of the given filters.
+func standInMatches(obj map[string]interface{}, filters []string, values map[string]interface{}) bool {
+	for _, filter := range filters {
+		value, ok := values[filter]
+		if !ok {
+			continue
+		}
+		if list, isList := value.([]interface{}); isList {
+			if !standInContains(list, fmt.Sprint(obj[strings.TrimSuffix(filter, "s")])) {
+				return false
+			}
+		} else if fmt.Sprint(obj[filter]) != ToString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func standInContains(list []interface{}, value string) bool {
+	for _, v := range list {
+		if ToString(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *stripeStandIn) delete(w http.ResponseWriter, collection *standInCollection, id string) {
+	if _, ok := s.lookup(id); !ok {
+		standInError(w, http.StatusNotFound, "resource_missing", "No such object: '"+id+"'")
+		return
+	}
+	// objects without a deleted flag, e.g. subscriptions, are canceled and returned as such
+	if _, ok := standInField(collection.model, "deleted"); !ok {
+		obj := s.objects[id]
+		delete(s.objects, id)
+		obj["status"] = "canceled"
+		s.respond(w, collection, obj)
+		return
+	}
+	delete(s.objects, id)
+	s.respond(w, collection, map[string]interface{}{"id": id, "object": collection.object, "deleted": true})
+}
+
+// standInForm decodes the form encoded (or multipart) body of the request into nested values,
+// for example metadata[key]=value becomes {"metadata": {"key": "value"}}.
+func standInForm(r *http.Request) (map[string]interface{}, error) {
+	var form url.Values
+	switch {
+	case strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"):
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			return nil, err
+		}
+		form = r.MultipartForm.Value
+		for name, files := range r.MultipartForm.File {
+			if name == "file" && len(files) > 0 {
+				form.Set("filename", files[0].Filename)
+				form.Set("size", strconv.FormatInt(files[0].Size, 10))
+				form.Set("type", strings.TrimPrefix(path.Ext(files[0].Filename), "."))
+			}
+		}
+	case r.Method == http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if form, err = url.ParseQuery(string(body)); err != nil {
+			return nil, err
+		}
+	default:
+		form = r.URL.Query()
+	}
+
+	values := map[string]interface{}{}
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "expand[]" || strings.HasPrefix(k, "expand[") {
+			continue
+		}
+		parts := strings.Split(strings.NewReplacer("][", "\x00", "[", "\x00", "]", "").Replace(k), "\x00")
+		node := values
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = form.Get(k)
+	}
+	return standInLists(values).(map[string]interface{}), nil
+}
+
+// standInLists turns the maps indexed by numbers into lists.
+func standInLists(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for k, v := range m {
+		m[k] = standInLists(v)
+	}
+	if len(m) == 0 {
+		return m
+	}
+	list := make([]interface{}, len(m))
+	for k, v := range m {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(m) {
+			return m
+		}
+		list[i] = v
+	}
+	return list
+}
+
+// mergeStandInValues applies the request values on the object the way Stripe does,
+// nested objects are merged, lists are replaced and empty values unset the field.
+func mergeStandInValues(obj, values map[string]interface{}) {
+	for k, v := range values {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			existing, ok := obj[k].(map[string]interface{})
+			if !ok {
+				existing = map[string]interface{}{}
+			}
+			mergeStandInValues(existing, v)
+			obj[k] = existing
+		case string:
+			if v == "" {
+				delete(obj, k)
+				continue
+			}
+			obj[k] = v
+		default:
+			obj[k] = v
+		}
+	}
+}
+
+func copyStandInValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[k] = copyStandInValue(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(value))
+		for i, v := range value {
+			l[i] = copyStandInValue(v)
+		}
+		return l
+	default:
+		return value
+	}
+}
+
+// typedStandInValue converts the form values to the JSON types expected by the stripe-go model.
+func typedStandInValue(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			switch t.Kind() {
+			case reflect.Struct:
+				if field, ok := standInField(t, k); ok {
+					// decimals are encoded as strings, e.g. unit_amount_decimal
+					if strings.HasSuffix(field.Tag.Get("json"), ",string") {
+						m[k] = ToString(v)
+						continue
+					}
+					m[k] = typedStandInValue(v, field.Type)
+					continue
+				}
+				m[k] = v
+			case reflect.Map:
+				m[k] = typedStandInValue(v, t.Elem())
+			default:
+				m[k] = v
+			}
+		}
+		return m
+	case []interface{}:
+		if t.Kind() == reflect.Struct {
+			// list objects, e.g. the file links
+			if field, ok := standInField(t, "data"); ok {
+				return map[string]interface{}{"object": "list", "data": typedStandInValue(value, field.Type)}
+			}
+		}
+		l := make([]interface{}, len(value))
+		for i, v := range value {
+			if t.Kind() == reflect.Slice {
+				l[i] = typedStandInValue(v, t.Elem())
+			} else {
+				l[i] = v
+			}
+		}
+		return l
+	case string:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int64:
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return i
+			}
+			return nil
+		case reflect.Float64:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				return f
+			}
+			return nil
+		case reflect.Bool:
+			return value == "true"
+		case reflect.Struct:
+			// references to other objects are returned unexpanded
+			return map[string]interface{}{"id": value}
+		case reflect.Slice, reflect.Map:
+			return nil
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// standInField finds the field of the struct by its JSON name.
+func standInField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] == name {
+			return field, true
+		}
+		// embedded structs, e.g. the metadata of lists
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if field, ok := standInField(field.Type, name); ok {
+				return field, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// standInUnknownFields lists the fields of a typed response value its stripe-go model doesn't know.
+// Every Stripe object has its type in object, not every model has a field for it, e.g. lists.
+func standInUnknownFields(value interface{}, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var unknown []string
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			switch t.Kind() {
+			case reflect.Struct:
+				field, ok := standInField(t, k)
+				switch {
+				case ok:
+					unknown = append(unknown, standInUnknownFields(v, field.Type, path+k+".")...)
+				case k != "object":
+					unknown = append(unknown, path+k)
+				}
+			case reflect.Map:
+				unknown = append(unknown, standInUnknownFields(v, t.Elem(), path+k+".")...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice {
+			for i, v := range value {
+				unknown = append(unknown, standInUnknownFields(v, t.Elem(), fmt.Sprintf("%s%d.", path, i))...)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func (s *stripeStandIn) respond(w http.ResponseWriter, collection *standInCollection, obj map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Request-Id", "req_standin")
+	_ = json.NewEncoder(w).Encode(s.response(collection, obj))
+}
+
+// response returns the object as Stripe returns it, the test fails when it has fields unknown to the
+// stripe-go model of the collection, which is generated from the API specification of Stripe.
+func (s *stripeStandIn) response(collection *standInCollection, obj map[string]interface{}) interface{} {
+	visible := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		visible[k] = v
+	}
+	for _, k := range collection.hidden {
+		delete(visible, k)
+	}
+	// every stored object has these, not every Stripe object does
+	for _, k := range []string{"id", "created", "livemode"} {
+		if _, ok := standInField(collection.model, k); !ok {
+			delete(visible, k)
+		}
+	}
+
+	response := typedStandInValue(visible, collection.model)
+	if unknown := standInUnknownFields(response, collection.model, ""); len(unknown) > 0 {
+		s.t.Errorf("stand-in: the %s response has fields unknown to %s: %s", collection.object,
+			collection.model, strings.Join(unknown, ", "))
+	}
+	return response
+}
+
+func standInError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    "invalid_request_error",
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -1,9 +1,15 @@
 package stripe
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stripe/stripe-go/v78"
@@ -196,6 +202,171 @@ func CallSet(err ...error) (d diag.Diagnostics) {
 	return d
 }
 
+// dataSourceSchemaFromResource turns a resource schema into a data source schema where every attribute is computed.
+// Attributes listed in exclude (e.g. Terraform only arguments) are left out.
+func dataSourceSchemaFromResource(r *schema.Resource, exclude ...string) map[string]*schema.Schema {
+	excluded := make(map[string]bool)
+	for _, key := range exclude {
+		excluded[key] = true
+	}
+
+	// the connected account is an argument of the resources only
+	excluded["stripe_account"] = true
+
+	result := make(map[string]*schema.Schema)
+	for key, s := range r.Schema {
+		if !excluded[key] {
+			result[key] = computedSchema(s)
+		}
+	}
+	return result
+}
+
+func computedSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:        s.Type,
+		Computed:    true,
+		Sensitive:   s.Sensitive,
+		Description: s.Description,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		computed.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		computed.Elem = &schema.Resource{Schema: dataSourceSchemaFromResource(elem)}
+	}
+	return computed
+}
+
+// stripeAccountSchema is the connected account argument shared by all resources.
+func stripeAccountSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validateStripeAccount,
+		Description: "Connected account the object belongs to, all requests for the object are made " +
+			"on its behalf. Defaults to the stripe_account of the provider, empty for the platform. The account " +
+			"is kept in the state, the object is replaced when the provider moves to another account.",
+	}
+}
+
+// stripeAccountContextKey carries the account the state records for the object of the operation.
+type stripeAccountContextKey struct{}
+
+// withStripeAccount keeps the account of the objects of the resource in the state. Without stripe_account in the
+// configuration it's the account of the provider at the time of the create, the requests for the object keep going
+// to that account and the object is replaced once the provider moves to another one.
+func withStripeAccount(r *schema.Resource) {
+	if _, ok := r.Schema["stripe_account"]; !ok {
+		return
+	}
+
+	onAccount := func(operation schema.CreateContextFunc) schema.CreateContextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx = context.WithValue(ctx, stripeAccountContextKey{}, ExtractString(d, "stripe_account"))
+			return operation(ctx, d, m)
+		}
+	}
+
+	read := onAccount(schema.CreateContextFunc(r.ReadContext))
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		// states written before the account was recorded, and imports, belong to the account of the provider
+		state := d.GetRawState()
+		recorded := !state.IsNull() && !state.GetAttr("stripe_account").IsNull()
+		if !recorded && ExtractString(d, "stripe_account") == "" {
+			if err := d.Set("stripe_account", m.(*stripeClient).account); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return read(ctx, d, m)
+	}
+	r.CreateContext = onAccount(r.CreateContext)
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(onAccount(schema.CreateContextFunc(r.UpdateContext)))
+	}
+	r.DeleteContext = schema.DeleteContextFunc(onAccount(schema.CreateContextFunc(r.DeleteContext)))
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, m); err != nil {
+				return err
+			}
+		}
+		c, _ := m.(*stripeClient)
+		config := d.GetRawConfig()
+		if c == nil || config.IsNull() || !config.GetAttr("stripe_account").IsNull() {
+			return nil
+		}
+		state := d.GetRawState()
+		switch {
+		case d.Id() == "":
+			return d.SetNew("stripe_account", c.account)
+		case state.IsNull() || state.GetAttr("stripe_account").IsNull():
+			// the account is recorded by the next refresh
+			return nil
+		case ToString(d.Get("stripe_account")) != c.account:
+			return d.SetNew("stripe_account", c.account)
+		}
+		return nil
+	}
+}
+
+// stripeAccountSetter is implemented by all Stripe parameters through the embedded stripe.Params or stripe.ListParams.
+type stripeAccountSetter interface {
+	SetStripeAccount(val string)
+}
+
+// setStripeAccount directs the request to the connected account of the resource,
+// without one the request goes to the account configured by the provider.
+func setStripeAccount(d *schema.ResourceData, params stripeAccountSetter) {
+	if account := ExtractString(d, "stripe_account"); account != "" {
+		params.SetStripeAccount(account)
+	}
+}
+
+// setRequestContext binds the request made with the params to ctx, the params of list and search calls
+// carry the context in their own embedded parameters.
+func setRequestContext(ctx context.Context, params stripeAccountSetter) {
+	switch p := params.(type) {
+	case stripe.ListParamsContainer:
+		p.GetListParams().Context = ctx
+	case stripe.SearchParamsContainer:
+		p.GetSearchParams().Context = ctx
+	case stripe.ParamsContainer:
+		p.GetParams().Context = ctx
+	}
+}
+
+// importStripeAccountPassthrough imports objects of a connected account identified by <account>/<id>,
+// a plain identifier imports the object of the account configured by the provider.
+func importStripeAccountPassthrough(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if account, id, found := strings.Cut(d.Id(), "/"); found {
+		if !strings.HasPrefix(account, "acct_") || id == "" {
+			return nil, fmt.Errorf("unexpected import identifier %q, expected <account>/<id> like acct_123/%s",
+				d.Id(), id)
+		}
+		if err := d.Set("stripe_account", account); err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// matchesMetadata reports whether metadata holds every key-value pair of the filter.
+func matchesMetadata(metadata map[string]string, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		if value, ok := metadata[k]; !ok || value != ToString(v) {
+			return false
+		}
+	}
+	return true
+}
+
 type MetadataAdder interface {
 	AddMetadata(key, value string)
 }
@@ -219,6 +390,14 @@ func UpdateMetadata(d *schema.ResourceData, adder MetadataAdder) {
 	}
 }
 
+const (
+	onDestroyArchive = "archive"
+	onDestroyAbandon = "abandon"
+)
+
+// timestampNow is accepted by the timestamp arguments that Stripe can set to the time of the request.
+const timestampNow = "now"
+
 func isRateLimitErr(e error) bool {
 	var err *stripe.Error
 	ok := errors.As(e, &err)
@@ -231,19 +410,122 @@ func isNotFoundErr(e error) bool {
 	return ok && err.HTTPStatusCode == 404
 }
 
-func retryWithBackOff(call func() error) error {
-	var backOff time.Duration = 1
-	for {
+const (
+	retryOnRateLimit           = "429"
+	retryOnServerError         = "5xx"
+	retryOnLockTimeout         = "lock_timeout"
+	retryOnIdempotencyConflict = "idempotency_conflict"
+
+	defaultMaxRetries = 8
+	defaultMaxBackOff = 30 * time.Second
+	initialBackOff    = time.Second
+)
+
+var (
+	retryCategories = []string{
+		retryOnRateLimit,
+		retryOnServerError,
+		retryOnLockTimeout,
+		retryOnIdempotencyConflict,
+	}
+	defaultRetryOn = []string{retryOnRateLimit, retryOnLockTimeout}
+)
+
+// retryPolicy describes how failed Stripe API calls are retried.
+type retryPolicy struct {
+	maxRetries int
+	maxBackOff time.Duration
+	retryOn    map[string]bool
+}
+
+// shouldRetry decides whether the error is worth another attempt.
+// The Stripe-Should-Retry header, when present, takes precedence over the configured categories.
+func (p retryPolicy) shouldRetry(e error) bool {
+	var err *stripe.Error
+	if !errors.As(e, &err) {
+		return false
+	}
+
+	if err.LastResponse != nil {
+		switch err.LastResponse.Header.Get("Stripe-Should-Retry") {
+		case "false":
+			return false
+		case "true":
+			return true
+		}
+	}
+
+	switch {
+	case err.Code == stripe.ErrorCodeLockTimeout:
+		return p.retryOn[retryOnLockTimeout]
+	case err.Type == stripe.ErrorTypeIdempotency || err.Code == stripe.ErrorCodeIdempotencyKeyInUse:
+		return p.retryOn[retryOnIdempotencyConflict]
+	case isRateLimitErr(err):
+		return p.retryOn[retryOnRateLimit]
+	case err.HTTPStatusCode >= 500:
+		return p.retryOn[retryOnServerError]
+	default:
+		return false
+	}
+}
+
+// backOff returns the wait time before the given attempt (starting at 0).
+// The exponential delay is capped by maxBackOff and jittered to spread concurrent retries,
+// a Retry-After header returned by Stripe is used as the lower bound, even above maxBackOff.
+func (p retryPolicy) backOff(attempt int, e error) time.Duration {
+	wait := p.maxBackOff
+	if attempt < 32 && initialBackOff<<attempt < p.maxBackOff {
+		wait = initialBackOff << attempt
+	}
+	wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+
+	if retryAfter := retryAfter(e); retryAfter > wait {
+		wait = retryAfter
+	}
+	return wait
+}
+
+func retryAfter(e error) time.Duration {
+	var err *stripe.Error
+	if !errors.As(e, &err) || err.LastResponse == nil {
+		return 0
+	}
+	seconds, convErr := strconv.Atoi(err.LastResponse.Header.Get("Retry-After"))
+	if convErr != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// retryWithBackOff makes the call until it succeeds, fails for good or ctx is done.
+// The params of the call are bound to ctx, a cancelled apply aborts the request in flight as well.
+func (c *stripeClient) retryWithBackOff(ctx context.Context, params stripeAccountSetter, call func() error) error {
+	setRequestContext(ctx, params)
+	keys := idempotencyKeysFrom(ctx)
+	for attempt := 0; ; attempt++ {
 		err := call()
-		switch {
-		case err == nil:
-			return nil
-		case isRateLimitErr(err):
-			time.Sleep(time.Second * backOff)
-			backOff *= 2
-		default:
+		if keys.rotate(ctx, params, err) {
+			continue
+		}
+		if err == nil || attempt >= c.retry.maxRetries || !c.retry.shouldRetry(err) {
+			keys.settle(params, err)
 			return err
 		}
+
+		wait := c.retry.backOff(attempt, err)
+		tflog.Debug(ctx, "Retrying Stripe API call", map[string]interface{}{
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
 	}
 }
 
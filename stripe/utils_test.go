@@ -0,0 +1,257 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v78"
+)
+
+// testRetryError returns a Stripe API error with the given status, code and response headers.
+func testRetryError(status int, code stripe.ErrorCode, headers map[string]string) *stripe.Error {
+	header := http.Header{}
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+	err := &stripe.Error{HTTPStatusCode: status, Code: code, Type: stripe.ErrorTypeAPI}
+	err.LastResponse = &stripe.APIResponse{Header: header}
+	return err
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	idempotencyErr := testRetryError(http.StatusBadRequest, "", nil)
+	idempotencyErr.Type = stripe.ErrorTypeIdempotency
+
+	testCases := map[string]struct {
+		err      error
+		retryOn  []string
+		expected bool
+	}{
+		"rate limit": {
+			err:      testRetryError(http.StatusTooManyRequests, stripe.ErrorCodeRateLimit, nil),
+			retryOn:  []string{retryOnRateLimit},
+			expected: true,
+		},
+		"rate limit not configured": {
+			err:     testRetryError(http.StatusTooManyRequests, stripe.ErrorCodeRateLimit, nil),
+			retryOn: []string{retryOnServerError},
+		},
+		"server error": {
+			err:      testRetryError(http.StatusServiceUnavailable, "", nil),
+			retryOn:  []string{retryOnServerError},
+			expected: true,
+		},
+		"server error not configured": {
+			err:     testRetryError(http.StatusInternalServerError, "", nil),
+			retryOn: defaultRetryOn,
+		},
+		"lock timeout": {
+			err:      testRetryError(http.StatusTooManyRequests, stripe.ErrorCodeLockTimeout, nil),
+			retryOn:  []string{retryOnLockTimeout},
+			expected: true,
+		},
+		// the lock timeout is answered with 429, it isn't retried as a rate limit
+		"lock timeout not configured": {
+			err:     testRetryError(http.StatusTooManyRequests, stripe.ErrorCodeLockTimeout, nil),
+			retryOn: []string{retryOnRateLimit},
+		},
+		"idempotency conflict": {
+			err:      idempotencyErr,
+			retryOn:  []string{retryOnIdempotencyConflict},
+			expected: true,
+		},
+		"idempotency key in use": {
+			err:      testRetryError(http.StatusConflict, stripe.ErrorCodeIdempotencyKeyInUse, nil),
+			retryOn:  []string{retryOnIdempotencyConflict},
+			expected: true,
+		},
+		"idempotency conflict not configured": {
+			err:     idempotencyErr,
+			retryOn: defaultRetryOn,
+		},
+		"client error": {
+			err:     testRetryError(http.StatusBadRequest, stripe.ErrorCodeParameterMissing, nil),
+			retryOn: retryCategories,
+		},
+		"should retry": {
+			err:      testRetryError(http.StatusBadRequest, "", map[string]string{"Stripe-Should-Retry": "true"}),
+			retryOn:  defaultRetryOn,
+			expected: true,
+		},
+		"should not retry": {
+			err: testRetryError(http.StatusServiceUnavailable, "",
+				map[string]string{"Stripe-Should-Retry": "false"}),
+			retryOn: retryCategories,
+		},
+		"network error": {
+			err:     errors.New("connection reset by peer"),
+			retryOn: retryCategories,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			policy := retryPolicy{retryOn: map[string]bool{}}
+			for _, category := range tc.retryOn {
+				policy.retryOn[category] = true
+			}
+			if actual := policy.shouldRetry(tc.err); actual != tc.expected {
+				t.Fatalf("expected retry %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected time.Duration
+	}{
+		"seconds":  {err: testRetryError(429, "", map[string]string{"Retry-After": "3"}), expected: 3 * time.Second},
+		"missing":  {err: testRetryError(429, "", nil)},
+		"negative": {err: testRetryError(429, "", map[string]string{"Retry-After": "-1"})},
+		// Stripe only sends seconds, an HTTP date isn't honoured
+		"date":        {err: testRetryError(429, "", map[string]string{"Retry-After": "Wed, 21 Oct 2026 07:28:00 GMT"})},
+		"no response": {err: &stripe.Error{HTTPStatusCode: 429}},
+		"other error": {err: errors.New("connection reset by peer")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := retryAfter(tc.err); actual != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackOff(t *testing.T) {
+	policy := retryPolicy{maxBackOff: 8 * time.Second}
+	err := testRetryError(http.StatusTooManyRequests, "", nil)
+
+	for attempt, limit := range []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second, 8 * time.Second,
+	} {
+		// the jitter keeps the wait between the half and the full delay
+		for i := 0; i < 100; i++ {
+			if wait := policy.backOff(attempt, err); wait < limit/2 || wait > limit {
+				t.Fatalf("attempt %d: expected a wait between %s and %s, got %s", attempt, limit/2, limit, wait)
+			}
+		}
+	}
+	if wait := policy.backOff(64, err); wait < 4*time.Second || wait > 8*time.Second {
+		t.Fatalf("expected the wait of a late attempt to be capped, got %s", wait)
+	}
+
+	// Retry-After is the minimal wait time, even above the cap
+	for header, expected := range map[string]time.Duration{"1": time.Second, "5": 5 * time.Second, "60": time.Minute} {
+		wait := policy.backOff(0, testRetryError(http.StatusTooManyRequests, "", map[string]string{"Retry-After": header}))
+		if wait < expected {
+			t.Fatalf("Retry-After %s: expected a wait of at least %s, got %s", header, expected, wait)
+		}
+		if expected > time.Second && wait != expected {
+			t.Fatalf("Retry-After %s: expected a wait of %s, got %s", header, expected, wait)
+		}
+	}
+}
+
+func TestRetryWithBackOff(t *testing.T) {
+	rateLimited := testRetryError(http.StatusTooManyRequests, stripe.ErrorCodeRateLimit, nil)
+	c := &stripeClient{retry: retryPolicy{
+		maxRetries: 2,
+		maxBackOff: time.Millisecond,
+		retryOn:    map[string]bool{retryOnRateLimit: true},
+	}}
+
+	t.Run("max retries", func(t *testing.T) {
+		calls := 0
+		err := c.retryWithBackOff(context.Background(), &stripe.PriceParams{}, func() error {
+			calls++
+			return rateLimited
+		})
+		if !errors.Is(err, rateLimited) || calls != 3 {
+			t.Fatalf("expected 3 calls failing with the last error, got %d calls and %v", calls, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		calls := 0
+		err := c.retryWithBackOff(context.Background(), &stripe.PriceParams{}, func() error {
+			calls++
+			if calls == 1 {
+				return rateLimited
+			}
+			return nil
+		})
+		if err != nil || calls != 2 {
+			t.Fatalf("expected 2 calls succeeding, got %d calls and %v", calls, err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c := &stripeClient{retry: retryPolicy{
+			maxRetries: 8,
+			maxBackOff: time.Hour,
+			retryOn:    map[string]bool{retryOnRateLimit: true},
+		}}
+
+		calls := 0
+		err := c.retryWithBackOff(ctx, &stripe.PriceParams{}, func() error {
+			calls++
+			return rateLimited
+		})
+		if !errors.Is(err, context.Canceled) || calls != 1 {
+			t.Fatalf("expected a single call cancelled, got %d calls and %v", calls, err)
+		}
+	})
+
+	t.Run("request in flight", func(t *testing.T) {
+		// the server only answers once the request is abandoned
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		t.Cleanup(server.Close)
+		c := testAccConfigure(t, map[string]interface{}{"api_base_url": server.URL}).(*stripeClient)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		params := &stripe.PriceParams{}
+		err := c.retryWithBackOff(ctx, params, func() error {
+			_, err := c.Prices.Get("price_standin", params)
+			return err
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the request to be aborted by the context, got %v", err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "apply")
+
+		params := &stripe.PriceParams{}
+		listParams := &stripe.PriceListParams{}
+		searchParams := &stripe.PriceSearchParams{}
+		for _, p := range []stripeAccountSetter{params, listParams, searchParams} {
+			if err := c.retryWithBackOff(ctx, p, func() error { return nil }); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the requests are bound to the context of the operation
+		for name, actual := range map[string]context.Context{
+			"params":        params.Context,
+			"list params":   listParams.Context,
+			"search params": searchParams.Context,
+		} {
+			if actual != ctx {
+				t.Fatalf("expected the %s to carry the context of the operation, got %v", name, actual)
+			}
+		}
+	})
+}
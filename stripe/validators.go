@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// validateCurrency checks the value is a three-letter ISO currency code in lowercase.
+var validateCurrency = validation.StringMatch(
+	regexp.MustCompile(`^[a-z]{3}$`),
+	"must be a three-letter ISO currency code in lowercase",
+)
+
+// validateCountry checks the value is a two-letter ISO 3166-1 alpha-2 country code.
+var validateCountry = validation.StringMatch(
+	regexp.MustCompile(`^[A-Z]{2}$`),
+	"must be a two-letter country code in uppercase (ISO 3166-1 alpha-2)",
+)
+
+// validateStripeAccount checks the value is the identifier of a connected account.
+var validateStripeAccount = validation.StringMatch(
+	regexp.MustCompile(`^acct_[A-Za-z0-9]+$`),
+	"must be the identifier of a connected account, e.g. acct_1032D82eZvKYlo2C",
+)
+
+// validateTimestamp checks the value is a RFC3339 timestamp.
+var validateTimestamp = validation.IsRFC3339Time
+
+// validateTimestampOrNow checks the value is a RFC3339 timestamp or the special value now.
+var validateTimestampOrNow = validation.Any(
+	validation.StringInSlice([]string{timestampNow}, false),
+	validation.IsRFC3339Time,
+)
+
+// validateAddress checks the keys of an address map.
+var validateAddress = validation.MapKeyMatch(
+	regexp.MustCompile(`^(line1|line2|city|state|postal_code|country)$`),
+	"must be one of line1, line2, city, state, postal_code or country",
+)
+
+// validateShippingAddress checks the keys of a shipping map, the address fields extended by name and phone.
+var validateShippingAddress = validation.MapKeyMatch(
+	regexp.MustCompile(`^(line1|line2|city|state|postal_code|country|name|phone)$`),
+	"must be one of line1, line2, city, state, postal_code, country, name or phone",
+)
+
+// diffKnown reports whether the planned values of all keys are known,
+// cross-field rules are only checked once the referenced values are resolved.
+func diffKnown(d *schema.ResourceDiff, keys ...string) bool {
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffError builds a plan error pointing at the attribute path.
+func diffError(path, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...))
+}
+
+// diffConfigNull reports whether the attribute is omitted from the configuration,
+// unlike NewValueKnown it tells an unset computed attribute apart from a value unknown until apply.
+func diffConfigNull(d *schema.ResourceDiff, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return config.GetAttr(key).IsNull()
+}
+
+// diffConfigSet reports whether the configuration sets the attribute, its value may be unknown until apply.
+func diffConfigSet(d *schema.ResourceDiff, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return !config.GetAttr(key).IsNull()
+}
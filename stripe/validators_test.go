@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidators(t *testing.T) {
+	testCases := map[string]struct {
+		validate schema.SchemaValidateFunc
+		valid    []interface{}
+		invalid  []interface{}
+	}{
+		"currency": {
+			validate: validateCurrency,
+			valid:    []interface{}{"usd", "eur"},
+			invalid:  []interface{}{"USD", "us", "dollar"},
+		},
+		"country": {
+			validate: validateCountry,
+			valid:    []interface{}{"DE", "US"},
+			invalid:  []interface{}{"de", "DEU"},
+		},
+		"stripe account": {
+			validate: validateStripeAccount,
+			valid:    []interface{}{"acct_1032D82eZvKYlo2C"},
+			invalid:  []interface{}{"acct_", "cus_1032D82eZvKYlo2C"},
+		},
+		"timestamp": {
+			validate: validateTimestamp,
+			valid:    []interface{}{"2030-01-01T00:00:00Z", "2030-01-01T01:00:00+01:00"},
+			invalid:  []interface{}{"now", "2030-01-01", "tomorrow"},
+		},
+		"timestamp or now": {
+			validate: validateTimestampOrNow,
+			valid:    []interface{}{"now", "2030-01-01T00:00:00Z"},
+			invalid:  []interface{}{"NOW", "2030-01-01", "tomorrow"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				if _, errs := tc.validate(value, name); len(errs) > 0 {
+					t.Errorf("expected %v to be valid, got %v", value, errs)
+				}
+			}
+			for _, value := range tc.invalid {
+				if _, errs := tc.validate(value, name); len(errs) == 0 {
+					t.Errorf("expected %v to be invalid", value)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	testCases := map[string]struct {
+		validate schema.SchemaValidateDiagFunc
+		valid    map[string]interface{}
+		invalid  map[string]interface{}
+	}{
+		"address": {
+			validate: validateAddress,
+			valid:    map[string]interface{}{"line1": "Unter den Linden 1", "postal_code": "10117", "country": "DE"},
+			invalid:  map[string]interface{}{"line1": "Unter den Linden 1", "zip": "10117"},
+		},
+		"shipping address": {
+			validate: validateShippingAddress,
+			valid:    map[string]interface{}{"name": "Jane Doe", "phone": "+4930123456", "city": "Berlin"},
+			invalid:  map[string]interface{}{"name": "Jane Doe", "email": "jane@example.com"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diags := tc.validate(tc.valid, nil); diags.HasError() {
+				t.Errorf("expected %v to be valid, got %v", tc.valid, diags)
+			}
+			if diags := tc.validate(tc.invalid, nil); !diags.HasError() {
+				t.Errorf("expected %v to be invalid", tc.invalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package stripe
+
+import "github.com/stripe/stripe-go/v78"
+
+// webhookEventTypes lists the events a webhook endpoint can be enabled for, "*" enables all of them.
+var webhookEventTypes = []string{
+	"*",
+	string(stripe.EventTypeAccountApplicationAuthorized),
+	string(stripe.EventTypeAccountApplicationDeauthorized),
+	string(stripe.EventTypeAccountExternalAccountCreated),
+	string(stripe.EventTypeAccountExternalAccountDeleted),
+	string(stripe.EventTypeAccountExternalAccountUpdated),
+	string(stripe.EventTypeAccountUpdated),
+	string(stripe.EventTypeApplicationFeeCreated),
+	string(stripe.EventTypeApplicationFeeRefundUpdated),
+	string(stripe.EventTypeApplicationFeeRefunded),
+	string(stripe.EventTypeBalanceAvailable),
+	string(stripe.EventTypeBillingPortalConfigurationCreated),
+	string(stripe.EventTypeBillingPortalConfigurationUpdated),
+	string(stripe.EventTypeBillingPortalSessionCreated),
+	string(stripe.EventTypeCapabilityUpdated),
+	string(stripe.EventTypeCashBalanceFundsAvailable),
+	string(stripe.EventTypeChargeCaptured),
+	string(stripe.EventTypeChargeDisputeClosed),
+	string(stripe.EventTypeChargeDisputeCreated),
+	string(stripe.EventTypeChargeDisputeFundsReinstated),
+	string(stripe.EventTypeChargeDisputeFundsWithdrawn),
+	string(stripe.EventTypeChargeDisputeUpdated),
+	string(stripe.EventTypeChargeExpired),
+	string(stripe.EventTypeChargeFailed),
+	string(stripe.EventTypeChargePending),
+	string(stripe.EventTypeChargeRefundUpdated),
+	string(stripe.EventTypeChargeRefunded),
+	string(stripe.EventTypeChargeSucceeded),
+	string(stripe.EventTypeChargeUpdated),
+	string(stripe.EventTypeCheckoutSessionAsyncPaymentFailed),
+	string(stripe.EventTypeCheckoutSessionAsyncPaymentSucceeded),
+	string(stripe.EventTypeCheckoutSessionCompleted),
+	string(stripe.EventTypeCheckoutSessionExpired),
+	string(stripe.EventTypeClimateOrderCanceled),
+	string(stripe.EventTypeClimateOrderCreated),
+	string(stripe.EventTypeClimateOrderDelayed),
+	string(stripe.EventTypeClimateOrderDelivered),
+	string(stripe.EventTypeClimateOrderProductSubstituted),
+	string(stripe.EventTypeClimateProductCreated),
+	string(stripe.EventTypeClimateProductPricingUpdated),
+	string(stripe.EventTypeCouponCreated),
+	string(stripe.EventTypeCouponDeleted),
+	string(stripe.EventTypeCouponUpdated),
+	string(stripe.EventTypeCreditNoteCreated),
+	string(stripe.EventTypeCreditNoteUpdated),
+	string(stripe.EventTypeCreditNoteVoided),
+	string(stripe.EventTypeCustomerCreated),
+	string(stripe.EventTypeCustomerDeleted),
+	string(stripe.EventTypeCustomerDiscountCreated),
+	string(stripe.EventTypeCustomerDiscountDeleted),
+	string(stripe.EventTypeCustomerDiscountUpdated),
+	string(stripe.EventTypeCustomerSourceCreated),
+	string(stripe.EventTypeCustomerSourceDeleted),
+	string(stripe.EventTypeCustomerSourceExpiring),
+	string(stripe.EventTypeCustomerSourceUpdated),
+	string(stripe.EventTypeCustomerSubscriptionCreated),
+	string(stripe.EventTypeCustomerSubscriptionDeleted),
+	string(stripe.EventTypeCustomerSubscriptionPaused),
+	string(stripe.EventTypeCustomerSubscriptionPendingUpdateApplied),
+	string(stripe.EventTypeCustomerSubscriptionPendingUpdateExpired),
+	string(stripe.EventTypeCustomerSubscriptionResumed),
+	string(stripe.EventTypeCustomerSubscriptionTrialWillEnd),
+	string(stripe.EventTypeCustomerSubscriptionUpdated),
+	string(stripe.EventTypeCustomerTaxIDCreated),
+	string(stripe.EventTypeCustomerTaxIDDeleted),
+	string(stripe.EventTypeCustomerTaxIDUpdated),
+	string(stripe.EventTypeCustomerUpdated),
+	string(stripe.EventTypeCustomerCashBalanceTransactionCreated),
+	string(stripe.EventTypeEntitlementsActiveEntitlementSummaryUpdated),
+	string(stripe.EventTypeFileCreated),
+	string(stripe.EventTypeFinancialConnectionsAccountCreated),
+	string(stripe.EventTypeFinancialConnectionsAccountDeactivated),
+	string(stripe.EventTypeFinancialConnectionsAccountDisconnected),
+	string(stripe.EventTypeFinancialConnectionsAccountReactivated),
+	string(stripe.EventTypeFinancialConnectionsAccountRefreshedBalance),
+	string(stripe.EventTypeFinancialConnectionsAccountRefreshedOwnership),
+	string(stripe.EventTypeFinancialConnectionsAccountRefreshedTransactions),
+	string(stripe.EventTypeIdentityVerificationSessionCanceled),
+	string(stripe.EventTypeIdentityVerificationSessionCreated),
+	string(stripe.EventTypeIdentityVerificationSessionProcessing),
+	string(stripe.EventTypeIdentityVerificationSessionRedacted),
+	string(stripe.EventTypeIdentityVerificationSessionRequiresInput),
+	string(stripe.EventTypeIdentityVerificationSessionVerified),
+	string(stripe.EventTypeInvoiceCreated),
+	string(stripe.EventTypeInvoiceDeleted),
+	string(stripe.EventTypeInvoiceFinalizationFailed),
+	string(stripe.EventTypeInvoiceFinalized),
+	string(stripe.EventTypeInvoiceMarkedUncollectible),
+	string(stripe.EventTypeInvoicePaid),
+	string(stripe.EventTypeInvoicePaymentActionRequired),
+	string(stripe.EventTypeInvoicePaymentFailed),
+	string(stripe.EventTypeInvoicePaymentSucceeded),
+	string(stripe.EventTypeInvoiceSent),
+	string(stripe.EventTypeInvoiceUpcoming),
+	string(stripe.EventTypeInvoiceUpdated),
+	string(stripe.EventTypeInvoiceVoided),
+	string(stripe.EventTypeInvoiceItemCreated),
+	string(stripe.EventTypeInvoiceItemDeleted),
+	string(stripe.EventTypeIssuingAuthorizationCreated),
+	string(stripe.EventTypeIssuingAuthorizationRequest),
+	string(stripe.EventTypeIssuingAuthorizationUpdated),
+	string(stripe.EventTypeIssuingCardCreated),
+	string(stripe.EventTypeIssuingCardUpdated),
+	string(stripe.EventTypeIssuingCardholderCreated),
+	string(stripe.EventTypeIssuingCardholderUpdated),
+	string(stripe.EventTypeIssuingDisputeClosed),
+	string(stripe.EventTypeIssuingDisputeCreated),
+	string(stripe.EventTypeIssuingDisputeFundsReinstated),
+	string(stripe.EventTypeIssuingDisputeSubmitted),
+	string(stripe.EventTypeIssuingDisputeUpdated),
+	string(stripe.EventTypeIssuingPersonalizationDesignActivated),
+	string(stripe.EventTypeIssuingPersonalizationDesignDeactivated),
+	string(stripe.EventTypeIssuingPersonalizationDesignRejected),
+	string(stripe.EventTypeIssuingPersonalizationDesignUpdated),
+	string(stripe.EventTypeIssuingTokenCreated),
+	string(stripe.EventTypeIssuingTokenUpdated),
+	string(stripe.EventTypeIssuingTransactionCreated),
+	string(stripe.EventTypeIssuingTransactionUpdated),
+	string(stripe.EventTypeMandateUpdated),
+	string(stripe.EventTypePaymentIntentAmountCapturableUpdated),
+	string(stripe.EventTypePaymentIntentCanceled),
+	string(stripe.EventTypePaymentIntentCreated),
+	string(stripe.EventTypePaymentIntentPartiallyFunded),
+	string(stripe.EventTypePaymentIntentPaymentFailed),
+	string(stripe.EventTypePaymentIntentProcessing),
+	string(stripe.EventTypePaymentIntentRequiresAction),
+	string(stripe.EventTypePaymentIntentSucceeded),
+	string(stripe.EventTypePaymentLinkCreated),
+	string(stripe.EventTypePaymentLinkUpdated),
+	string(stripe.EventTypePaymentMethodAttached),
+	string(stripe.EventTypePaymentMethodAutomaticallyUpdated),
+	string(stripe.EventTypePaymentMethodDetached),
+	string(stripe.EventTypePaymentMethodUpdated),
+	string(stripe.EventTypePayoutCanceled),
+	string(stripe.EventTypePayoutCreated),
+	string(stripe.EventTypePayoutFailed),
+	string(stripe.EventTypePayoutPaid),
+	string(stripe.EventTypePayoutReconciliationCompleted),
+	string(stripe.EventTypePayoutUpdated),
+	string(stripe.EventTypePersonCreated),
+	string(stripe.EventTypePersonDeleted),
+	string(stripe.EventTypePersonUpdated),
+	string(stripe.EventTypePlanCreated),
+	string(stripe.EventTypePlanDeleted),
+	string(stripe.EventTypePlanUpdated),
+	string(stripe.EventTypePriceCreated),
+	string(stripe.EventTypePriceDeleted),
+	string(stripe.EventTypePriceUpdated),
+	string(stripe.EventTypeProductCreated),
+	string(stripe.EventTypeProductDeleted),
+	string(stripe.EventTypeProductUpdated),
+	string(stripe.EventTypePromotionCodeCreated),
+	string(stripe.EventTypePromotionCodeUpdated),
+	string(stripe.EventTypeQuoteAccepted),
+	string(stripe.EventTypeQuoteCanceled),
+	string(stripe.EventTypeQuoteCreated),
+	string(stripe.EventTypeQuoteFinalized),
+	string(stripe.EventTypeRadarEarlyFraudWarningCreated),
+	string(stripe.EventTypeRadarEarlyFraudWarningUpdated),
+	string(stripe.EventTypeRefundCreated),
+	string(stripe.EventTypeRefundUpdated),
+	string(stripe.EventTypeReportingReportRunFailed),
+	string(stripe.EventTypeReportingReportRunSucceeded),
+	string(stripe.EventTypeReportingReportTypeUpdated),
+	string(stripe.EventTypeReviewClosed),
+	string(stripe.EventTypeReviewOpened),
+	string(stripe.EventTypeSetupIntentCanceled),
+	string(stripe.EventTypeSetupIntentCreated),
+	string(stripe.EventTypeSetupIntentRequiresAction),
+	string(stripe.EventTypeSetupIntentSetupFailed),
+	string(stripe.EventTypeSetupIntentSucceeded),
+	string(stripe.EventTypeSigmaScheduledQueryRunCreated),
+	string(stripe.EventTypeSourceCanceled),
+	string(stripe.EventTypeSourceChargeable),
+	string(stripe.EventTypeSourceFailed),
+	string(stripe.EventTypeSourceMandateNotification),
+	string(stripe.EventTypeSourceRefundAttributesRequired),
+	string(stripe.EventTypeSourceTransactionCreated),
+	string(stripe.EventTypeSourceTransactionUpdated),
+	string(stripe.EventTypeSubscriptionScheduleAborted),
+	string(stripe.EventTypeSubscriptionScheduleCanceled),
+	string(stripe.EventTypeSubscriptionScheduleCompleted),
+	string(stripe.EventTypeSubscriptionScheduleCreated),
+	string(stripe.EventTypeSubscriptionScheduleExpiring),
+	string(stripe.EventTypeSubscriptionScheduleReleased),
+	string(stripe.EventTypeSubscriptionScheduleUpdated),
+	string(stripe.EventTypeTaxSettingsUpdated),
+	string(stripe.EventTypeTaxRateCreated),
+	string(stripe.EventTypeTaxRateUpdated),
+	string(stripe.EventTypeTerminalReaderActionFailed),
+	string(stripe.EventTypeTerminalReaderActionSucceeded),
+	string(stripe.EventTypeTestHelpersTestClockAdvancing),
+	string(stripe.EventTypeTestHelpersTestClockCreated),
+	string(stripe.EventTypeTestHelpersTestClockDeleted),
+	string(stripe.EventTypeTestHelpersTestClockInternalFailure),
+	string(stripe.EventTypeTestHelpersTestClockReady),
+	string(stripe.EventTypeTopupCanceled),
+	string(stripe.EventTypeTopupCreated),
+	string(stripe.EventTypeTopupFailed),
+	string(stripe.EventTypeTopupReversed),
+	string(stripe.EventTypeTopupSucceeded),
+	string(stripe.EventTypeTransferCreated),
+	string(stripe.EventTypeTransferReversed),
+	string(stripe.EventTypeTransferUpdated),
+	string(stripe.EventTypeTreasuryCreditReversalCreated),
+	string(stripe.EventTypeTreasuryCreditReversalPosted),
+	string(stripe.EventTypeTreasuryDebitReversalCompleted),
+	string(stripe.EventTypeTreasuryDebitReversalCreated),
+	string(stripe.EventTypeTreasuryDebitReversalInitialCreditGranted),
+	string(stripe.EventTypeTreasuryFinancialAccountClosed),
+	string(stripe.EventTypeTreasuryFinancialAccountCreated),
+	string(stripe.EventTypeTreasuryFinancialAccountFeaturesStatusUpdated),
+	string(stripe.EventTypeTreasuryInboundTransferCanceled),
+	string(stripe.EventTypeTreasuryInboundTransferCreated),
+	string(stripe.EventTypeTreasuryInboundTransferFailed),
+	string(stripe.EventTypeTreasuryInboundTransferSucceeded),
+	string(stripe.EventTypeTreasuryOutboundPaymentCanceled),
+	string(stripe.EventTypeTreasuryOutboundPaymentCreated),
+	string(stripe.EventTypeTreasuryOutboundPaymentExpectedArrivalDateUpdated),
+	string(stripe.EventTypeTreasuryOutboundPaymentFailed),
+	string(stripe.EventTypeTreasuryOutboundPaymentPosted),
+	string(stripe.EventTypeTreasuryOutboundPaymentReturned),
+	string(stripe.EventTypeTreasuryOutboundPaymentTrackingDetailsUpdated),
+	string(stripe.EventTypeTreasuryOutboundTransferCanceled),
+	string(stripe.EventTypeTreasuryOutboundTransferCreated),
+	string(stripe.EventTypeTreasuryOutboundTransferExpectedArrivalDateUpdated),
+	string(stripe.EventTypeTreasuryOutboundTransferFailed),
+	string(stripe.EventTypeTreasuryOutboundTransferPosted),
+	string(stripe.EventTypeTreasuryOutboundTransferReturned),
+	string(stripe.EventTypeTreasuryOutboundTransferTrackingDetailsUpdated),
+	string(stripe.EventTypeTreasuryReceivedCreditCreated),
+	string(stripe.EventTypeTreasuryReceivedCreditFailed),
+	string(stripe.EventTypeTreasuryReceivedCreditSucceeded),
+	string(stripe.EventTypeTreasuryReceivedDebitCreated),
+}